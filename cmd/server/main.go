@@ -17,17 +17,12 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name X-API-KEY
-func main() {
-	// sdk, err := distro.Run()
-	// if err != nil {
-	// 	log.Fatalf("Failed to run distro: %v", err)
-	// }
-	// defer func() {
-	// 	if err := sdk.Shutdown(context.Background()); err != nil {
-	// 		log.Fatalf("Failed to shutdown SDK: %v", err)
-	// 	}
-	// }()
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT as "Bearer <token>"
+func main() {
 	api, cleanup, err := di.InitializeApi()
 	if err != nil {
 		log.Fatalf("Failed to initialize app: %v", err)
@@ -1,31 +1,81 @@
 package di
 
 import (
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/usecase"
 	"go-payments-api/internal/infrastructure/api/handler"
+	"go-payments-api/internal/infrastructure/rpc"
 	"go-payments-api/internal/settings"
 	"go-payments-api/pkg/api"
 	"go-payments-api/pkg/api/presenter"
+	log "go-payments-api/pkg/log/implement"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"google.golang.org/grpc"
 )
 
 var apiHandlersSet = wire.NewSet(
 	provideApiServer,
+	provideGrpcServer,
+	providePaymentService,
 	provideApiPresenter,
 	wire.Struct(new(handler.Health), "*"),
 	wire.Struct(new(handler.CreatePayment), "*"),
+	wire.Struct(new(handler.GetPayment), "*"),
+	wire.Struct(new(handler.ListPayments), "*"),
+	wire.Struct(new(handler.UpdatePaymentStatus), "*"),
+	wire.Struct(new(handler.CreateRefund), "*"),
+	wire.Struct(new(handler.ListRefunds), "*"),
+	wire.Struct(new(handler.ReceiveWebhook), "*"),
+	wire.Struct(new(handler.ReplayWebhook), "*"),
+	wire.Struct(new(handler.CreateWebhookSubscription), "*"),
+	wire.Struct(new(handler.ListWebhookSubscriptions), "*"),
+	wire.Struct(new(handler.ListWebhookDeliveries), "*"),
+	wire.Struct(new(handler.RedeliverWebhook), "*"),
+	wire.Struct(new(handler.CreateMerchant), "*"),
+	wire.Struct(new(handler.GetMerchant), "*"),
+	wire.Struct(new(handler.ListMerchants), "*"),
+	wire.Struct(new(handler.UpdateMerchant), "*"),
+	wire.Struct(new(handler.CloseMerchant), "*"),
+	wire.Struct(new(handler.GetLogLevel), "*"),
+	wire.Struct(new(handler.SetLogLevel), "*"),
 )
 
-func provideApiServer() api.Server[*gin.Engine] {
+// provideApiServer leaves the health checks out of the traces.
+func provideApiServer(logger log.Logger) (api.Server[*gin.Engine], error) {
 	return api.NewGinServer[*gin.Engine](&http.Server{
 		Addr:         settings.Settings.HttpServer.Port,
 		ReadTimeout:  settings.Settings.HttpServer.ReadTimeout,
 		WriteTimeout: settings.Settings.HttpServer.WriteTimeout,
+	}, api.GinConfig{
+		Service:        settings.Settings.Metrics.Name,
+		TrustedProxies: settings.Settings.HttpServer.TrustedProxies,
+		UntracedRoutes: []string{"/v1/payments/health"},
+		Logger:         logger,
 	})
 }
 
 func provideApiPresenter() api.Presenter {
 	return presenter.NewJson()
 }
+
+func provideGrpcServer(authenticator *auth.Authenticator, logger log.Logger) api.Server[*grpc.Server] {
+	return api.NewGrpcServer[*grpc.Server](
+		settings.Settings.GrpcServer.Port,
+		logger,
+		grpc.ChainUnaryInterceptor(rpc.UnaryAuthInterceptor(authenticator)),
+		grpc.ChainStreamInterceptor(rpc.StreamAuthInterceptor(authenticator)),
+	)
+}
+
+func providePaymentService(
+	createPayment usecase.CreatePayment,
+	getPayment usecase.GetPayment,
+	listPayments usecase.ListPayments,
+) *rpc.PaymentService {
+	return rpc.NewPaymentService(createPayment, getPayment, listPayments, rpc.PaymentServiceConfig{
+		WatchInterval: settings.Settings.GrpcServer.WatchInterval,
+	})
+}
@@ -1,5 +1,73 @@
 package di
 
-import "github.com/google/wire"
+import (
+	"context"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/infrastructure/api/middleware"
+	"go-payments-api/internal/infrastructure/jwt"
+	"go-payments-api/internal/settings"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/pkg/ratelimit"
 
-var apiMiddlewaresSet = wire.NewSet()
+	"github.com/google/wire"
+)
+
+var apiMiddlewaresSet = wire.NewSet(
+	provideTokenVerifier,
+	auth.NewAuthenticator,
+	wire.Struct(new(middleware.Auth), "*"),
+	provideRateLimitStore,
+	wire.Struct(new(middleware.RateLimit), "*"),
+	provideCorsMiddleware,
+	wire.Struct(new(middleware.Metrics)),
+)
+
+// provideRateLimitStore keeps the rate limit buckets in memory, so each
+// instance enforces the limits on its own. A ratelimit.DistributedStore over
+// a shared backend makes them global.
+func provideRateLimitStore() ratelimit.Store {
+	return ratelimit.NewMemoryStore()
+}
+
+// provideCorsMiddleware exposes the trace ID header of the presenter next to
+// the configured headers, so browsers can report it.
+func provideCorsMiddleware(apiPresenter api.Presenter) (*middleware.Cors, error) {
+	spec := settings.Settings.Cors
+	return middleware.NewCors(middleware.CorsConfig{
+		AllowedOrigins:   spec.AllowedOrigins,
+		AllowedMethods:   spec.AllowedMethods,
+		AllowedHeaders:   spec.AllowedHeaders,
+		ExposedHeaders:   append([]string{presenter.TraceIDHeader}, spec.ExposedHeaders...),
+		AllowCredentials: spec.AllowCredentials,
+		MaxAge:           spec.MaxAge,
+		RouteOrigins:     spec.Routes,
+	}, apiPresenter)
+}
+
+// provideTokenVerifier returns nil, rejecting every bearer token, when no
+// JWT keys are configured.
+func provideTokenVerifier() (auth.TokenVerifier, func(), error) {
+	spec := settings.Settings.Auth
+	if len(spec.JWKSURLs) == 0 && spec.StaticJWKS == "" {
+		return nil, func() {}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	verifier, err := jwt.NewVerifier(ctx, jwt.Config{
+		JWKSURLs:        spec.JWKSURLs,
+		RefreshInterval: spec.JWKSRefreshInterval,
+		StaticJWKS:      spec.StaticJWKS,
+		Algorithms:      spec.Algorithms,
+		Issuer:          spec.Issuer,
+		Audience:        spec.Audience,
+		Leeway:          spec.Leeway,
+		MerchantClaim:   spec.MerchantClaim,
+	})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return verifier, cancel, nil
+}
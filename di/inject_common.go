@@ -1,25 +1,123 @@
 package di
 
 import (
+	"context"
 	"go-payments-api/internal/application"
+	"go-payments-api/internal/settings"
 	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/redact"
+	"time"
 
 	"github.com/google/wire"
-	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var commonSet = wire.NewSet(
 	provideLogger,
+	provideResource,
+	provideTracerProvider,
+	wire.Bind(new(trace.TracerProvider), new(*sdktrace.TracerProvider)),
 	provideTracer,
+	providePropagator,
+	provideMeterProvider,
+	provideRedactor,
 	gatewaysSet,
 	wire.Struct(new(application.App), "*"),
 )
 
-func provideLogger() log.Logger {
-	return log.NewLogrus()
+func provideLogger() (log.Logger, error) {
+	level, err := log.LevelFromString(settings.Settings.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, err := log.FormatFromString(settings.Settings.Log.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []log.LoggerOption{log.WithLevel(level), log.WithFormat(format)}
+	for level, sampling := range settings.Settings.Log.Sampling {
+		options = append(options, log.WithSampling(level, sampling))
+	}
+
+	return log.NewLogrus(options...), nil
+}
+
+func provideRedactor() *redact.Redactor {
+	return redact.New(redact.Config{
+		Fields:  redact.DefaultFields(),
+		Rules:   redact.DefaultRules(),
+		HashKey: []byte(settings.Settings.Redaction.HashKey),
+	})
+}
+
+func provideResource() (*resource.Resource, error) {
+	spec := settings.Settings.Metrics
+	return metrics.NewResource(spec.Name, spec.Resource)
+}
+
+// otlpConfig sends to the configured collector, with the Splunk access token
+// when there is one.
+func otlpConfig() metrics.OTLPConfig {
+	spec := settings.Settings.Metrics
+	config := metrics.OTLPConfig{Endpoint: spec.Url, Protocol: spec.Protocol}
+	if spec.Token != "" {
+		config.Headers = map[string]string{"X-SF-Token": spec.Token}
+	}
+	return config
 }
 
-func provideTracer() trace.Tracer {
-	return otel.Tracer("")
+// provideTracerProvider flushes the spans still in the batch on cleanup.
+func provideTracerProvider(res *resource.Resource, logger log.Logger) (*sdktrace.TracerProvider, func(), error) {
+	spec := settings.Settings.Metrics
+	provider, err := metrics.NewTracerProvider(context.Background(), metrics.TracerConfig{
+		Resource:    res,
+		Exporter:    spec.TracesExporter,
+		OTLP:        otlpConfig(),
+		SampleRatio: spec.SampleRatio,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return provider, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.Errorf("failed to shutdown the tracer provider: %v", err)
+		}
+	}, nil
+}
+
+func provideTracer(provider *sdktrace.TracerProvider) trace.Tracer {
+	return provider.Tracer("go-payments-api")
+}
+
+func providePropagator() (propagation.TextMapPropagator, error) {
+	return metrics.NewPropagator(settings.Settings.Metrics.Propagators)
+}
+
+// provideMeterProvider flushes the pending metrics on cleanup, so the last
+// OTLP export isn't lost on shutdown.
+func provideMeterProvider(res *resource.Resource, logger log.Logger) (*metrics.MeterProvider, func(), error) {
+	provider, err := metrics.NewMeterProvider(context.Background(), metrics.MeterConfig{
+		Resource:  res,
+		Exporters: settings.Settings.Metrics.MetricsExporter,
+		OTLP:      otlpConfig(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return provider, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.Errorf("failed to shutdown the meter provider: %v", err)
+		}
+	}, nil
 }
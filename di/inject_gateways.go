@@ -1,6 +1,10 @@
 package di
 
 import (
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/infrastructure/provider/simulator"
+	"go-payments-api/internal/settings"
 	"go-payments-api/pkg/http"
 
 	"github.com/google/wire"
@@ -9,4 +13,18 @@ import (
 var gatewaysSet = wire.NewSet(
 	http.NewWrapper,
 	wire.Bind(new(http.Wrapper), new(*http.WrapperImpl)),
+	provideProviderRegistry,
 )
+
+func provideProviderRegistry() *provider.Registry {
+	sim := simulator.New(simulator.Config{
+		Latency:     settings.Settings.Provider.SimulatorLatency,
+		DeclineRate: settings.Settings.Provider.SimulatorDeclineRate,
+		ErrorRate:   settings.Settings.Provider.SimulatorErrorRate,
+	})
+
+	return provider.NewRegistry(map[string]provider.Provider{
+		entity.MethodPix:  sim,
+		entity.MethodCard: sim,
+	})
+}
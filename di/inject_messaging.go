@@ -1,16 +1,114 @@
 package di
 
 import (
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/infrastructure/messaging/consumer"
 	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/internal/infrastructure/messaging/outbox"
+	"go-payments-api/internal/infrastructure/messaging/schemas"
 	"go-payments-api/internal/settings"
+	"go-payments-api/pkg/cloudevents"
+	"go-payments-api/pkg/http"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/schemaregistry"
 
 	"github.com/google/wire"
 )
 
 var messagingSet = wire.NewSet(
+	provideKafkaAuth,
+	provideSchemaRegistry,
+	provideKafkaSerializer,
 	provideKafkaPublisher,
+	provideOutboxRelay,
+	provideStatusUpdatesConsumer,
 )
 
-func provideKafkaPublisher() kafka.Publisher {
-	return kafka.NewPublisher(settings.Settings.Kafka.Brokers)
+func provideSchemaRegistry(wrapper http.Wrapper) schemaregistry.Registry {
+	if settings.Settings.Kafka.SchemaRegistryURL == "" {
+		return schemaregistry.NewInMemory()
+	}
+
+	return schemaregistry.NewClient(
+		settings.Settings.Kafka.SchemaRegistryURL,
+		settings.Settings.Kafka.SchemaRegistryUsername,
+		settings.Settings.Kafka.SchemaRegistryPassword,
+		wrapper,
+	)
+}
+
+func provideKafkaSerializer(registry schemaregistry.Registry) (kafka.Serializer, error) {
+	return kafka.NewSerializer(kafka.Format(settings.Settings.Kafka.Serializer), registry, map[string]kafka.Schema{
+		"payment.events": schemas.PaymentEvents,
+		settings.Settings.Kafka.StatusUpdatesDLQTopic: schemas.DeadLetters,
+	})
+}
+
+func provideKafkaAuth() (kafka.Auth, error) {
+	return kafka.NewAuth(kafka.AuthConfig{
+		SASLMechanism:      settings.Settings.Kafka.SASLMechanism,
+		Username:           settings.Settings.Kafka.SASLUsername,
+		Password:           settings.Settings.Kafka.SASLPassword,
+		TLS:                settings.Settings.Kafka.TLSEnabled,
+		CAFile:             settings.Settings.Kafka.TLSCAFile,
+		InsecureSkipVerify: settings.Settings.Kafka.TLSInsecureSkipVerify,
+	})
+}
+
+// provideKafkaPublisher is the producer of the outbox relay and of the dead
+// letters of the consumer. It's always synchronous: both settle their work,
+// marking the outbox message published or committing the offset, only once
+// Publish confirms the write.
+func provideKafkaPublisher(serializer kafka.Serializer, auth kafka.Auth, logger log.Logger) (kafka.Publisher, error) {
+	topics := make([]kafka.TopicConfig, 0, len(settings.Settings.Kafka.Topics))
+	for _, topic := range settings.Settings.Kafka.Topics {
+		topics = append(topics, kafka.TopicConfig{
+			Name:              topic.Name,
+			Partitions:        topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+		})
+	}
+
+	return kafka.NewPublisher(kafka.ProducerConfig{
+		Brokers:      settings.Settings.Kafka.Brokers,
+		RequiredAcks: settings.Settings.Kafka.RequiredAcks,
+		Compression:  settings.Settings.Kafka.Compression,
+		Balancer:     settings.Settings.Kafka.Balancer,
+		BatchSize:    settings.Settings.Kafka.BatchSize,
+		BatchBytes:   settings.Settings.Kafka.BatchBytes,
+		BatchTimeout: settings.Settings.Kafka.BatchTimeout,
+		Topics:       topics,
+		Auth:         auth,
+	}, serializer, logger)
+}
+
+func provideOutboxRelay(
+	outboxRepository repository.OutboxRepository,
+	transactor repository.Transactor,
+	publisher kafka.Publisher,
+	webhookDeliveryRepository repository.WebhookDeliveryRepository,
+) *outbox.Relay {
+	return outbox.NewRelay(outboxRepository, transactor, publisher, webhookDeliveryRepository, outbox.Config{
+		PollInterval: settings.Settings.Outbox.PollInterval,
+		BatchSize:    settings.Settings.Outbox.BatchSize,
+		BaseBackoff:  settings.Settings.Outbox.BaseBackoff,
+		MaxBackoff:   settings.Settings.Outbox.MaxBackoff,
+		ContentType:  cloudevents.ContentType(settings.Settings.Kafka.Serializer),
+	})
+}
+
+func provideStatusUpdatesConsumer(useCase usecase.ApplyStatusUpdate, publisher kafka.Publisher, auth kafka.Auth) *kafka.Consumer {
+	statusUpdates := consumer.NewStatusUpdates(useCase, settings.Settings.Kafka.StatusUpdatesProvider)
+
+	return kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:      settings.Settings.Kafka.Brokers,
+		GroupID:      settings.Settings.Kafka.ConsumerGroup,
+		Topic:        settings.Settings.Kafka.StatusUpdatesTopic,
+		DLQTopic:     settings.Settings.Kafka.StatusUpdatesDLQTopic,
+		MaxRetries:   settings.Settings.Kafka.MaxRetries,
+		RetryBackoff: settings.Settings.Kafka.RetryBackoff,
+		MaxBackoff:   settings.Settings.Kafka.MaxBackoff,
+		Auth:         auth,
+	}, statusUpdates.Handle, publisher)
 }
@@ -3,25 +3,36 @@ package di
 import (
 	"go-payments-api/internal/application/gateway/repository"
 	"go-payments-api/internal/infrastructure/database/postgres"
-	"log"
+	log "go-payments-api/pkg/log/implement"
 
 	"github.com/google/wire"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var repositoriesSet = wire.NewSet(
 	ProvidePostgresConnection,
 	ProvidePaymentRepository,
+	ProvideRefundRepository,
+	ProvidePixChargeRepository,
+	ProvideWebhookEventRepository,
+	ProvideWebhookSubscriptionRepository,
+	ProvideWebhookDeliveryRepository,
+	ProvideIdempotencyKeyRepository,
+	ProvideApiKeyRepository,
+	ProvideMerchantRepository,
+	ProvideOutboxRepository,
+	ProvideTransactor,
 )
 
-func ProvidePostgresConnection() (*postgres.DB, func(), error) {
-	db, err := postgres.NewConnection()
+func ProvidePostgresConnection(logger log.Logger, tracerProvider trace.TracerProvider) (*postgres.DB, func(), error) {
+	db, err := postgres.NewConnection(logger, tracerProvider)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	cleanup := func() {
 		if err := db.Close(); err != nil {
-			log.Printf("Error closing database: %v", err)
+			logger.Errorf("failed to close database: %v", err)
 		}
 	}
 
@@ -31,3 +42,43 @@ func ProvidePostgresConnection() (*postgres.DB, func(), error) {
 func ProvidePaymentRepository(db *postgres.DB) repository.PaymentRepository {
 	return postgres.NewPaymentRepository(db.GetConnection())
 }
+
+func ProvideRefundRepository(db *postgres.DB) repository.RefundRepository {
+	return postgres.NewRefundRepository(db.GetConnection())
+}
+
+func ProvidePixChargeRepository(db *postgres.DB) repository.PixChargeRepository {
+	return postgres.NewPixChargeRepository(db.GetConnection())
+}
+
+func ProvideWebhookEventRepository(db *postgres.DB) repository.WebhookEventRepository {
+	return postgres.NewWebhookEventRepository(db.GetConnection())
+}
+
+func ProvideWebhookSubscriptionRepository(db *postgres.DB) repository.WebhookSubscriptionRepository {
+	return postgres.NewWebhookSubscriptionRepository(db.GetConnection())
+}
+
+func ProvideWebhookDeliveryRepository(db *postgres.DB) repository.WebhookDeliveryRepository {
+	return postgres.NewWebhookDeliveryRepository(db.GetConnection())
+}
+
+func ProvideIdempotencyKeyRepository(db *postgres.DB) repository.IdempotencyKeyRepository {
+	return postgres.NewIdempotencyKeyRepository(db.GetConnection())
+}
+
+func ProvideApiKeyRepository(db *postgres.DB) repository.ApiKeyRepository {
+	return postgres.NewApiKeyRepository(db.GetConnection())
+}
+
+func ProvideMerchantRepository(db *postgres.DB) repository.MerchantRepository {
+	return postgres.NewMerchantRepository(db.GetConnection())
+}
+
+func ProvideOutboxRepository(db *postgres.DB) repository.OutboxRepository {
+	return postgres.NewOutboxRepository(db.GetConnection())
+}
+
+func ProvideTransactor(db *postgres.DB) repository.Transactor {
+	return postgres.NewTransactor(db.GetConnection())
+}
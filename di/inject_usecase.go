@@ -2,15 +2,146 @@ package di
 
 import (
 	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/settings"
 
 	"github.com/google/wire"
 )
 
 var provideCreatePaymentUseCase = wire.NewSet(
+	providePixConfig,
 	usecase.NewCreatePaymentUseCase,
-	wire.Bind(new(usecase.CreatePayment), new(*usecase.CreatePaymentImplementation)),
+	usecase.NewIdempotentCreatePaymentUseCase,
+	wire.Bind(new(usecase.CreatePayment), new(*usecase.IdempotentCreatePaymentImplementation)),
+)
+
+var provideGetPaymentUseCase = wire.NewSet(
+	usecase.NewGetPaymentUseCase,
+	wire.Bind(new(usecase.GetPayment), new(*usecase.GetPaymentImplementation)),
+)
+
+var provideListPaymentsUseCase = wire.NewSet(
+	usecase.NewListPaymentsUseCase,
+	wire.Bind(new(usecase.ListPayments), new(*usecase.ListPaymentsImplementation)),
+)
+
+var provideUpdatePaymentStatusUseCase = wire.NewSet(
+	usecase.NewUpdatePaymentStatusUseCase,
+	wire.Bind(new(usecase.UpdatePaymentStatus), new(*usecase.UpdatePaymentStatusImplementation)),
+)
+
+var provideCreateRefundUseCase = wire.NewSet(
+	usecase.NewCreateRefundUseCase,
+	wire.Bind(new(usecase.CreateRefund), new(*usecase.CreateRefundImplementation)),
+)
+
+var provideListRefundsUseCase = wire.NewSet(
+	usecase.NewListRefundsUseCase,
+	wire.Bind(new(usecase.ListRefunds), new(*usecase.ListRefundsImplementation)),
+)
+
+var provideExpirePixChargesUseCase = wire.NewSet(
+	usecase.NewExpirePixChargesUseCase,
+	wire.Bind(new(usecase.ExpirePixCharges), new(*usecase.ExpirePixChargesImplementation)),
+)
+
+var provideReceiveWebhookUseCase = wire.NewSet(
+	provideWebhookConfig,
+	usecase.NewReceiveWebhookUseCase,
+	wire.Bind(new(usecase.ReceiveWebhook), new(*usecase.ReceiveWebhookImplementation)),
+)
+
+var provideReplayWebhookUseCase = wire.NewSet(
+	usecase.NewReplayWebhookUseCase,
+	wire.Bind(new(usecase.ReplayWebhook), new(*usecase.ReplayWebhookImplementation)),
+)
+
+var provideCreateWebhookSubscriptionUseCase = wire.NewSet(
+	provideWebhookSubscriptionConfig,
+	usecase.NewCreateWebhookSubscriptionUseCase,
+	wire.Bind(new(usecase.CreateWebhookSubscription), new(*usecase.CreateWebhookSubscriptionImplementation)),
+)
+
+var provideListWebhookSubscriptionsUseCase = wire.NewSet(
+	usecase.NewListWebhookSubscriptionsUseCase,
+	wire.Bind(new(usecase.ListWebhookSubscriptions), new(*usecase.ListWebhookSubscriptionsImplementation)),
+)
+
+var provideListWebhookDeliveriesUseCase = wire.NewSet(
+	usecase.NewListWebhookDeliveriesUseCase,
+	wire.Bind(new(usecase.ListWebhookDeliveries), new(*usecase.ListWebhookDeliveriesImplementation)),
+)
+
+var provideRedeliverWebhookUseCase = wire.NewSet(
+	usecase.NewRedeliverWebhookUseCase,
+	wire.Bind(new(usecase.RedeliverWebhook), new(*usecase.RedeliverWebhookImplementation)),
+)
+
+var provideCreateMerchantUseCase = wire.NewSet(
+	usecase.NewCreateMerchantUseCase,
+	wire.Bind(new(usecase.CreateMerchant), new(*usecase.CreateMerchantImplementation)),
+)
+
+var provideGetMerchantUseCase = wire.NewSet(
+	usecase.NewGetMerchantUseCase,
+	wire.Bind(new(usecase.GetMerchant), new(*usecase.GetMerchantImplementation)),
+)
+
+var provideListMerchantsUseCase = wire.NewSet(
+	usecase.NewListMerchantsUseCase,
+	wire.Bind(new(usecase.ListMerchants), new(*usecase.ListMerchantsImplementation)),
+)
+
+var provideUpdateMerchantUseCase = wire.NewSet(
+	usecase.NewUpdateMerchantUseCase,
+	wire.Bind(new(usecase.UpdateMerchant), new(*usecase.UpdateMerchantImplementation)),
+)
+
+var provideApplyStatusUpdateUseCase = wire.NewSet(
+	usecase.NewApplyStatusUpdateUseCase,
+	wire.Bind(new(usecase.ApplyStatusUpdate), new(*usecase.ApplyStatusUpdateImplementation)),
 )
 
 var usecasesSet = wire.NewSet(
 	provideCreatePaymentUseCase,
+	provideGetPaymentUseCase,
+	provideListPaymentsUseCase,
+	provideUpdatePaymentStatusUseCase,
+	provideCreateRefundUseCase,
+	provideListRefundsUseCase,
+	provideExpirePixChargesUseCase,
+	provideReceiveWebhookUseCase,
+	provideReplayWebhookUseCase,
+	provideApplyStatusUpdateUseCase,
+	provideCreateWebhookSubscriptionUseCase,
+	provideListWebhookSubscriptionsUseCase,
+	provideListWebhookDeliveriesUseCase,
+	provideRedeliverWebhookUseCase,
+	provideCreateMerchantUseCase,
+	provideGetMerchantUseCase,
+	provideListMerchantsUseCase,
+	provideUpdateMerchantUseCase,
 )
+
+func providePixConfig() usecase.PixConfig {
+	return usecase.PixConfig{
+		Key:          settings.Settings.Pix.Key,
+		MerchantName: settings.Settings.Pix.MerchantName,
+		MerchantCity: settings.Settings.Pix.MerchantCity,
+		Expiration:   settings.Settings.Pix.Expiration,
+		QRCodeSize:   settings.Settings.Pix.QRCodeSize,
+	}
+}
+
+func provideWebhookConfig() usecase.WebhookConfig {
+	return usecase.WebhookConfig{
+		Secrets:   settings.Settings.ProviderWebhook.Secrets,
+		Tolerance: settings.Settings.ProviderWebhook.Tolerance,
+	}
+}
+
+func provideWebhookSubscriptionConfig() usecase.WebhookSubscriptionConfig {
+	return usecase.WebhookSubscriptionConfig{
+		RequireHTTPS:         !settings.Settings.IsDevelopment(),
+		AllowPrivateNetworks: settings.Settings.MerchantWebhook.AllowPrivateNetworks,
+	}
+}
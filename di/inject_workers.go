@@ -0,0 +1,40 @@
+package di
+
+import (
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/infrastructure/sweeper"
+	"go-payments-api/internal/infrastructure/webhook"
+	"go-payments-api/internal/settings"
+	"go-payments-api/pkg/http"
+
+	"github.com/google/wire"
+)
+
+var workersSet = wire.NewSet(
+	providePixExpirySweeper,
+	provideWebhookDispatcher,
+)
+
+func providePixExpirySweeper(useCase usecase.ExpirePixCharges) *sweeper.PixExpiry {
+	return sweeper.NewPixExpiry(useCase, sweeper.Config{
+		Interval:  settings.Settings.Pix.SweepInterval,
+		BatchSize: settings.Settings.Pix.SweepBatchSize,
+	})
+}
+
+func provideWebhookDispatcher(deliveries repository.WebhookDeliveryRepository) *webhook.Dispatcher {
+	client := http.NewPublicWrapper()
+	if settings.Settings.MerchantWebhook.AllowPrivateNetworks {
+		client = http.NewWrapper()
+	}
+
+	return webhook.NewDispatcher(deliveries, client, webhook.Config{
+		PollInterval: settings.Settings.MerchantWebhook.PollInterval,
+		BatchSize:    settings.Settings.MerchantWebhook.BatchSize,
+		MaxAttempts:  settings.Settings.MerchantWebhook.MaxAttempts,
+		BaseBackoff:  settings.Settings.MerchantWebhook.BaseBackoff,
+		MaxBackoff:   settings.Settings.MerchantWebhook.MaxBackoff,
+		Timeout:      settings.Settings.MerchantWebhook.Timeout,
+	})
+}
@@ -16,6 +16,7 @@ var wireApiSet = wire.NewSet(
 	repositoriesSet,
 	messagingSet,
 	usecasesSet,
+	workersSet,
 
 	apiMiddlewaresSet,
 	apiHandlersSet,
@@ -28,6 +29,7 @@ var wireTestSet = wire.NewSet(
 	repositoriesSet,
 	messagingSet,
 	usecasesSet,
+	workersSet,
 
 	apiMiddlewaresSet,
 	apiHandlersSet,
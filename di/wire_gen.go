@@ -9,77 +9,529 @@ package di
 import (
 	"github.com/google/wire"
 	"go-payments-api/internal/application"
+	"go-payments-api/internal/application/auth"
 	"go-payments-api/internal/application/usecase"
 	"go-payments-api/internal/infrastructure/api"
 	"go-payments-api/internal/infrastructure/api/handler"
+	"go-payments-api/internal/infrastructure/api/middleware"
 	"go-payments-api/internal/test"
+	"go-payments-api/pkg/http"
 	"go.uber.org/mock/gomock"
 )
 
 // Injectors from wire.go:
 
 func InitializeApi() (*api.Application, func(), error) {
-	logger := provideLogger()
-	tracer := provideTracer()
+	logger, err := provideLogger()
+	if err != nil {
+		return nil, nil, err
+	}
+	resource, err := provideResource()
+	if err != nil {
+		return nil, nil, err
+	}
+	tracerProvider, cleanup, err := provideTracerProvider(resource, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	tracer := provideTracer(tracerProvider)
+	redactor := provideRedactor()
+	meterProvider, cleanup2, err := provideMeterProvider(resource, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	textMapPropagator, err := providePropagator()
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	app := &application.App{
-		Logger: logger,
-		Tracer: tracer,
+		Logger:         logger,
+		Tracer:         tracer,
+		Redactor:       redactor,
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+		Propagator:     textMapPropagator,
 	}
-	server := provideApiServer()
-	presenter := provideApiPresenter()
-	health := &handler.Health{
-		Presenter: presenter,
+	server, err := provideApiServer(logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	db, cleanup3, err := ProvidePostgresConnection(logger, tracerProvider)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
-	db, cleanup, err := ProvidePostgresConnection()
+	apiKeyRepository := ProvideApiKeyRepository(db)
+	tokenVerifier, cleanup4, err := provideTokenVerifier()
 	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
+	authenticator := auth.NewAuthenticator(apiKeyRepository, tokenVerifier)
+	apiServer := provideGrpcServer(authenticator, logger)
 	paymentRepository := ProvidePaymentRepository(db)
-	publisher := provideKafkaPublisher()
-	createPaymentImplementation := usecase.NewCreatePaymentUseCase(paymentRepository, publisher)
+	merchantRepository := ProvideMerchantRepository(db)
+	outboxRepository := ProvideOutboxRepository(db)
+	transactor := ProvideTransactor(db)
+	registry := provideProviderRegistry()
+	pixChargeRepository := ProvidePixChargeRepository(db)
+	pixConfig := providePixConfig()
+	createPaymentImplementation := usecase.NewCreatePaymentUseCase(paymentRepository, merchantRepository, outboxRepository, transactor, registry, pixChargeRepository, pixConfig)
+	idempotencyKeyRepository := ProvideIdempotencyKeyRepository(db)
+	idempotentCreatePaymentImplementation := usecase.NewIdempotentCreatePaymentUseCase(createPaymentImplementation, idempotencyKeyRepository, transactor)
+	getPaymentImplementation := usecase.NewGetPaymentUseCase(paymentRepository)
+	listPaymentsImplementation := usecase.NewListPaymentsUseCase(paymentRepository)
+	paymentService := providePaymentService(idempotentCreatePaymentImplementation, getPaymentImplementation, listPaymentsImplementation)
+	wrapperImpl := http.NewWrapper()
+	schemaregistryRegistry := provideSchemaRegistry(wrapperImpl)
+	serializer, err := provideKafkaSerializer(schemaregistryRegistry)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	kafkaAuth, err := provideKafkaAuth()
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	publisher, err := provideKafkaPublisher(serializer, kafkaAuth, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	webhookDeliveryRepository := ProvideWebhookDeliveryRepository(db)
+	relay := provideOutboxRelay(outboxRepository, transactor, publisher, webhookDeliveryRepository)
+	expirePixChargesImplementation := usecase.NewExpirePixChargesUseCase(paymentRepository, pixChargeRepository, outboxRepository, transactor, registry)
+	pixExpiry := providePixExpirySweeper(expirePixChargesImplementation)
+	dispatcher := provideWebhookDispatcher(webhookDeliveryRepository)
+	webhookEventRepository := ProvideWebhookEventRepository(db)
+	applyStatusUpdateImplementation := usecase.NewApplyStatusUpdateUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor)
+	consumer := provideStatusUpdatesConsumer(applyStatusUpdateImplementation, publisher, kafkaAuth)
+	presenter := provideApiPresenter()
+	cors, err := provideCorsMiddleware(presenter)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	middlewareAuth := &middleware.Auth{
+		Authenticator: authenticator,
+		Presenter:     presenter,
+	}
+	store := provideRateLimitStore()
+	rateLimit := &middleware.RateLimit{
+		Store:     store,
+		Presenter: presenter,
+	}
+	metrics := &middleware.Metrics{}
+	health := &handler.Health{
+		Presenter: presenter,
+	}
 	createPayment := &handler.CreatePayment{
-		UseCase:   createPaymentImplementation,
+		UseCase:   idempotentCreatePaymentImplementation,
+		Presenter: presenter,
+	}
+	getPayment := &handler.GetPayment{
+		UseCase:   getPaymentImplementation,
+		Presenter: presenter,
+	}
+	listPayments := &handler.ListPayments{
+		UseCase:   listPaymentsImplementation,
+		Presenter: presenter,
+	}
+	updatePaymentStatusImplementation := usecase.NewUpdatePaymentStatusUseCase(paymentRepository, outboxRepository, transactor)
+	updatePaymentStatus := &handler.UpdatePaymentStatus{
+		UseCase:   updatePaymentStatusImplementation,
+		Presenter: presenter,
+	}
+	refundRepository := ProvideRefundRepository(db)
+	createRefundImplementation := usecase.NewCreateRefundUseCase(paymentRepository, refundRepository, outboxRepository, transactor, registry)
+	createRefund := &handler.CreateRefund{
+		UseCase:   createRefundImplementation,
+		Presenter: presenter,
+	}
+	listRefundsImplementation := usecase.NewListRefundsUseCase(paymentRepository, refundRepository)
+	listRefunds := &handler.ListRefunds{
+		UseCase:   listRefundsImplementation,
+		Presenter: presenter,
+	}
+	webhookConfig := provideWebhookConfig()
+	receiveWebhookImplementation := usecase.NewReceiveWebhookUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor, webhookConfig)
+	receiveWebhook := &handler.ReceiveWebhook{
+		UseCase:   receiveWebhookImplementation,
+		Presenter: presenter,
+	}
+	replayWebhookImplementation := usecase.NewReplayWebhookUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor)
+	replayWebhook := &handler.ReplayWebhook{
+		UseCase:   replayWebhookImplementation,
+		Presenter: presenter,
+	}
+	webhookSubscriptionRepository := ProvideWebhookSubscriptionRepository(db)
+	webhookSubscriptionConfig := provideWebhookSubscriptionConfig()
+	createWebhookSubscriptionImplementation := usecase.NewCreateWebhookSubscriptionUseCase(webhookSubscriptionRepository, webhookSubscriptionConfig)
+	createWebhookSubscription := &handler.CreateWebhookSubscription{
+		UseCase:   createWebhookSubscriptionImplementation,
+		Presenter: presenter,
+	}
+	listWebhookSubscriptionsImplementation := usecase.NewListWebhookSubscriptionsUseCase(webhookSubscriptionRepository)
+	listWebhookSubscriptions := &handler.ListWebhookSubscriptions{
+		UseCase:   listWebhookSubscriptionsImplementation,
+		Presenter: presenter,
+	}
+	listWebhookDeliveriesImplementation := usecase.NewListWebhookDeliveriesUseCase(webhookDeliveryRepository)
+	listWebhookDeliveries := &handler.ListWebhookDeliveries{
+		UseCase:   listWebhookDeliveriesImplementation,
+		Presenter: presenter,
+	}
+	redeliverWebhookImplementation := usecase.NewRedeliverWebhookUseCase(webhookDeliveryRepository)
+	redeliverWebhook := &handler.RedeliverWebhook{
+		UseCase:   redeliverWebhookImplementation,
+		Presenter: presenter,
+	}
+	createMerchantImplementation := usecase.NewCreateMerchantUseCase(merchantRepository)
+	createMerchant := &handler.CreateMerchant{
+		UseCase:   createMerchantImplementation,
+		Presenter: presenter,
+	}
+	getMerchantImplementation := usecase.NewGetMerchantUseCase(merchantRepository)
+	getMerchant := &handler.GetMerchant{
+		UseCase:   getMerchantImplementation,
+		Presenter: presenter,
+	}
+	listMerchantsImplementation := usecase.NewListMerchantsUseCase(merchantRepository)
+	listMerchants := &handler.ListMerchants{
+		UseCase:   listMerchantsImplementation,
+		Presenter: presenter,
+	}
+	updateMerchantImplementation := usecase.NewUpdateMerchantUseCase(merchantRepository)
+	updateMerchant := &handler.UpdateMerchant{
+		UseCase:   updateMerchantImplementation,
+		Presenter: presenter,
+	}
+	closeMerchant := &handler.CloseMerchant{
+		UseCase:   updateMerchantImplementation,
+		Presenter: presenter,
+	}
+	getLogLevel := &handler.GetLogLevel{
+		Logger:    logger,
+		Presenter: presenter,
+	}
+	setLogLevel := &handler.SetLogLevel{
+		Logger:    logger,
 		Presenter: presenter,
 	}
 	apiApplication := &api.Application{
-		BaseApp:              app,
-		Server:               server,
-		HealthHandler:        health,
-		CreatePaymentHandler: createPayment,
+		BaseApp:                          app,
+		Server:                           server,
+		GrpcServer:                       apiServer,
+		PaymentService:                   paymentService,
+		OutboxRelay:                      relay,
+		PixExpirySweeper:                 pixExpiry,
+		WebhookDispatcher:                dispatcher,
+		StatusUpdatesConsumer:            consumer,
+		CorsMiddleware:                   cors,
+		AuthMiddleware:                   middlewareAuth,
+		RateLimitMiddleware:              rateLimit,
+		MetricsMiddleware:                metrics,
+		HealthHandler:                    health,
+		CreatePaymentHandler:             createPayment,
+		GetPaymentHandler:                getPayment,
+		ListPaymentsHandler:              listPayments,
+		UpdatePaymentStatusHandler:       updatePaymentStatus,
+		CreateRefundHandler:              createRefund,
+		ListRefundsHandler:               listRefunds,
+		ReceiveWebhookHandler:            receiveWebhook,
+		ReplayWebhookHandler:             replayWebhook,
+		CreateWebhookSubscriptionHandler: createWebhookSubscription,
+		ListWebhookSubscriptionsHandler:  listWebhookSubscriptions,
+		ListWebhookDeliveriesHandler:     listWebhookDeliveries,
+		RedeliverWebhookHandler:          redeliverWebhook,
+		CreateMerchantHandler:            createMerchant,
+		GetMerchantHandler:               getMerchant,
+		ListMerchantsHandler:             listMerchants,
+		UpdateMerchantHandler:            updateMerchant,
+		CloseMerchantHandler:             closeMerchant,
+		GetLogLevelHandler:               getLogLevel,
+		SetLogLevelHandler:               setLogLevel,
 	}
 	return apiApplication, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
 
 func InitilizeTests(mockCtrl *gomock.Controller) (*test.Application, func(), error) {
-	logger := provideLogger()
-	tracer := provideTracer()
+	logger, err := provideLogger()
+	if err != nil {
+		return nil, nil, err
+	}
+	resource, err := provideResource()
+	if err != nil {
+		return nil, nil, err
+	}
+	tracerProvider, cleanup, err := provideTracerProvider(resource, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	tracer := provideTracer(tracerProvider)
+	redactor := provideRedactor()
+	meterProvider, cleanup2, err := provideMeterProvider(resource, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	textMapPropagator, err := providePropagator()
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	app := &application.App{
-		Logger: logger,
-		Tracer: tracer,
+		Logger:         logger,
+		Tracer:         tracer,
+		Redactor:       redactor,
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+		Propagator:     textMapPropagator,
 	}
-	server := provideApiServer()
-	presenter := provideApiPresenter()
-	health := &handler.Health{
-		Presenter: presenter,
+	server, err := provideApiServer(logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	db, cleanup3, err := ProvidePostgresConnection(logger, tracerProvider)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
-	db, cleanup, err := ProvidePostgresConnection()
+	apiKeyRepository := ProvideApiKeyRepository(db)
+	tokenVerifier, cleanup4, err := provideTokenVerifier()
 	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
+	authenticator := auth.NewAuthenticator(apiKeyRepository, tokenVerifier)
+	apiServer := provideGrpcServer(authenticator, logger)
 	paymentRepository := ProvidePaymentRepository(db)
-	publisher := provideKafkaPublisher()
-	createPaymentImplementation := usecase.NewCreatePaymentUseCase(paymentRepository, publisher)
+	merchantRepository := ProvideMerchantRepository(db)
+	outboxRepository := ProvideOutboxRepository(db)
+	transactor := ProvideTransactor(db)
+	registry := provideProviderRegistry()
+	pixChargeRepository := ProvidePixChargeRepository(db)
+	pixConfig := providePixConfig()
+	createPaymentImplementation := usecase.NewCreatePaymentUseCase(paymentRepository, merchantRepository, outboxRepository, transactor, registry, pixChargeRepository, pixConfig)
+	idempotencyKeyRepository := ProvideIdempotencyKeyRepository(db)
+	idempotentCreatePaymentImplementation := usecase.NewIdempotentCreatePaymentUseCase(createPaymentImplementation, idempotencyKeyRepository, transactor)
+	getPaymentImplementation := usecase.NewGetPaymentUseCase(paymentRepository)
+	listPaymentsImplementation := usecase.NewListPaymentsUseCase(paymentRepository)
+	paymentService := providePaymentService(idempotentCreatePaymentImplementation, getPaymentImplementation, listPaymentsImplementation)
+	wrapperImpl := http.NewWrapper()
+	schemaregistryRegistry := provideSchemaRegistry(wrapperImpl)
+	serializer, err := provideKafkaSerializer(schemaregistryRegistry)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	kafkaAuth, err := provideKafkaAuth()
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	publisher, err := provideKafkaPublisher(serializer, kafkaAuth, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	webhookDeliveryRepository := ProvideWebhookDeliveryRepository(db)
+	relay := provideOutboxRelay(outboxRepository, transactor, publisher, webhookDeliveryRepository)
+	expirePixChargesImplementation := usecase.NewExpirePixChargesUseCase(paymentRepository, pixChargeRepository, outboxRepository, transactor, registry)
+	pixExpiry := providePixExpirySweeper(expirePixChargesImplementation)
+	dispatcher := provideWebhookDispatcher(webhookDeliveryRepository)
+	webhookEventRepository := ProvideWebhookEventRepository(db)
+	applyStatusUpdateImplementation := usecase.NewApplyStatusUpdateUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor)
+	consumer := provideStatusUpdatesConsumer(applyStatusUpdateImplementation, publisher, kafkaAuth)
+	presenter := provideApiPresenter()
+	cors, err := provideCorsMiddleware(presenter)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	middlewareAuth := &middleware.Auth{
+		Authenticator: authenticator,
+		Presenter:     presenter,
+	}
+	store := provideRateLimitStore()
+	rateLimit := &middleware.RateLimit{
+		Store:     store,
+		Presenter: presenter,
+	}
+	metrics := &middleware.Metrics{}
+	health := &handler.Health{
+		Presenter: presenter,
+	}
 	createPayment := &handler.CreatePayment{
-		UseCase:   createPaymentImplementation,
+		UseCase:   idempotentCreatePaymentImplementation,
+		Presenter: presenter,
+	}
+	getPayment := &handler.GetPayment{
+		UseCase:   getPaymentImplementation,
+		Presenter: presenter,
+	}
+	listPayments := &handler.ListPayments{
+		UseCase:   listPaymentsImplementation,
+		Presenter: presenter,
+	}
+	updatePaymentStatusImplementation := usecase.NewUpdatePaymentStatusUseCase(paymentRepository, outboxRepository, transactor)
+	updatePaymentStatus := &handler.UpdatePaymentStatus{
+		UseCase:   updatePaymentStatusImplementation,
+		Presenter: presenter,
+	}
+	refundRepository := ProvideRefundRepository(db)
+	createRefundImplementation := usecase.NewCreateRefundUseCase(paymentRepository, refundRepository, outboxRepository, transactor, registry)
+	createRefund := &handler.CreateRefund{
+		UseCase:   createRefundImplementation,
+		Presenter: presenter,
+	}
+	listRefundsImplementation := usecase.NewListRefundsUseCase(paymentRepository, refundRepository)
+	listRefunds := &handler.ListRefunds{
+		UseCase:   listRefundsImplementation,
+		Presenter: presenter,
+	}
+	webhookConfig := provideWebhookConfig()
+	receiveWebhookImplementation := usecase.NewReceiveWebhookUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor, webhookConfig)
+	receiveWebhook := &handler.ReceiveWebhook{
+		UseCase:   receiveWebhookImplementation,
+		Presenter: presenter,
+	}
+	replayWebhookImplementation := usecase.NewReplayWebhookUseCase(paymentRepository, webhookEventRepository, outboxRepository, transactor)
+	replayWebhook := &handler.ReplayWebhook{
+		UseCase:   replayWebhookImplementation,
+		Presenter: presenter,
+	}
+	webhookSubscriptionRepository := ProvideWebhookSubscriptionRepository(db)
+	webhookSubscriptionConfig := provideWebhookSubscriptionConfig()
+	createWebhookSubscriptionImplementation := usecase.NewCreateWebhookSubscriptionUseCase(webhookSubscriptionRepository, webhookSubscriptionConfig)
+	createWebhookSubscription := &handler.CreateWebhookSubscription{
+		UseCase:   createWebhookSubscriptionImplementation,
+		Presenter: presenter,
+	}
+	listWebhookSubscriptionsImplementation := usecase.NewListWebhookSubscriptionsUseCase(webhookSubscriptionRepository)
+	listWebhookSubscriptions := &handler.ListWebhookSubscriptions{
+		UseCase:   listWebhookSubscriptionsImplementation,
+		Presenter: presenter,
+	}
+	listWebhookDeliveriesImplementation := usecase.NewListWebhookDeliveriesUseCase(webhookDeliveryRepository)
+	listWebhookDeliveries := &handler.ListWebhookDeliveries{
+		UseCase:   listWebhookDeliveriesImplementation,
+		Presenter: presenter,
+	}
+	redeliverWebhookImplementation := usecase.NewRedeliverWebhookUseCase(webhookDeliveryRepository)
+	redeliverWebhook := &handler.RedeliverWebhook{
+		UseCase:   redeliverWebhookImplementation,
+		Presenter: presenter,
+	}
+	createMerchantImplementation := usecase.NewCreateMerchantUseCase(merchantRepository)
+	createMerchant := &handler.CreateMerchant{
+		UseCase:   createMerchantImplementation,
+		Presenter: presenter,
+	}
+	getMerchantImplementation := usecase.NewGetMerchantUseCase(merchantRepository)
+	getMerchant := &handler.GetMerchant{
+		UseCase:   getMerchantImplementation,
+		Presenter: presenter,
+	}
+	listMerchantsImplementation := usecase.NewListMerchantsUseCase(merchantRepository)
+	listMerchants := &handler.ListMerchants{
+		UseCase:   listMerchantsImplementation,
+		Presenter: presenter,
+	}
+	updateMerchantImplementation := usecase.NewUpdateMerchantUseCase(merchantRepository)
+	updateMerchant := &handler.UpdateMerchant{
+		UseCase:   updateMerchantImplementation,
+		Presenter: presenter,
+	}
+	closeMerchant := &handler.CloseMerchant{
+		UseCase:   updateMerchantImplementation,
+		Presenter: presenter,
+	}
+	getLogLevel := &handler.GetLogLevel{
+		Logger:    logger,
+		Presenter: presenter,
+	}
+	setLogLevel := &handler.SetLogLevel{
+		Logger:    logger,
 		Presenter: presenter,
 	}
 	apiApplication := &api.Application{
-		BaseApp:              app,
-		Server:               server,
-		HealthHandler:        health,
-		CreatePaymentHandler: createPayment,
+		BaseApp:                          app,
+		Server:                           server,
+		GrpcServer:                       apiServer,
+		PaymentService:                   paymentService,
+		OutboxRelay:                      relay,
+		PixExpirySweeper:                 pixExpiry,
+		WebhookDispatcher:                dispatcher,
+		StatusUpdatesConsumer:            consumer,
+		CorsMiddleware:                   cors,
+		AuthMiddleware:                   middlewareAuth,
+		RateLimitMiddleware:              rateLimit,
+		MetricsMiddleware:                metrics,
+		HealthHandler:                    health,
+		CreatePaymentHandler:             createPayment,
+		GetPaymentHandler:                getPayment,
+		ListPaymentsHandler:              listPayments,
+		UpdatePaymentStatusHandler:       updatePaymentStatus,
+		CreateRefundHandler:              createRefund,
+		ListRefundsHandler:               listRefunds,
+		ReceiveWebhookHandler:            receiveWebhook,
+		ReplayWebhookHandler:             replayWebhook,
+		CreateWebhookSubscriptionHandler: createWebhookSubscription,
+		ListWebhookSubscriptionsHandler:  listWebhookSubscriptions,
+		ListWebhookDeliveriesHandler:     listWebhookDeliveries,
+		RedeliverWebhookHandler:          redeliverWebhook,
+		CreateMerchantHandler:            createMerchant,
+		GetMerchantHandler:               getMerchant,
+		ListMerchantsHandler:             listMerchants,
+		UpdateMerchantHandler:            updateMerchant,
+		CloseMerchantHandler:             closeMerchant,
+		GetLogLevelHandler:               getLogLevel,
+		SetLogLevelHandler:               setLogLevel,
 	}
 	testApplication := &test.Application{
 		BaseApp:  app,
@@ -87,6 +539,9 @@ func InitilizeTests(mockCtrl *gomock.Controller) (*test.Application, func(), err
 		MockCtrl: mockCtrl,
 	}
 	return testApplication, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -98,6 +553,7 @@ var wireApiSet = wire.NewSet(
 	repositoriesSet,
 	messagingSet,
 	usecasesSet,
+	workersSet,
 
 	apiMiddlewaresSet,
 	apiHandlersSet, wire.Struct(new(api.Application), "*"),
@@ -108,6 +564,7 @@ var wireTestSet = wire.NewSet(
 	repositoriesSet,
 	messagingSet,
 	usecasesSet,
+	workersSet,
 
 	apiMiddlewaresSet,
 	apiHandlersSet, wire.Struct(new(api.Application), "*"), wire.Struct(new(test.Application), "*"),
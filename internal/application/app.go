@@ -1,9 +1,12 @@
 package application
 
 import (
-	"go-payments-api/internal/settings"
 	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/redact"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 
 	log2 "go-payments-api/pkg/log"
@@ -11,20 +14,26 @@ import (
 )
 
 type App struct {
-	Logger log.Logger
-	Tracer trace.Tracer
+	Logger   log.Logger
+	Tracer   trace.Tracer
+	Redactor *redact.Redactor
+
+	// The providers and the propagator become the global ones, so the
+	// instrumentations of gin, gRPC and otel.Meter use them too
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *metrics2.MeterProvider
+	Propagator     propagation.TextMapPropagator
 }
 
 func (a *App) Start(serviceName string) {
+	redact.Default = a.Redactor
+	a.Logger = a.Logger.Tag("service", serviceName)
 	log2.Logger = a.Logger
 
-	if settings.Settings.IsLocal() {
-		metrics2.Tracer = trace.NewNoopTracerProvider().Tracer("local")
-	} else {
-		metrics2.Tracer = a.Tracer
-	}
-
-	a.Logger.Tag("service", serviceName)
+	otel.SetTracerProvider(a.TracerProvider)
+	otel.SetMeterProvider(a.MeterProvider)
+	otel.SetTextMapPropagator(a.Propagator)
+	metrics2.Tracer = a.Tracer
 }
 
 func (a *App) Stop() {
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go-payments-api/internal/application/gateway/repository"
+	appErr "go-payments-api/pkg/errors"
+	"strings"
+	"time"
+)
+
+const bearerPrefix = "Bearer "
+
+// TokenVerifier checks a bearer token and returns the principal it was
+// issued to.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (Principal, error)
+}
+
+// Credentials are what a request presented to authenticate. ApiKey wins when
+// both are set.
+type Credentials struct {
+	ApiKey      string
+	BearerToken string
+}
+
+// BearerToken returns the token of an Authorization header value, or an
+// empty string when it isn't a bearer one.
+func BearerToken(authorization string) string {
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(bearerPrefix):])
+}
+
+type Authenticator struct {
+	apiKeys repository.ApiKeyRepository
+	tokens  TokenVerifier
+}
+
+// NewAuthenticator returns an Authenticator accepting API keys stored in
+// apiKeys and, when tokens isn't nil, bearer tokens it verifies.
+func NewAuthenticator(apiKeys repository.ApiKeyRepository, tokens TokenVerifier) *Authenticator {
+	return &Authenticator{
+		apiKeys: apiKeys,
+		tokens:  tokens,
+	}
+}
+
+// Authenticate returns the principal of credentials. Missing or invalid
+// credentials are appErr.Unauthorized errors that don't tell which check
+// failed.
+func (a *Authenticator) Authenticate(ctx context.Context, credentials Credentials) (Principal, error) {
+	switch {
+	case credentials.ApiKey != "":
+		return a.authenticateApiKey(ctx, credentials.ApiKey)
+	case credentials.BearerToken != "" && a.tokens != nil:
+		principal, err := a.tokens.Verify(ctx, credentials.BearerToken)
+		if err != nil {
+			return Principal{}, appErr.NewUnauthorized("invalid bearer token")
+		}
+		return principal, nil
+	}
+
+	return Principal{}, appErr.NewUnauthorized("missing credentials")
+}
+
+func (a *Authenticator) authenticateApiKey(ctx context.Context, key string) (Principal, error) {
+	apiKey, err := a.apiKeys.FindByHash(ctx, HashApiKey(key))
+	if err != nil {
+		return Principal{}, fmt.Errorf("failed to find API key: %w", err)
+	}
+
+	if apiKey == nil || !apiKey.IsUsable(time.Now()) {
+		return Principal{}, appErr.NewUnauthorized("invalid API key")
+	}
+
+	return Principal{
+		Subject:    apiKey.Prefix,
+		MerchantID: apiKey.MerchantID,
+		Method:     MethodApiKey,
+		Scopes:     apiKey.Scopes,
+		ApiKeyID:   apiKey.ID,
+	}, nil
+}
+
+// HashApiKey returns the hex encoded SHA-256 API keys are stored by.
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
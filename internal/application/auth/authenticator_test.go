@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type tokenVerifierFunc func(ctx context.Context, token string) (Principal, error)
+
+func (f tokenVerifierFunc) Verify(ctx context.Context, token string) (Principal, error) {
+	return f(ctx, token)
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	past := time.Now().Add(-time.Minute)
+	apiKeys := repository.NewMockApiKeyRepository(ctrl)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), HashApiKey("pk_valid")).
+		Return(&entity.ApiKey{MerchantID: 7, Prefix: "pk_valid", Scopes: []string{"payments"}}, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), HashApiKey("pk_revoked")).
+		Return(&entity.ApiKey{MerchantID: 7, RevokedAt: &past}, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), HashApiKey("pk_expired")).
+		Return(&entity.ApiKey{MerchantID: 7, ExpiresAt: &past}, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), HashApiKey("pk_unknown")).Return(nil, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), HashApiKey("pk_error")).Return(nil, errors.New("connection refused"))
+
+	authenticator := NewAuthenticator(apiKeys, nil)
+
+	principal, err := authenticator.Authenticate(context.Background(), Credentials{ApiKey: "pk_valid"})
+	assert.NoError(t, err)
+	assert.Equal(t, Principal{Subject: "pk_valid", MerchantID: 7, Method: MethodApiKey, Scopes: []string{"payments"}}, principal)
+	assert.True(t, principal.HasScope("payments"))
+
+	for _, key := range []string{"pk_revoked", "pk_expired", "pk_unknown"} {
+		_, err = authenticator.Authenticate(context.Background(), Credentials{ApiKey: key})
+		assert.IsType(t, appErr.Unauthorized{}, err, key)
+	}
+
+	_, err = authenticator.Authenticate(context.Background(), Credentials{ApiKey: "pk_error"})
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestAuthenticateBearerToken(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	tokens := tokenVerifierFunc(func(_ context.Context, token string) (Principal, error) {
+		if token != "valid" {
+			return Principal{}, errors.New("signature is invalid")
+		}
+		return Principal{Subject: "user-1", MerchantID: 7, Method: MethodJWT}, nil
+	})
+	authenticator := NewAuthenticator(repository.NewMockApiKeyRepository(ctrl), tokens)
+
+	principal, err := authenticator.Authenticate(context.Background(), Credentials{BearerToken: "valid"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), principal.MerchantID)
+
+	_, err = authenticator.Authenticate(context.Background(), Credentials{BearerToken: "forged"})
+	assert.IsType(t, appErr.Unauthorized{}, err)
+
+	_, err = authenticator.Authenticate(context.Background(), Credentials{})
+	assert.IsType(t, appErr.Unauthorized{}, err)
+
+	// bearer tokens are rejected when no verifier is configured
+	_, err = NewAuthenticator(nil, nil).Authenticate(context.Background(), Credentials{BearerToken: "valid"})
+	assert.IsType(t, appErr.Unauthorized{}, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "abc.def.ghi", BearerToken("Bearer abc.def.ghi"))
+	assert.Equal(t, "abc.def.ghi", BearerToken("bearer  abc.def.ghi "))
+	assert.Equal(t, "", BearerToken("Basic dXNlcjpwYXNz"))
+	assert.Equal(t, "", BearerToken("Bearer"))
+	assert.Equal(t, "", BearerToken(""))
+}
+
+func TestCanAccess(t *testing.T) {
+	assert.True(t, CanAccess(context.Background(), 7))
+	assert.Equal(t, int64(0), MerchantID(context.Background()))
+
+	ctx := NewContext(context.Background(), Principal{MerchantID: 7})
+	assert.True(t, CanAccess(ctx, 7))
+	assert.False(t, CanAccess(ctx, 8))
+	assert.False(t, CanAccess(ctx, 0))
+	assert.Equal(t, int64(7), MerchantID(ctx))
+}
@@ -0,0 +1,63 @@
+// Package auth identifies who is calling the API and which merchant they act
+// for. The HTTP and gRPC servers authenticate every request into a Principal
+// carried by its context, and use cases read it to scope what they touch to
+// that merchant.
+package auth
+
+import "context"
+
+type Method string
+
+const (
+	MethodApiKey Method = "api_key"
+	MethodJWT    Method = "jwt"
+)
+
+// ScopeAdmin lets the caller manage the platform, such as its merchants,
+// besides acting for its own merchant.
+const ScopeAdmin = "admin"
+
+type Principal struct {
+	// Subject is the API key prefix or the JWT subject
+	Subject    string
+	MerchantID int64
+	Method     Method
+	Scopes     []string
+
+	// ApiKeyID is the API key the caller authenticated with, 0 for JWTs
+	ApiKeyID int64
+}
+
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// MerchantID returns the merchant the caller acts for, or 0 for internal
+// callers such as workers and consumers, which aren't scoped to one.
+func MerchantID(ctx context.Context) int64 {
+	principal, _ := FromContext(ctx)
+	return principal.MerchantID
+}
+
+// CanAccess reports whether the caller may see a resource owned by
+// merchantID. Internal callers may see every resource.
+func CanAccess(ctx context.Context, merchantID int64) bool {
+	principal, ok := FromContext(ctx)
+	return !ok || principal.MerchantID == merchantID
+}
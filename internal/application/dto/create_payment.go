@@ -1,25 +1,52 @@
 package dto
 
-import "time"
+import (
+	"go-payments-api/internal/domain/money"
+	"time"
+)
 
 type CreatePaymentInput struct {
-	Amount float64 `json:"amount" binding:"required,gt=0" example:"100.50"`
-	Method string  `json:"method" binding:"required,oneof=PIX CARD" example:"PIX"`
+	Amount money.Money `json:"amount"`
+	Method string      `json:"method" binding:"required,oneof=PIX CARD" example:"PIX"`
+
+	// IdempotencyKey comes from the Idempotency-Key header
+	IdempotencyKey string `json:"-" swaggerignore:"true"`
 }
 
 type CreatePaymentOutput struct {
-	ID        int64     `json:"id" example:"1"`
-	Amount    float64   `json:"amount" example:"100.50"`
-	Method    string    `json:"method" example:"PIX"`
-	Status    string    `json:"status" example:"CREATED"`
-	CreatedAt time.Time `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	ID        int64       `json:"id" example:"1"`
+	Amount    money.Money `json:"amount"`
+	Method    string      `json:"method" example:"PIX"`
+	Status    string      `json:"status" example:"CREATED"`
+	CreatedAt time.Time   `json:"created_at" example:"2024-01-01T10:00:00Z"`
+
+	// Pix is the charge the payer uses to pay PIX payments
+	Pix *PixChargeOutput `json:"pix,omitempty"`
+
+	// Replayed tells the output was stored by a previous request with the
+	// same idempotency key
+	Replayed bool `json:"-"`
+
+	// StatusCode is the HTTP status the stored response was first sent
+	// with, set on replays
+	StatusCode int `json:"-"`
+}
+
+type PixChargeOutput struct {
+	TxID string `json:"txid" example:"PAY0000000000000000000001"`
+
+	// Payload is the BR Code "copia e cola"
+	Payload string `json:"payload" example:"00020101021226...6304ABCD"`
+
+	// QRCode is the payload as a base64 encoded PNG image
+	QRCode    string    `json:"qr_code" example:"iVBORw0KGgo..."`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-01-01T10:30:00Z"`
 }
 
 type PaymentEvent struct {
-	ID        int64     `json:"id"`
-	Amount    float64   `json:"amount"`
-	Method    string    `json:"method"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	EventType string    `json:"event_type"`
+	ID        int64       `json:"id"`
+	Amount    money.Money `json:"amount"`
+	Method    string      `json:"method"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
 }
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type ExpirePixChargesInput struct {
+	Now   time.Time
+	Limit int
+}
+
+type ExpirePixChargesOutput struct {
+	// Found is how many expired charges were still waiting to be paid
+	Found int
+
+	// Expired is how many of their payments were moved to EXPIRED
+	Expired int
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"go-payments-api/internal/domain/money"
+	"time"
+)
+
+type GetPaymentInput struct {
+	ID int64 `uri:"id" binding:"required,gt=0" example:"1"`
+}
+
+type GetPaymentOutput struct {
+	ID        int64       `json:"id" example:"1"`
+	Amount    money.Money `json:"amount"`
+	Method    string      `json:"method" example:"PIX"`
+	Status    string      `json:"status" example:"CREATED"`
+	CreatedAt time.Time   `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt time.Time   `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"go-payments-api/pkg/constants"
+	"time"
+)
+
+type ListPaymentsInput struct {
+	Status      string               `form:"status" binding:"omitempty,oneof=CREATED PROCESSING COMPLETED FAILED CANCELED REFUNDED EXPIRED PARTIALLY_REFUNDED" example:"CREATED"`
+	Method      string               `form:"method" binding:"omitempty,oneof=PIX CARD" example:"PIX"`
+	Currency    string               `form:"currency" binding:"omitempty,len=3" example:"BRL"`
+	MinAmount   string               `form:"min_amount" example:"10.00"`
+	MaxAmount   string               `form:"max_amount" example:"500.00"`
+	CreatedFrom time.Time            `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-01-01T00:00:00Z"`
+	CreatedTo   time.Time            `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-02-01T00:00:00Z"`
+	Cursor      string               `form:"cursor"`
+	Limit       constants.PageLength `form:"limit" binding:"omitempty,oneof=10 50 100" example:"10"`
+}
+
+type ListPaymentsOutput struct {
+	Data       []GetPaymentOutput `json:"data"`
+	NextCursor string             `json:"next_cursor,omitempty" example:"MTcwNDEwMzIwMDAwMDAwMDAwMDoxMA"`
+	HasMore    bool               `json:"has_more" example:"true"`
+}
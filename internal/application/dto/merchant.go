@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type Settlement struct {
+	Schedule  string `json:"schedule" binding:"required,oneof=DAILY WEEKLY MONTHLY" example:"DAILY"`
+	DelayDays int    `json:"delay_days" binding:"min=0,max=90" example:"1"`
+}
+
+type CreateMerchantInput struct {
+	Name           string   `json:"name" binding:"required,max=255" example:"Acme Store"`
+	PaymentMethods []string `json:"payment_methods" binding:"required,min=1,dive,oneof=PIX CARD" example:"PIX,CARD"`
+
+	// Settlement defaults to DAILY, D+1
+	Settlement *Settlement `json:"settlement"`
+}
+
+// UpdateMerchantInput changes the fields that are set and keeps the others.
+type UpdateMerchantInput struct {
+	ID             int64       `json:"-" swaggerignore:"true"`
+	Name           *string     `json:"name" binding:"omitempty,min=1,max=255" example:"Acme Store"`
+	Status         *string     `json:"status" binding:"omitempty,oneof=ACTIVE SUSPENDED CLOSED" example:"SUSPENDED"`
+	PaymentMethods []string    `json:"payment_methods" binding:"omitempty,min=1,dive,oneof=PIX CARD" example:"PIX"`
+	Settlement     *Settlement `json:"settlement"`
+}
+
+type GetMerchantInput struct {
+	ID int64 `uri:"id" binding:"required,gt=0" example:"1"`
+}
+
+type ListMerchantsInput struct {
+	Status string `form:"status" binding:"omitempty,oneof=ACTIVE SUSPENDED CLOSED" example:"ACTIVE"`
+}
+
+type MerchantOutput struct {
+	ID             int64      `json:"id" example:"1"`
+	Name           string     `json:"name" example:"Acme Store"`
+	Status         string     `json:"status" example:"ACTIVE"`
+	PaymentMethods []string   `json:"payment_methods" example:"PIX,CARD"`
+	Settlement     Settlement `json:"settlement"`
+	CreatedAt      time.Time  `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt      time.Time  `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+}
+
+type ListMerchantsOutput struct {
+	Data []MerchantOutput `json:"data"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"go-payments-api/internal/domain/money"
+	"time"
+)
+
+type CreateRefundInput struct {
+	PaymentID int64 `json:"-" swaggerignore:"true"`
+
+	// Amount defaults to what is left to refund of the payment
+	Amount *money.Money `json:"amount,omitempty"`
+	Reason string       `json:"reason" binding:"max=255" example:"customer request"`
+}
+
+type RefundOutput struct {
+	ID        int64       `json:"id" example:"1"`
+	PaymentID int64       `json:"payment_id" example:"1"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status" example:"COMPLETED"`
+	Reason    string      `json:"reason" example:"customer request"`
+	CreatedAt time.Time   `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt time.Time   `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+}
+
+type CreateRefundOutput struct {
+	RefundOutput
+	PaymentStatus string `json:"payment_status" example:"PARTIALLY_REFUNDED"`
+}
+
+type ListRefundsOutput struct {
+	Data []RefundOutput `json:"data"`
+}
+
+type RefundEvent struct {
+	ID        int64       `json:"id"`
+	PaymentID int64       `json:"payment_id"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	Reason    string      `json:"reason"`
+	CreatedAt time.Time   `json:"created_at"`
+}
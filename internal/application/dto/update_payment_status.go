@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// UpdatePaymentStatusInput only takes the statuses that move no money.
+// Payments are completed by the provider capture and refunded through the
+// refunds endpoint.
+type UpdatePaymentStatusInput struct {
+	ID     int64  `json:"-" swaggerignore:"true"`
+	Status string `json:"status" binding:"required,oneof=PROCESSING FAILED CANCELED" example:"CANCELED"`
+}
+
+type UpdatePaymentStatusOutput struct {
+	ID             int64     `json:"id" example:"1"`
+	PreviousStatus string    `json:"previous_status" example:"CREATED"`
+	Status         string    `json:"status" example:"PROCESSING"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2024-01-01T10:05:00Z"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+type ReceiveWebhookInput struct {
+	Provider  string
+	Timestamp string
+	Signature string
+	Body      []byte
+}
+
+type ReplayWebhookInput struct {
+	Provider string `uri:"provider" binding:"required"`
+	EventID  string `uri:"event_id" binding:"required"`
+}
+
+type WebhookOutput struct {
+	EventID string `json:"event_id" example:"evt_123"`
+	Status  string `json:"status" example:"PROCESSED"`
+	Error   string `json:"error,omitempty" example:"payment not found"`
+
+	// Duplicate tells the event had already been received
+	Duplicate bool `json:"duplicate" example:"false"`
+}
+
+// ProviderNotification is the body providers send to report that the
+// payment they know by Reference reached Status.
+type ProviderNotification struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+}
+
+// StatusUpdateInput is a ProviderNotification consumed from the provider
+// status updates topic instead of received as a webhook.
+type StatusUpdateInput struct {
+	Provider string
+	Body     []byte
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/json"
+	"go-payments-api/pkg/constants"
+	"time"
+)
+
+type CreateWebhookSubscriptionInput struct {
+	URL string `json:"url" binding:"required,url,max=2048" example:"https://merchant.example.com/webhooks"`
+
+	// EventTypes limits the events delivered, all of them when empty
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,required,max=100" example:"payment.completed,refund.completed"`
+}
+
+type WebhookSubscriptionOutput struct {
+	ID         int64     `json:"id" example:"1"`
+	URL        string    `json:"url" example:"https://merchant.example.com/webhooks"`
+	EventTypes []string  `json:"event_types" example:"payment.completed,refund.completed"`
+	Active     bool      `json:"active" example:"true"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-01T10:00:00Z"`
+}
+
+type CreateWebhookSubscriptionOutput struct {
+	WebhookSubscriptionOutput
+
+	// Secret signs every delivery and is only shown once
+	Secret string `json:"secret" example:"whsec_4f9c2d..."`
+}
+
+type ListWebhookSubscriptionsInput struct{}
+
+type ListWebhookSubscriptionsOutput struct {
+	Data []WebhookSubscriptionOutput `json:"data"`
+}
+
+type ListWebhookDeliveriesInput struct {
+	SubscriptionID int64                `form:"subscription_id" binding:"omitempty,gt=0" example:"1"`
+	Status         string               `form:"status" binding:"omitempty,oneof=PENDING DELIVERED DEAD" example:"DEAD"`
+	Cursor         string               `form:"cursor"`
+	Limit          constants.PageLength `form:"limit" binding:"omitempty,oneof=10 50 100" example:"10"`
+}
+
+type WebhookDeliveryOutput struct {
+	ID             int64           `json:"id" example:"1"`
+	SubscriptionID int64           `json:"subscription_id" example:"1"`
+	EventType      string          `json:"event_type" example:"payment.completed"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status" example:"DEAD"`
+	Attempts       int             `json:"attempts" example:"8"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" example:"2024-01-01T10:00:00Z"`
+	LastError      string          `json:"last_error,omitempty" example:"endpoint responded with status 503"`
+	ResponseStatus int             `json:"response_status,omitempty" example:"503"`
+	CreatedAt      time.Time       `json:"created_at" example:"2024-01-01T10:00:00Z"`
+	UpdatedAt      time.Time       `json:"updated_at" example:"2024-01-01T10:00:00Z"`
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Data       []WebhookDeliveryOutput `json:"data"`
+	NextCursor string                  `json:"next_cursor,omitempty" example:"MTA"`
+	HasMore    bool                    `json:"has_more" example:"true"`
+}
+
+type RedeliverWebhookInput struct {
+	ID int64 `uri:"id" binding:"required,gt=0" example:"1"`
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+)
+
+// Status is the state of an operation as reported by the provider.
+type Status string
+
+const (
+	StatusPending    Status = "PENDING"
+	StatusAuthorized Status = "AUTHORIZED"
+	StatusCaptured   Status = "CAPTURED"
+	StatusDeclined   Status = "DECLINED"
+	StatusVoided     Status = "VOIDED"
+	StatusRefunded   Status = "REFUNDED"
+)
+
+// ErrUnknownReference is returned when the provider has no operation under
+// the given reference.
+var ErrUnknownReference = errors.New("unknown provider reference")
+
+// Result is what the provider answered to an operation. Reference identifies
+// the payment on the provider side and must be sent back on later calls.
+type Result struct {
+	Reference string
+	Status    Status
+	Message   string
+}
+
+// Provider processes payments of one or more methods on an external
+// acquirer or PSP.
+type Provider interface {
+	Authorize(ctx context.Context, payment *entity.Payment) (*Result, error)
+	Capture(ctx context.Context, reference string, amount money.Money) (*Result, error)
+	Void(ctx context.Context, reference string) (*Result, error)
+	Refund(ctx context.Context, reference string, amount money.Money) (*Result, error)
+	GetStatus(ctx context.Context, reference string) (*Result, error)
+}
@@ -0,0 +1,21 @@
+package provider
+
+import "fmt"
+
+// Registry picks the provider that processes each payment method.
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers map[string]Provider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// For returns the provider registered for method.
+func (r *Registry) For(method string) (Provider, error) {
+	provider, ok := r.providers[method]
+	if !ok {
+		return nil, fmt.Errorf("no provider registered for method %s", method)
+	}
+	return provider, nil
+}
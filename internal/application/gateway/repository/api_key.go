@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+)
+
+type ApiKeyRepository interface {
+	// FindByHash returns the key whose SHA-256 is hash, or nil when there is
+	// none.
+	FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error)
+}
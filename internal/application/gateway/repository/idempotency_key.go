@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+)
+
+type IdempotencyKeyRepository interface {
+	// Lock serializes every caller of the merchant using the same key until
+	// the transaction carried by ctx ends, and returns the record saved by a
+	// previous request with the same key, or nil when this is the first one.
+	// Keys of different merchants never collide.
+	Lock(ctx context.Context, merchantID int64, key string) (*entity.IdempotencyKey, error)
+
+	// Save stores the record in the transaction carried by ctx, so it
+	// commits together with whatever the request wrote.
+	Save(ctx context.Context, record *entity.IdempotencyKey) error
+
+	// Complete stores the status code and the response of a saved record.
+	Complete(ctx context.Context, record *entity.IdempotencyKey) error
+}
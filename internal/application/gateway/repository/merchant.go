@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+)
+
+type MerchantRepository interface {
+	Create(ctx context.Context, merchant *entity.Merchant) error
+
+	// FindByID returns the merchant with id, or nil when there is none
+	FindByID(ctx context.Context, id int64) (*entity.Merchant, error)
+
+	// List returns the merchants in status, or all of them when status is
+	// empty, ordered by id
+	List(ctx context.Context, status entity.MerchantStatus) ([]*entity.Merchant, error)
+	Update(ctx context.Context, merchant *entity.Merchant) error
+}
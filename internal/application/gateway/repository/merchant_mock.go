@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/merchant.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/merchant.go -destination=repository/merchant_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	entity "go-payments-api/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMerchantRepository is a mock of MerchantRepository interface.
+type MockMerchantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMerchantRepositoryMockRecorder is the mock recorder for MockMerchantRepository.
+type MockMerchantRepositoryMockRecorder struct {
+	mock *MockMerchantRepository
+}
+
+// NewMockMerchantRepository creates a new mock instance.
+func NewMockMerchantRepository(ctrl *gomock.Controller) *MockMerchantRepository {
+	mock := &MockMerchantRepository{ctrl: ctrl}
+	mock.recorder = &MockMerchantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantRepository) EXPECT() *MockMerchantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMerchantRepository) Create(ctx context.Context, merchant *entity.Merchant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, merchant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMerchantRepositoryMockRecorder) Create(ctx, merchant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMerchantRepository)(nil).Create), ctx, merchant)
+}
+
+// FindByID mocks base method.
+func (m *MockMerchantRepository) FindByID(ctx context.Context, id int64) (*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockMerchantRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockMerchantRepository)(nil).FindByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockMerchantRepository) List(ctx context.Context, status entity.MerchantStatus) ([]*entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, status)
+	ret0, _ := ret[0].([]*entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMerchantRepositoryMockRecorder) List(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMerchantRepository)(nil).List), ctx, status)
+}
+
+// Update mocks base method.
+func (m *MockMerchantRepository) Update(ctx context.Context, merchant *entity.Merchant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, merchant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMerchantRepositoryMockRecorder) Update(ctx, merchant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMerchantRepository)(nil).Update), ctx, merchant)
+}
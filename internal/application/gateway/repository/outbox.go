@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message *entity.OutboxMessage) error
+
+	// FetchPending returns messages due for publishing in insertion order,
+	// skipping messages queued behind an unpublished one with the same topic
+	// and key so they are always relayed in order.
+	FetchPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error)
+
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, message *entity.OutboxMessage) error
+
+	// Stats returns how many messages are waiting to be published and when
+	// the oldest of them was enqueued.
+	Stats(ctx context.Context) (pending int64, oldest time.Time, err error)
+}
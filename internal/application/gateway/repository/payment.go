@@ -2,10 +2,57 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"time"
 )
 
+// ErrStaleStatus is returned by UpdateStatus when the stored payment is no
+// longer in the status the caller expected, meaning a concurrent transition
+// already happened.
+var ErrStaleStatus = errors.New("payment status changed concurrently")
+
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *entity.Payment) error
 	FindByID(ctx context.Context, id int64) (*entity.Payment, error)
+
+	// FindByIDForUpdate works as FindByID but also locks the payment until
+	// the transaction carried by ctx ends.
+	FindByIDForUpdate(ctx context.Context, id int64) (*entity.Payment, error)
+
+	// FindByProviderReference locks and returns the payment the provider
+	// knows by reference, or nil when there is none.
+	FindByProviderReference(ctx context.Context, reference string) (*entity.Payment, error)
+	List(ctx context.Context, filter PaymentFilter) ([]*entity.Payment, error)
+
+	// UpdateStatus stores payment.Status and payment.ProviderReference as long
+	// as the stored status is still from, recording the transition in the
+	// payment status history.
+	UpdateStatus(ctx context.Context, payment *entity.Payment, from entity.PaymentStatus) error
+
+	// SetProviderReference stores payment.ProviderReference alone, so the
+	// payment can be found by the provider before its status moves.
+	SetProviderReference(ctx context.Context, payment *entity.Payment) error
+}
+
+// PaymentFilter narrows a payment listing. Zero values are ignored. Results
+// are ordered by created_at and id, newest first, and After is the keyset
+// position of the last payment already returned.
+type PaymentFilter struct {
+	MerchantID  int64
+	Status      entity.PaymentStatus
+	Method      string
+	Currency    money.Currency
+	MinAmount   *money.Money
+	MaxAmount   *money.Money
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	After       *PaymentCursor
+	Limit       int
+}
+
+type PaymentCursor struct {
+	CreatedAt time.Time
+	ID        int64
 }
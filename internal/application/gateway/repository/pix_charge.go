@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+type PixChargeRepository interface {
+	Create(ctx context.Context, charge *entity.PixCharge) error
+	FindByPayment(ctx context.Context, paymentID int64) (*entity.PixCharge, error)
+
+	// ListExpired returns up to limit charges expired at now whose payments
+	// are still waiting to be paid, oldest first.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.PixCharge, error)
+}
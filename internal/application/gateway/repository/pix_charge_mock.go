@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/pix_charge.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/pix_charge.go -destination=repository/pix_charge_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	entity "go-payments-api/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPixChargeRepository is a mock of PixChargeRepository interface.
+type MockPixChargeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPixChargeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPixChargeRepositoryMockRecorder is the mock recorder for MockPixChargeRepository.
+type MockPixChargeRepositoryMockRecorder struct {
+	mock *MockPixChargeRepository
+}
+
+// NewMockPixChargeRepository creates a new mock instance.
+func NewMockPixChargeRepository(ctrl *gomock.Controller) *MockPixChargeRepository {
+	mock := &MockPixChargeRepository{ctrl: ctrl}
+	mock.recorder = &MockPixChargeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPixChargeRepository) EXPECT() *MockPixChargeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPixChargeRepository) Create(ctx context.Context, charge *entity.PixCharge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, charge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPixChargeRepositoryMockRecorder) Create(ctx, charge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPixChargeRepository)(nil).Create), ctx, charge)
+}
+
+// FindByPayment mocks base method.
+func (m *MockPixChargeRepository) FindByPayment(ctx context.Context, paymentID int64) (*entity.PixCharge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPayment", ctx, paymentID)
+	ret0, _ := ret[0].(*entity.PixCharge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPayment indicates an expected call of FindByPayment.
+func (mr *MockPixChargeRepositoryMockRecorder) FindByPayment(ctx, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPayment", reflect.TypeOf((*MockPixChargeRepository)(nil).FindByPayment), ctx, paymentID)
+}
+
+// ListExpired mocks base method.
+func (m *MockPixChargeRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.PixCharge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.PixCharge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockPixChargeRepositoryMockRecorder) ListExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockPixChargeRepository)(nil).ListExpired), ctx, now, limit)
+}
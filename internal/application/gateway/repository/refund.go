@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+)
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund *entity.Refund) error
+	UpdateStatus(ctx context.Context, refund *entity.Refund) error
+	ListByPayment(ctx context.Context, paymentID int64) ([]*entity.Refund, error)
+
+	// TotalByPayment sums the refunds of a payment that haven't failed.
+	TotalByPayment(ctx context.Context, paymentID int64, currency money.Currency) (money.Money, error)
+}
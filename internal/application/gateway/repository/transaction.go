@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+type Transactor interface {
+	// WithinTransaction runs fn in a database transaction carried by the
+	// context it receives, committing when fn returns nil and rolling back
+	// otherwise. Repositories called with that context join the transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+type WebhookDeliveryRepository interface {
+	// Schedule queues payload for every active subscription of the merchant
+	// interested in eventType and returns how many deliveries were created.
+	Schedule(ctx context.Context, merchantID int64, eventType string, payload []byte) (int64, error)
+
+	// ClaimDue returns pending deliveries whose next attempt is due along
+	// with their subscription, pushing their next attempt to until so no
+	// other dispatcher claims them meanwhile. Deliveries left unrecorded,
+	// e.g. by a crash, are due again at until.
+	ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	Find(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	List(ctx context.Context, filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// Update stores the status, attempts and outcome of the last attempt.
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+// WebhookDeliveryFilter narrows a delivery listing. Zero values are ignored.
+// Results are ordered by id, newest first, and BeforeID is the id of the
+// last delivery already returned.
+type WebhookDeliveryFilter struct {
+	MerchantID     int64
+	SubscriptionID int64
+	Status         entity.WebhookDeliveryStatus
+	BeforeID       int64
+	Limit          int
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-payments-api/internal/domain/entity"
+)
+
+// ErrDuplicateWebhookEvent is returned by Create when the provider already
+// sent an event with the same id.
+var ErrDuplicateWebhookEvent = errors.New("duplicate webhook event")
+
+type WebhookEventRepository interface {
+	Create(ctx context.Context, event *entity.WebhookEvent) error
+	Find(ctx context.Context, provider string, eventID string) (*entity.WebhookEvent, error)
+
+	// Update stores the status, error and attempts of the event.
+	Update(ctx context.Context, event *entity.WebhookEvent) error
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/webhook_event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository/webhook_event.go -destination=repository/webhook_event_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	entity "go-payments-api/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookEventRepository is a mock of WebhookEventRepository interface.
+type MockWebhookEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookEventRepositoryMockRecorder is the mock recorder for MockWebhookEventRepository.
+type MockWebhookEventRepositoryMockRecorder struct {
+	mock *MockWebhookEventRepository
+}
+
+// NewMockWebhookEventRepository creates a new mock instance.
+func NewMockWebhookEventRepository(ctrl *gomock.Controller) *MockWebhookEventRepository {
+	mock := &MockWebhookEventRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEventRepository) EXPECT() *MockWebhookEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookEventRepository) Create(ctx context.Context, event *entity.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookEventRepositoryMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookEventRepository)(nil).Create), ctx, event)
+}
+
+// Find mocks base method.
+func (m *MockWebhookEventRepository) Find(ctx context.Context, provider, eventID string) (*entity.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, provider, eventID)
+	ret0, _ := ret[0].(*entity.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWebhookEventRepositoryMockRecorder) Find(ctx, provider, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWebhookEventRepository)(nil).Find), ctx, provider, eventID)
+}
+
+// Update mocks base method.
+func (m *MockWebhookEventRepository) Update(ctx context.Context, event *entity.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookEventRepositoryMockRecorder) Update(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookEventRepository)(nil).Update), ctx, event)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"go-payments-api/internal/domain/entity"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+
+	// List returns the subscriptions of the merchant, or every subscription
+	// when merchantID is 0.
+	List(ctx context.Context, merchantID int64) ([]*entity.WebhookSubscription, error)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ApplyStatusUpdate = base.UseCase[dto.StatusUpdateInput, *dto.WebhookOutput]
+
+type ApplyStatusUpdateImplementation struct {
+	payments   repository.PaymentRepository
+	events     repository.WebhookEventRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+}
+
+func NewApplyStatusUpdateUseCase(
+	payments repository.PaymentRepository,
+	events repository.WebhookEventRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+) *ApplyStatusUpdateImplementation {
+	return &ApplyStatusUpdateImplementation{
+		payments:   payments,
+		events:     events,
+		outbox:     outbox,
+		transactor: transactor,
+	}
+}
+
+// Execute applies a provider status update consumed from Kafka. Updates are
+// recorded like webhook events, so redelivered messages are applied once and
+// rejected ones can be replayed through the webhook replay endpoint.
+func (uc *ApplyStatusUpdateImplementation) Execute(ctx context.Context, input dto.StatusUpdateInput) (*dto.WebhookOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ApplyStatusUpdateUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.String("webhook.provider", input.Provider))
+
+	var notification dto.ProviderNotification
+	if err := json.Unmarshal(input.Body, &notification); err != nil || notification.ID == "" {
+		return nil, appErr.NewBadFormat("status update must be a notification with an id")
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.String("webhook.event.id", notification.ID))
+
+	event, duplicate, err := receiveProviderNotification(ctx, uc.payments, uc.events, uc.outbox, uc.transactor, input.Provider, input.Body, notification)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "status_update.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	return webhookOutput(event, duplicate), nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyStatusUpdateExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByProviderReference(gomock.Any(), "ref-1").Return(&entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10050, Currency: "BRL"},
+		Method: entity.MethodCard,
+		Status: entity.StatusProcessing,
+	}, nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(nil)
+
+	events := repository.NewMockWebhookEventRepository(ctrl)
+	events.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *entity.WebhookEvent) error {
+			assert.Equal(t, "simulator", event.Provider)
+			assert.Equal(t, "evt_1", event.EventID)
+			return nil
+		})
+	events.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "1", "payment.failed")
+
+	output, err := NewApplyStatusUpdateUseCase(payments, events, outbox, mockTransactor(ctrl)).Execute(context.Background(), dto.StatusUpdateInput{
+		Provider: "simulator",
+		Body:     []byte(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"DECLINED"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.WebhookEventProcessed), output.Status)
+}
+
+func TestApplyStatusUpdateExecuteDuplicate(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	events := repository.NewMockWebhookEventRepository(ctrl)
+	events.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateWebhookEvent)
+	events.EXPECT().Find(gomock.Any(), "simulator", "evt_1").Return(&entity.WebhookEvent{
+		EventID: "evt_1",
+		Status:  entity.WebhookEventProcessed,
+	}, nil)
+
+	output, err := NewApplyStatusUpdateUseCase(repository.NewMockPaymentRepository(ctrl), events, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).
+		Execute(context.Background(), dto.StatusUpdateInput{
+			Provider: "simulator",
+			Body:     []byte(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"DECLINED"}`),
+		})
+
+	assert.NoError(t, err)
+	assert.True(t, output.Duplicate)
+}
+
+func TestApplyStatusUpdateExecuteMalformed(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	output, err := NewApplyStatusUpdateUseCase(repository.NewMockPaymentRepository(ctrl), repository.NewMockWebhookEventRepository(ctrl), repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).
+		Execute(context.Background(), dto.StatusUpdateInput{Provider: "simulator", Body: []byte(`not json`)})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.BadFormat{}, err)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateMerchant = base.UseCase[dto.CreateMerchantInput, *dto.MerchantOutput]
+
+type CreateMerchantImplementation struct {
+	repository repository.MerchantRepository
+}
+
+func NewCreateMerchantUseCase(repository repository.MerchantRepository) *CreateMerchantImplementation {
+	return &CreateMerchantImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *CreateMerchantImplementation) Execute(ctx context.Context, input dto.CreateMerchantInput) (*dto.MerchantOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "CreateMerchantUseCase.Execute")
+	defer span.End()
+
+	merchant := &entity.Merchant{
+		Name:           input.Name,
+		Status:         entity.MerchantActive,
+		PaymentMethods: input.PaymentMethods,
+		Settlement:     entity.DefaultSettlement,
+	}
+	if input.Settlement != nil {
+		merchant.Settlement = settlement(*input.Settlement)
+	}
+
+	if err := uc.repository.Create(ctx, merchant); err != nil {
+		return nil, fmt.Errorf("failed to create merchant: %w", err)
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("merchant.id", merchant.ID))
+
+	output := merchantOutput(merchant)
+	return &output, nil
+}
+
+func settlement(input dto.Settlement) entity.Settlement {
+	return entity.Settlement{
+		Schedule:  entity.SettlementSchedule(input.Schedule),
+		DelayDays: input.DelayDays,
+	}
+}
+
+func merchantOutput(merchant *entity.Merchant) dto.MerchantOutput {
+	methods := merchant.PaymentMethods
+	if methods == nil {
+		methods = []string{}
+	}
+
+	return dto.MerchantOutput{
+		ID:             merchant.ID,
+		Name:           merchant.Name,
+		Status:         string(merchant.Status),
+		PaymentMethods: methods,
+		Settlement: dto.Settlement{
+			Schedule:  string(merchant.Settlement.Schedule),
+			DelayDays: merchant.Settlement.DelayDays,
+		},
+		CreatedAt: merchant.CreatedAt,
+		UpdatedAt: merchant.UpdatedAt,
+	}
+}
@@ -3,13 +3,16 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"go-payments-api/internal/application/auth"
 	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
 	"go-payments-api/internal/application/gateway/repository"
 	"go-payments-api/internal/domain/entity"
-	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/internal/domain/money"
 	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/log"
 	"go-payments-api/pkg/metrics"
-	"log"
 	"strconv"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -17,18 +20,35 @@ import (
 
 type CreatePayment = base.UseCase[dto.CreatePaymentInput, *dto.CreatePaymentOutput]
 
+const pixCurrency money.Currency = "BRL"
+
 type CreatePaymentImplementation struct {
 	repository repository.PaymentRepository
-	publisher  kafka.Publisher
+	merchants  repository.MerchantRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	providers  *provider.Registry
+	pixCharges repository.PixChargeRepository
+	pix        PixConfig
 }
 
 func NewCreatePaymentUseCase(
 	repository repository.PaymentRepository,
-	publisher kafka.Publisher,
+	merchants repository.MerchantRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+	providers *provider.Registry,
+	pixCharges repository.PixChargeRepository,
+	pix PixConfig,
 ) *CreatePaymentImplementation {
 	return &CreatePaymentImplementation{
 		repository: repository,
-		publisher:  publisher,
+		merchants:  merchants,
+		outbox:     outbox,
+		transactor: transactor,
+		providers:  providers,
+		pixCharges: pixCharges,
+		pix:        pix,
 	}
 }
 
@@ -36,61 +56,240 @@ func (uc *CreatePaymentImplementation) Execute(ctx context.Context, input dto.Cr
 	ctx, span := metrics.StartSpan(ctx, "CreatePaymentUseCase.Execute")
 	defer span.End()
 
-	log.Printf("🔵 Starting payment creation - Amount: %.2f, Method: %s", input.Amount, input.Method)
+	payment, err := uc.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.Process(ctx, payment)
+}
+
+// Create validates the input and stores the payment with its payment.created
+// event, in the transaction carried by ctx when there is one. The provider
+// isn't called yet.
+func (uc *CreatePaymentImplementation) Create(ctx context.Context, input dto.CreatePaymentInput) (*entity.Payment, error) {
+	logger := log.Logger.WithContext(ctx)
+	logger.Infof("creating payment of %s by %s", input.Amount, input.Method)
 
 	metrics.AddSpanAttributes(ctx,
-		attribute.Float64("payment.amount", input.Amount),
+		attribute.Int64("payment.amount", input.Amount.Value),
+		attribute.String("payment.currency", string(input.Amount.Currency)),
 		attribute.String("payment.method", input.Method),
 	)
 
-	// Validate payment method
-	if input.Method != entity.MethodPix && input.Method != entity.MethodCard {
-		log.Printf("❌ Invalid payment method: %s", input.Method)
-		return nil, fmt.Errorf("invalid payment method: %s", input.Method)
+	if err := input.Amount.Validate(); err != nil {
+		return nil, appErr.NewBadFormat(err.Error())
+	}
+
+	if !input.Amount.IsPositive() {
+		return nil, appErr.NewBadFormat("amount must be greater than zero")
+	}
+
+	// PIX only settles in reais
+	if input.Method == entity.MethodPix && input.Amount.Currency != pixCurrency {
+		return nil, appErr.NewBadFormat(fmt.Sprintf("%s payments only accept %s", entity.MethodPix, pixCurrency))
+	}
+
+	if err := uc.checkMethod(ctx, input.Method); err != nil {
+		logger.Warnf("payment method %s rejected: %v", input.Method, err)
+		return nil, err
 	}
 
 	// Create payment entity
 	payment := &entity.Payment{
-		Amount: input.Amount,
-		Method: input.Method,
+		Amount:     input.Amount,
+		Method:     input.Method,
+		MerchantID: auth.MerchantID(ctx),
 	}
 
-	// Save to database
-	log.Printf("💾 Saving payment to database...")
-	if err := uc.repository.Create(ctx, payment); err != nil {
-		log.Printf("❌ Failed to save payment to database: %v", err)
+	// Save payment and its payment.created event atomically, the outbox
+	// relay publishes the event to Kafka once the transaction commits
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.Create(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+
+		return enqueuePaymentEvent(ctx, uc.outbox, payment)
+	})
+	if err != nil {
+		logger.Errorf("failed to save payment: %v", err)
 		metrics.AddSpanEvent(ctx, "payment.creation.failed", attribute.String("error", err.Error()))
-		return nil, fmt.Errorf("failed to create payment: %w", err)
+		return nil, err
 	}
 
-	log.Printf("✅ Payment saved to database with ID: %d", payment.ID)
+	logger.Tag("payment_id", payment.ID).Infof("payment saved")
 	metrics.AddSpanAttributes(ctx, attribute.Int64("payment.id", payment.ID))
 
-	// Publish event to Kafka
-	event := dto.PaymentEvent{
-		ID:        payment.ID,
-		Amount:    payment.Amount,
-		Method:    payment.Method,
-		Status:    string(payment.Status),
-		CreatedAt: payment.CreatedAt,
-		EventType: "payment.created",
-	}
+	return payment, nil
+}
 
-	log.Printf("📤 Publishing event to Kafka - Topic: payment.events, Key: %d", payment.ID)
-	if err := uc.publisher.Publish(ctx, "payment.events", strconv.FormatInt(payment.ID, 10), event); err != nil {
-		log.Printf("❌ Failed to publish event to Kafka: %v", err)
-		metrics.AddSpanEvent(ctx, "kafka.publish.failed", attribute.String("error", err.Error()))
-		// Don't fail the request, just log
-	} else {
-		log.Printf("✅ Event published successfully to Kafka")
+// Process sends a payment stored by Create to its provider and returns the
+// output of the request. It must run outside of transactions, so no
+// connection or lock is held while the provider answers.
+func (uc *CreatePaymentImplementation) Process(ctx context.Context, payment *entity.Payment) (*dto.CreatePaymentOutput, error) {
+	logger := log.Logger.WithContext(ctx).Tag("payment_id", payment.ID)
+
+	// The payment exists from here on, so a failure while processing it
+	// returns it as stored instead of failing a request the client would
+	// retry into a second payment
+	charge, err := uc.process(ctx, payment)
+	if err != nil {
+		logger.Errorf("failed to process payment: %v", err)
+		metrics.AddSpanEvent(ctx, "payment.processing.failed", attribute.String("error", err.Error()))
+
+		stored, findErr := uc.repository.FindByID(ctx, payment.ID)
+		if findErr != nil || stored == nil {
+			logger.Errorf("failed to reload payment: %v", findErr)
+			return nil, err
+		}
+		payment, charge = stored, nil
 	}
+	recordPaymentCreated(ctx, payment)
+
+	logger.Infof("payment processed with status %s", payment.Status)
 
 	// Return output
-	return &dto.CreatePaymentOutput{
+	output := &dto.CreatePaymentOutput{
 		ID:        payment.ID,
 		Amount:    payment.Amount,
 		Method:    payment.Method,
 		Status:    string(payment.Status),
 		CreatedAt: payment.CreatedAt,
-	}, nil
+	}
+
+	if charge != nil {
+		output.Pix, err = pixChargeOutput(charge, uc.pix.QRCodeSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// checkMethod rejects methods no provider processes and, for callers acting
+// for a merchant, methods the merchant hasn't enabled or any method when the
+// merchant isn't active.
+func (uc *CreatePaymentImplementation) checkMethod(ctx context.Context, method string) error {
+	if _, err := uc.providers.For(method); err != nil {
+		return appErr.NewBadFormat(fmt.Sprintf("unsupported payment method: %s", method))
+	}
+
+	merchantID := auth.MerchantID(ctx)
+	if merchantID == 0 {
+		return nil
+	}
+
+	merchant, err := uc.merchants.FindByID(ctx, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to find merchant: %w", err)
+	}
+
+	if merchant == nil || !merchant.IsActive() {
+		return appErr.NewForbidden(fmt.Sprintf("merchant %d is not active", merchantID))
+	}
+
+	if !merchant.AcceptsMethod(method) {
+		return appErr.NewUnprocessable(fmt.Sprintf("payment method %s is not enabled for merchant %d", method, merchantID))
+	}
+
+	return nil
+}
+
+// process sends the payment to the provider of its method and moves it to
+// the status the provider answers. Authorized payments are captured right
+// away; when the provider can't be reached or the capture fails the payment
+// is failed, voiding the authorization if there is one. PIX payments left
+// waiting for the payer get a charge, which is returned.
+func (uc *CreatePaymentImplementation) process(ctx context.Context, payment *entity.Payment) (*entity.PixCharge, error) {
+	p, err := uc.providers.For(payment.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.Logger.WithContext(ctx).Tag("payment_id", payment.ID)
+
+	result, err := p.Authorize(ctx, payment)
+	if err != nil {
+		logger.Errorf("provider failed to authorize payment: %v", err)
+		return nil, uc.advance(ctx, payment, entity.StatusProcessing, entity.StatusFailed)
+	}
+
+	// The reference is stored before anything else, so a payment left
+	// behind by a failure below can still be matched to the provider
+	payment.ProviderReference = result.Reference
+	if err := uc.repository.SetProviderReference(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to store provider reference: %w", err)
+	}
+	metrics.AddSpanAttributes(ctx, attribute.String("payment.provider.status", string(result.Status)))
+
+	if result.Status == provider.StatusAuthorized {
+		captured, err := p.Capture(ctx, result.Reference, payment.Amount)
+		if err != nil {
+			logger.Errorf("provider failed to capture payment: %v", err)
+			if _, err := p.Void(ctx, result.Reference); err != nil {
+				logger.Errorf("provider failed to void payment: %v", err)
+			}
+			return nil, uc.advance(ctx, payment, entity.StatusProcessing, entity.StatusFailed)
+		}
+		result = captured
+	}
+
+	switch {
+	case result.Status == provider.StatusCaptured:
+		return nil, uc.advance(ctx, payment, entity.StatusProcessing, entity.StatusCompleted)
+	case result.Status == provider.StatusPending && payment.Method == entity.MethodPix:
+		var charge *entity.PixCharge
+		err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := uc.advance(ctx, payment, entity.StatusProcessing); err != nil {
+				return err
+			}
+
+			var err error
+			charge, err = issuePixCharge(ctx, uc.pixCharges, uc.pix, payment)
+			return err
+		})
+		return charge, err
+	case result.Status == provider.StatusPending:
+		return nil, uc.advance(ctx, payment, entity.StatusProcessing)
+	default:
+		logger.Infof("provider answered %s: %s", result.Status, result.Message)
+		return nil, uc.advance(ctx, payment, entity.StatusProcessing, entity.StatusFailed)
+	}
+}
+
+// recordPaymentCreated counts the payment by method and the status it was
+// left in, and records its amount in units of its currency.
+func recordPaymentCreated(ctx context.Context, payment *entity.Payment) {
+	method := attribute.String("payment.method", payment.Method)
+	currency := attribute.String("payment.currency", string(payment.Amount.Currency))
+
+	metrics.AddCounter(ctx, metrics.PaymentsCreated, 1,
+		method, currency, attribute.String("payment.status", string(payment.Status)))
+
+	if amount, err := strconv.ParseFloat(payment.Amount.Decimal(), 64); err == nil {
+		metrics.RecordHistogram(ctx, metrics.PaymentAmount, amount, method, currency)
+	}
+}
+
+// advance moves the payment through statuses in order, storing each
+// transition with its event.
+func (uc *CreatePaymentImplementation) advance(ctx context.Context, payment *entity.Payment, statuses ...entity.PaymentStatus) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, next := range statuses {
+			previous := payment.Status
+			if err := payment.TransitionTo(next); err != nil {
+				return err
+			}
+
+			if err := uc.repository.UpdateStatus(ctx, payment, previous); err != nil {
+				return fmt.Errorf("failed to update payment status: %w", err)
+			}
+
+			if err := enqueuePaymentEvent(ctx, uc.outbox, payment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
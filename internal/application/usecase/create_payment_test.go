@@ -1 +1,367 @@
 package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// mockProvider returns a registry processing every method with the returned
+// provider.
+func mockProvider(ctrl *gomock.Controller) (*provider.Registry, *provider.MockProvider) {
+	p := provider.NewMockProvider(ctrl)
+	return provider.NewRegistry(map[string]provider.Provider{
+		entity.MethodPix:  p,
+		entity.MethodCard: p,
+	}), p
+}
+
+var testPixConfig = PixConfig{
+	Key:          "payments@example.com",
+	MerchantName: "Go Payments",
+	MerchantCity: "Sao Paulo",
+	Expiration:   30 * time.Minute,
+	QRCodeSize:   128,
+}
+
+// expectCreated makes repo create the payment with the given id.
+func expectCreated(repo *repository.MockPaymentRepository, id int64) {
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, payment *entity.Payment) error {
+		payment.ID = id
+		payment.Status = entity.StatusCreated
+		return nil
+	})
+}
+
+// expectReference makes repo store the provider reference of the payment.
+func expectReference(t *testing.T, repo *repository.MockPaymentRepository, reference string) {
+	repo.EXPECT().SetProviderReference(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, payment *entity.Payment) error {
+			assert.Equal(t, reference, payment.ProviderReference)
+			return nil
+		})
+}
+
+// expectTransitions makes repo store the payment moving through statuses.
+func expectTransitions(t *testing.T, repo *repository.MockPaymentRepository, reference string, statuses ...entity.PaymentStatus) {
+	for i := 1; i < len(statuses); i++ {
+		next := statuses[i]
+		repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), statuses[i-1]).
+			DoAndReturn(func(_ context.Context, payment *entity.Payment, _ entity.PaymentStatus) error {
+				assert.Equal(t, next, payment.Status)
+				assert.Equal(t, reference, payment.ProviderReference)
+				return nil
+			})
+	}
+}
+
+func TestCreatePaymentExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	expectCreated(repo, 7)
+	expectReference(t, repo, "ref-7")
+	expectTransitions(t, repo, "ref-7", entity.StatusCreated, entity.StatusProcessing)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "7", "payment.created")
+	expectPaymentEvent(t, outbox, "7", "payment.processing")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusPending}, nil)
+
+	charges := repository.NewMockPixChargeRepository(ctrl)
+	charges.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, charge *entity.PixCharge) error {
+			assert.Equal(t, int64(7), charge.PaymentID)
+			assert.Equal(t, entity.PixTxID(7), charge.TxID)
+			assert.Contains(t, charge.Payload, "5406100.50")
+			return nil
+		})
+
+	output, err := NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, mockTransactor(ctrl), providers, charges, testPixConfig).Execute(context.Background(), dto.CreatePaymentInput{
+		Amount: money.Money{Value: 10050, Currency: "BRL"},
+		Method: entity.MethodPix,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+	assert.Equal(t, string(entity.StatusProcessing), output.Status)
+	assert.Equal(t, entity.PixTxID(7), output.Pix.TxID)
+	assert.NotEmpty(t, output.Pix.QRCode)
+	assert.WithinDuration(t, time.Now().Add(testPixConfig.Expiration), output.Pix.ExpiresAt, time.Minute)
+}
+
+func TestCreatePaymentExecuteCaptured(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	expectCreated(repo, 7)
+	expectReference(t, repo, "ref-7")
+	expectTransitions(t, repo, "ref-7", entity.StatusCreated, entity.StatusProcessing, entity.StatusCompleted)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "7", "payment.created")
+	expectPaymentEvent(t, outbox, "7", "payment.processing")
+	expectPaymentEvent(t, outbox, "7", "payment.completed")
+
+	amount := money.Money{Value: 10050, Currency: "USD"}
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusAuthorized}, nil)
+	p.EXPECT().Capture(gomock.Any(), "ref-7", amount).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusCaptured}, nil)
+
+	output, err := NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig).Execute(context.Background(), dto.CreatePaymentInput{
+		Amount: amount,
+		Method: entity.MethodCard,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.StatusCompleted), output.Status)
+}
+
+func TestCreatePaymentExecuteProviderFailure(t *testing.T) {
+	cases := map[string]func(p *provider.MockProvider){
+		"declined": func(p *provider.MockProvider) {
+			p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusDeclined}, nil)
+		},
+		"capture error": func(p *provider.MockProvider) {
+			p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusAuthorized}, nil)
+			p.EXPECT().Capture(gomock.Any(), "ref-7", gomock.Any()).Return(nil, errors.New("timeout"))
+			p.EXPECT().Void(gomock.Any(), "ref-7").Return(&provider.Result{Reference: "ref-7", Status: provider.StatusVoided}, nil)
+		},
+	}
+
+	for name, expect := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			repo := repository.NewMockPaymentRepository(ctrl)
+			expectCreated(repo, 7)
+			expectReference(t, repo, "ref-7")
+			expectTransitions(t, repo, "ref-7", entity.StatusCreated, entity.StatusProcessing, entity.StatusFailed)
+
+			outbox := repository.NewMockOutboxRepository(ctrl)
+			expectPaymentEvent(t, outbox, "7", "payment.created")
+			expectPaymentEvent(t, outbox, "7", "payment.processing")
+			expectPaymentEvent(t, outbox, "7", "payment.failed")
+
+			providers, p := mockProvider(ctrl)
+			expect(p)
+
+			output, err := NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig).Execute(context.Background(), dto.CreatePaymentInput{
+				Amount: money.Money{Value: 10050, Currency: "BRL"},
+				Method: entity.MethodCard,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(entity.StatusFailed), output.Status)
+		})
+	}
+}
+
+func TestCreatePaymentExecuteProcessingError(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	expectCreated(repo, 7)
+	expectReference(t, repo, "ref-7")
+	repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusCreated).Return(errors.New("connection reset"))
+	repo.EXPECT().FindByID(gomock.Any(), int64(7)).Return(&entity.Payment{
+		ID:                7,
+		Amount:            money.Money{Value: 10050, Currency: "USD"},
+		Method:            entity.MethodCard,
+		Status:            entity.StatusCreated,
+		ProviderReference: "ref-7",
+	}, nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "7", "payment.created")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusAuthorized}, nil)
+	p.EXPECT().Capture(gomock.Any(), "ref-7", gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusCaptured}, nil)
+
+	output, err := NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig).Execute(context.Background(), dto.CreatePaymentInput{
+		Amount: money.Money{Value: 10050, Currency: "USD"},
+		Method: entity.MethodCard,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+	assert.Equal(t, string(entity.StatusCreated), output.Status)
+}
+
+func TestCreatePaymentExecuteOutboxError(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).Return(errors.New("disk full"))
+
+	providers, _ := mockProvider(ctrl)
+
+	output, err := NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig).Execute(context.Background(), dto.CreatePaymentInput{
+		Amount: money.Money{Value: 10050, Currency: "USD"},
+		Method: entity.MethodCard,
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorContains(t, err, "disk full")
+}
+
+func TestCreatePaymentExecuteInvalidAmount(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	uc := NewCreatePaymentUseCase(
+		repository.NewMockPaymentRepository(ctrl),
+		repository.NewMockMerchantRepository(ctrl),
+		repository.NewMockOutboxRepository(ctrl),
+		repository.NewMockTransactor(ctrl),
+		provider.NewRegistry(nil),
+		repository.NewMockPixChargeRepository(ctrl),
+		testPixConfig,
+	)
+
+	cases := map[string]dto.CreatePaymentInput{
+		"zero amount":      {Amount: money.Money{Value: 0, Currency: "BRL"}, Method: entity.MethodCard},
+		"negative amount":  {Amount: money.Money{Value: -1, Currency: "BRL"}, Method: entity.MethodCard},
+		"missing currency": {Amount: money.Money{Value: 100}, Method: entity.MethodCard},
+		"pix in dollars":   {Amount: money.Money{Value: 100, Currency: "USD"}, Method: entity.MethodPix},
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			output, err := uc.Execute(context.Background(), input)
+
+			assert.Nil(t, output)
+			assert.IsType(t, appErr.BadFormat{}, err)
+		})
+	}
+}
+
+func TestCreatePaymentExecuteInvalidMethod(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	uc := NewCreatePaymentUseCase(
+		repository.NewMockPaymentRepository(ctrl),
+		repository.NewMockMerchantRepository(ctrl),
+		repository.NewMockOutboxRepository(ctrl),
+		repository.NewMockTransactor(ctrl),
+		provider.NewRegistry(nil),
+		repository.NewMockPixChargeRepository(ctrl),
+		testPixConfig,
+	)
+	output, err := uc.Execute(context.Background(), dto.CreatePaymentInput{
+		Amount: money.Money{Value: 1, Currency: "BRL"},
+		Method: "BOLETO",
+	})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.BadFormat{}, err)
+}
+
+func TestCreatePaymentExecuteForMerchant(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().FindByID(gomock.Any(), int64(3)).Return(&entity.Merchant{
+		ID:             3,
+		Status:         entity.MerchantActive,
+		PaymentMethods: []string{entity.MethodCard},
+	}, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, payment *entity.Payment) error {
+		assert.Equal(t, int64(3), payment.MerchantID)
+		payment.ID = 7
+		payment.Status = entity.StatusCreated
+		return nil
+	})
+	expectReference(t, repo, "ref-7")
+	expectTransitions(t, repo, "ref-7", entity.StatusCreated, entity.StatusProcessing)
+
+	// The events carry the merchant, so only its webhook subscriptions
+	// receive them
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *entity.OutboxMessage) error {
+			assert.Equal(t, int64(3), message.MerchantID)
+			return nil
+		}).
+		Times(2)
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusPending}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+	output, err := NewCreatePaymentUseCase(repo, merchants, outbox, mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig).Execute(ctx, dto.CreatePaymentInput{
+		Amount: money.Money{Value: 100, Currency: "BRL"},
+		Method: entity.MethodCard,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+}
+
+func TestCreatePaymentExecuteMerchantRejects(t *testing.T) {
+	cases := map[string]struct {
+		merchant *entity.Merchant
+		err      error
+	}{
+		"method not enabled": {
+			merchant: &entity.Merchant{ID: 3, Status: entity.MerchantActive, PaymentMethods: []string{entity.MethodPix}},
+			err:      appErr.Unprocessable{},
+		},
+		"suspended": {
+			merchant: &entity.Merchant{ID: 3, Status: entity.MerchantSuspended, PaymentMethods: []string{entity.MethodCard}},
+			err:      appErr.Forbidden{},
+		},
+		"missing": {
+			err: appErr.Forbidden{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			merchants := repository.NewMockMerchantRepository(ctrl)
+			merchants.EXPECT().FindByID(gomock.Any(), int64(3)).Return(tc.merchant, nil)
+
+			providers, _ := mockProvider(ctrl)
+			uc := NewCreatePaymentUseCase(
+				repository.NewMockPaymentRepository(ctrl),
+				merchants,
+				repository.NewMockOutboxRepository(ctrl),
+				repository.NewMockTransactor(ctrl),
+				providers,
+				repository.NewMockPixChargeRepository(ctrl),
+				testPixConfig,
+			)
+
+			ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+			output, err := uc.Execute(ctx, dto.CreatePaymentInput{
+				Amount: money.Money{Value: 100, Currency: "BRL"},
+				Method: entity.MethodCard,
+			})
+
+			assert.Nil(t, output)
+			assert.IsType(t, tc.err, err)
+		})
+	}
+}
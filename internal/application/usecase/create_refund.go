@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateRefund = base.UseCase[dto.CreateRefundInput, *dto.CreateRefundOutput]
+
+type CreateRefundImplementation struct {
+	payments   repository.PaymentRepository
+	refunds    repository.RefundRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	providers  *provider.Registry
+}
+
+func NewCreateRefundUseCase(
+	payments repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+	providers *provider.Registry,
+) *CreateRefundImplementation {
+	return &CreateRefundImplementation{
+		payments:   payments,
+		refunds:    refunds,
+		outbox:     outbox,
+		transactor: transactor,
+		providers:  providers,
+	}
+}
+
+// Execute refunds the payment fully or partially. The PENDING refund is
+// committed while the payment row is locked, so concurrent refunds can't
+// exceed the refundable amount, and the provider is called after the lock is
+// released. A second transaction settles the refund and the payment. A
+// refund the provider rejects is stored as FAILED and leaves the payment
+// untouched.
+func (uc *CreateRefundImplementation) Execute(ctx context.Context, input dto.CreateRefundInput) (*dto.CreateRefundOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "CreateRefundUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("payment.id", input.PaymentID))
+
+	refund, payment, err := uc.createPending(ctx, input)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "refund.create.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("refund.id", refund.ID))
+
+	if err := uc.refundWithProvider(ctx, payment, refund); err != nil {
+		metrics.AddSpanEvent(ctx, "refund.provider.failed", attribute.String("error", err.Error()))
+		err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return uc.failRefund(ctx, payment, refund)
+		})
+	} else {
+		payment, err = uc.complete(ctx, refund)
+	}
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "refund.settle.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &dto.CreateRefundOutput{
+		RefundOutput:  refundOutput(refund),
+		PaymentStatus: string(payment.Status),
+	}, nil
+}
+
+// createPending validates the refund against the locked payment and stores it
+// as PENDING. Pending refunds count towards the refunded total, so the amount
+// stays reserved while the provider is called.
+func (uc *CreateRefundImplementation) createPending(ctx context.Context, input dto.CreateRefundInput) (*entity.Refund, *entity.Payment, error) {
+	var (
+		refund  *entity.Refund
+		payment *entity.Payment
+	)
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = uc.payments.FindByIDForUpdate(ctx, input.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to find payment: %w", err)
+		}
+
+		if payment == nil || !auth.CanAccess(ctx, payment.MerchantID) {
+			return appErr.NewNotFound(fmt.Sprintf("payment %d not found", input.PaymentID))
+		}
+
+		if !payment.Status.IsRefundable() {
+			return appErr.NewConflict(fmt.Sprintf("payment %d is %s and can't be refunded", payment.ID, payment.Status))
+		}
+
+		refunded, err := uc.refunds.TotalByPayment(ctx, payment.ID, payment.Amount.Currency)
+		if err != nil {
+			return fmt.Errorf("failed to sum refunds: %w", err)
+		}
+
+		amount, err := refundAmount(payment, refunded, input.Amount)
+		if err != nil {
+			return err
+		}
+
+		refund = &entity.Refund{
+			PaymentID: payment.ID,
+			Amount:    amount,
+			Status:    entity.RefundStatusPending,
+			Reason:    input.Reason,
+		}
+
+		if err := uc.refunds.Create(ctx, refund); err != nil {
+			return fmt.Errorf("failed to create refund: %w", err)
+		}
+
+		return enqueueRefundEvent(ctx, uc.outbox, payment, refund)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return refund, payment, nil
+}
+
+// complete marks the refund COMPLETED and settles the payment, locked again
+// since other refunds may have settled it in the meantime.
+func (uc *CreateRefundImplementation) complete(ctx context.Context, refund *entity.Refund) (*entity.Payment, error) {
+	var payment *entity.Payment
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = uc.payments.FindByIDForUpdate(ctx, refund.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to find payment: %w", err)
+		}
+
+		if payment == nil {
+			return appErr.NewNotFound(fmt.Sprintf("payment %d not found", refund.PaymentID))
+		}
+
+		if err := refund.Complete(); err != nil {
+			return err
+		}
+
+		if err := uc.refunds.UpdateStatus(ctx, refund); err != nil {
+			return fmt.Errorf("failed to update refund: %w", err)
+		}
+
+		if err := enqueueRefundEvent(ctx, uc.outbox, payment, refund); err != nil {
+			return err
+		}
+
+		refunded, err := uc.completedTotal(ctx, payment)
+		if err != nil {
+			return err
+		}
+
+		return uc.settlePayment(ctx, payment, refunded)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// completedTotal sums the completed refunds of the payment. Refunds still
+// pending with the provider don't move the payment status yet.
+func (uc *CreateRefundImplementation) completedTotal(ctx context.Context, payment *entity.Payment) (money.Money, error) {
+	refunds, err := uc.refunds.ListByPayment(ctx, payment.ID)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("failed to list refunds: %w", err)
+	}
+
+	total := money.Money{Currency: payment.Amount.Currency}
+	for _, refund := range refunds {
+		if refund.Status != entity.RefundStatusCompleted {
+			continue
+		}
+
+		if total, err = total.Add(refund.Amount); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+// refundWithProvider asks the provider of the payment to give the refund
+// amount back. Payments without a provider reference were never sent to a
+// provider, so there is nothing to ask.
+func (uc *CreateRefundImplementation) refundWithProvider(ctx context.Context, payment *entity.Payment, refund *entity.Refund) error {
+	if payment.ProviderReference == "" {
+		return nil
+	}
+
+	p, err := uc.providers.For(payment.Method)
+	if err != nil {
+		return err
+	}
+
+	result, err := p.Refund(ctx, payment.ProviderReference, refund.Amount)
+	if err != nil {
+		return err
+	}
+
+	if result.Status != provider.StatusRefunded {
+		return fmt.Errorf("provider answered %s: %s", result.Status, result.Message)
+	}
+
+	return nil
+}
+
+func (uc *CreateRefundImplementation) failRefund(ctx context.Context, payment *entity.Payment, refund *entity.Refund) error {
+	if err := refund.Fail(); err != nil {
+		return err
+	}
+
+	if err := uc.refunds.UpdateStatus(ctx, refund); err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+
+	return enqueueRefundEvent(ctx, uc.outbox, payment, refund)
+}
+
+// settlePayment moves the payment to REFUNDED or PARTIALLY_REFUNDED after
+// refunded out of its amount has been refunded.
+func (uc *CreateRefundImplementation) settlePayment(ctx context.Context, payment *entity.Payment, refunded money.Money) error {
+	next, err := payment.RefundedStatus(refunded)
+	if err != nil {
+		return err
+	}
+
+	if next == payment.Status {
+		return nil
+	}
+
+	previous := payment.Status
+	if err := payment.TransitionTo(next); err != nil {
+		return appErr.NewConflict(err.Error())
+	}
+
+	if err := uc.payments.UpdateStatus(ctx, payment, previous); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	return enqueuePaymentEvent(ctx, uc.outbox, payment)
+}
+
+// refundAmount validates the requested amount against what is left to refund
+// of the payment, defaulting to all of it.
+func refundAmount(payment *entity.Payment, refunded money.Money, requested *money.Money) (money.Money, error) {
+	remaining, err := payment.Amount.Sub(refunded)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	// Refunds still pending with the provider count as refunded
+	if !remaining.IsPositive() {
+		return money.Money{}, appErr.NewConflict(fmt.Sprintf("payment %d has nothing left to refund", payment.ID))
+	}
+
+	if requested == nil {
+		return remaining, nil
+	}
+
+	if !requested.IsPositive() {
+		return money.Money{}, appErr.NewBadFormat("refund amount must be greater than zero")
+	}
+
+	if requested.Currency != payment.Amount.Currency {
+		return money.Money{}, appErr.NewUnprocessable(fmt.Sprintf("refund currency %s differs from payment currency %s", requested.Currency, payment.Amount.Currency))
+	}
+
+	if cmp, _ := requested.Cmp(remaining); cmp > 0 {
+		return money.Money{}, appErr.NewUnprocessable(fmt.Sprintf("refund amount %s exceeds the refundable %s", requested, remaining))
+	}
+
+	return *requested, nil
+}
+
+func refundOutput(refund *entity.Refund) dto.RefundOutput {
+	return dto.RefundOutput{
+		ID:        refund.ID,
+		PaymentID: refund.PaymentID,
+		Amount:    refund.Amount,
+		Status:    string(refund.Status),
+		Reason:    refund.Reason,
+		CreatedAt: refund.CreatedAt,
+		UpdatedAt: refund.UpdatedAt,
+	}
+}
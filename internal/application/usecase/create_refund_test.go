@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func completedPayment() *entity.Payment {
+	return &entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10000, Currency: "BRL"},
+		Method: entity.MethodPix,
+		Status: entity.StatusCompleted,
+
+		ProviderReference: "ref-1",
+	}
+}
+
+func expectRefundCreated(refunds *repository.MockRefundRepository) {
+	refunds.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, refund *entity.Refund) error {
+			refund.ID = 7
+			return nil
+		})
+	refunds.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(nil)
+}
+
+func completedRefunds(values ...int64) []*entity.Refund {
+	refunds := make([]*entity.Refund, 0, len(values))
+	for _, value := range values {
+		refunds = append(refunds, &entity.Refund{
+			PaymentID: 1,
+			Amount:    money.Money{Value: value, Currency: "BRL"},
+			Status:    entity.RefundStatusCompleted,
+		})
+	}
+	return refunds
+}
+
+func TestCreateRefundExecutePartial(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	refunds := repository.NewMockRefundRepository(ctrl)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(completedPayment(), nil).Times(2)
+	refunds.EXPECT().TotalByPayment(gomock.Any(), int64(1), money.Currency("BRL")).Return(money.Money{Currency: "BRL"}, nil)
+	expectRefundCreated(refunds)
+	refunds.EXPECT().ListByPayment(gomock.Any(), int64(1)).Return(completedRefunds(2500), nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusCompleted).Return(nil)
+
+	gomock.InOrder(
+		expectRefundEvent(t, outbox, "refund.created"),
+		expectRefundEvent(t, outbox, "refund.completed"),
+	)
+	expectPaymentEvent(t, outbox, "1", "payment.partially_refunded")
+
+	// The provider is called with no transaction open, so the payment row
+	// isn't locked while it answers
+	inTransaction := false
+	transactor := repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			inTransaction = true
+			defer func() { inTransaction = false }()
+			return fn(ctx)
+		}).
+		Times(2)
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Refund(gomock.Any(), "ref-1", money.Money{Value: 2500, Currency: "BRL"}).
+		DoAndReturn(func(context.Context, string, money.Money) (*provider.Result, error) {
+			assert.False(t, inTransaction)
+			return &provider.Result{Reference: "ref-1", Status: provider.StatusRefunded}, nil
+		})
+
+	output, err := NewCreateRefundUseCase(payments, refunds, outbox, transactor, providers).Execute(context.Background(), dto.CreateRefundInput{
+		PaymentID: 1,
+		Amount:    &money.Money{Value: 2500, Currency: "BRL"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+	assert.Equal(t, int64(2500), output.Amount.Value)
+	assert.Equal(t, string(entity.RefundStatusCompleted), output.Status)
+	assert.Equal(t, string(entity.StatusPartiallyRefunded), output.PaymentStatus)
+}
+
+func TestCreateRefundExecuteRemaining(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payment := completedPayment()
+	payment.Status = entity.StatusPartiallyRefunded
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	refunds := repository.NewMockRefundRepository(ctrl)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(payment, nil).Times(2)
+	refunds.EXPECT().TotalByPayment(gomock.Any(), int64(1), money.Currency("BRL")).Return(money.Money{Value: 2500, Currency: "BRL"}, nil)
+	expectRefundCreated(refunds)
+	refunds.EXPECT().ListByPayment(gomock.Any(), int64(1)).Return(completedRefunds(2500, 7500), nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusPartiallyRefunded).Return(nil)
+	outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	expectPaymentEvent(t, outbox, "1", "payment.refunded")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Refund(gomock.Any(), "ref-1", money.Money{Value: 7500, Currency: "BRL"}).
+		Return(&provider.Result{Reference: "ref-1", Status: provider.StatusRefunded}, nil)
+
+	output, err := NewCreateRefundUseCase(payments, refunds, outbox, mockTransactor(ctrl), providers).Execute(context.Background(), dto.CreateRefundInput{
+		PaymentID: 1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7500), output.Amount.Value)
+	assert.Equal(t, string(entity.StatusRefunded), output.PaymentStatus)
+}
+
+func TestCreateRefundExecuteConcurrentPending(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	refunds := repository.NewMockRefundRepository(ctrl)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+
+	// Another refund of 7500 is still pending with the provider, so this one
+	// settles the payment as PARTIALLY_REFUNDED only
+	pending := &entity.Refund{PaymentID: 1, Amount: money.Money{Value: 7500, Currency: "BRL"}, Status: entity.RefundStatusPending}
+
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(completedPayment(), nil).Times(2)
+	refunds.EXPECT().TotalByPayment(gomock.Any(), int64(1), money.Currency("BRL")).Return(money.Money{Value: 7500, Currency: "BRL"}, nil)
+	expectRefundCreated(refunds)
+	refunds.EXPECT().ListByPayment(gomock.Any(), int64(1)).Return(append(completedRefunds(2500), pending), nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusCompleted).Return(nil)
+	outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	expectPaymentEvent(t, outbox, "1", "payment.partially_refunded")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Refund(gomock.Any(), "ref-1", money.Money{Value: 2500, Currency: "BRL"}).
+		Return(&provider.Result{Reference: "ref-1", Status: provider.StatusRefunded}, nil)
+
+	output, err := NewCreateRefundUseCase(payments, refunds, outbox, mockTransactor(ctrl), providers).Execute(context.Background(), dto.CreateRefundInput{
+		PaymentID: 1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), output.Amount.Value)
+	assert.Equal(t, string(entity.StatusPartiallyRefunded), output.PaymentStatus)
+}
+
+func TestCreateRefundExecuteProviderFailure(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	refunds := repository.NewMockRefundRepository(ctrl)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(completedPayment(), nil)
+	refunds.EXPECT().TotalByPayment(gomock.Any(), int64(1), money.Currency("BRL")).Return(money.Money{Currency: "BRL"}, nil)
+	refunds.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	refunds.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, refund *entity.Refund) error {
+			assert.Equal(t, entity.RefundStatusFailed, refund.Status)
+			return nil
+		})
+
+	gomock.InOrder(
+		expectRefundEvent(t, outbox, "refund.created"),
+		expectRefundEvent(t, outbox, "refund.failed"),
+	)
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Refund(gomock.Any(), "ref-1", gomock.Any()).Return(nil, errors.New("timeout"))
+
+	output, err := NewCreateRefundUseCase(payments, refunds, outbox, mockTransactor(ctrl), providers).Execute(context.Background(), dto.CreateRefundInput{
+		PaymentID: 1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.RefundStatusFailed), output.Status)
+	assert.Equal(t, string(entity.StatusCompleted), output.PaymentStatus)
+}
+
+func TestCreateRefundExecuteRejected(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   entity.PaymentStatus
+		refunded int64
+		amount   *money.Money
+		want     error
+	}{
+		{name: "not refundable", status: entity.StatusProcessing, want: appErr.Conflict{}},
+		{name: "nothing left", status: entity.StatusCompleted, refunded: 10000, want: appErr.Conflict{}},
+		{name: "exceeds remaining", status: entity.StatusCompleted, refunded: 9000, amount: &money.Money{Value: 1001, Currency: "BRL"}, want: appErr.Unprocessable{}},
+		{name: "currency mismatch", status: entity.StatusCompleted, amount: &money.Money{Value: 100, Currency: "USD"}, want: appErr.Unprocessable{}},
+		{name: "not positive", status: entity.StatusCompleted, amount: &money.Money{Currency: "BRL"}, want: appErr.BadFormat{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			payment := completedPayment()
+			payment.Status = tc.status
+
+			payments := repository.NewMockPaymentRepository(ctrl)
+			refunds := repository.NewMockRefundRepository(ctrl)
+
+			payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(payment, nil)
+			refunds.EXPECT().TotalByPayment(gomock.Any(), int64(1), money.Currency("BRL")).
+				Return(money.Money{Value: tc.refunded, Currency: "BRL"}, nil).
+				AnyTimes()
+
+			providers, _ := mockProvider(ctrl)
+
+			output, err := NewCreateRefundUseCase(payments, refunds, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl), providers).Execute(context.Background(), dto.CreateRefundInput{
+				PaymentID: 1,
+				Amount:    tc.amount,
+			})
+
+			assert.Nil(t, output)
+			assert.IsType(t, tc.want, err)
+		})
+	}
+}
+
+func TestCreateRefundExecuteNotFound(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(nil, nil)
+
+	providers, _ := mockProvider(ctrl)
+
+	output, err := NewCreateRefundUseCase(payments, repository.NewMockRefundRepository(ctrl), repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl), providers).Execute(context.Background(), dto.CreateRefundInput{
+		PaymentID: 1,
+	})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.NotFound{}, err)
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/netguard"
+	"net"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateWebhookSubscription = base.UseCase[dto.CreateWebhookSubscriptionInput, *dto.CreateWebhookSubscriptionOutput]
+
+// WebhookSubscriptionConfig restricts the URLs merchants subscribe. Outside
+// of development only https is accepted, and hosts on loopback, private or
+// link-local addresses are refused unless AllowPrivateNetworks is set.
+type WebhookSubscriptionConfig struct {
+	RequireHTTPS         bool
+	AllowPrivateNetworks bool
+}
+
+type CreateWebhookSubscriptionImplementation struct {
+	repository repository.WebhookSubscriptionRepository
+	config     WebhookSubscriptionConfig
+	lookup     netguard.Lookup
+}
+
+func NewCreateWebhookSubscriptionUseCase(repository repository.WebhookSubscriptionRepository, config WebhookSubscriptionConfig) *CreateWebhookSubscriptionImplementation {
+	return &CreateWebhookSubscriptionImplementation{
+		repository: repository,
+		config:     config,
+		lookup:     net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+func (uc *CreateWebhookSubscriptionImplementation) Execute(ctx context.Context, input dto.CreateWebhookSubscriptionInput) (*dto.CreateWebhookSubscriptionOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "CreateWebhookSubscriptionUseCase.Execute")
+	defer span.End()
+
+	endpoint, err := url.Parse(input.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, appErr.NewBadFormat("url must be an absolute http or https url")
+	}
+	if uc.config.RequireHTTPS && endpoint.Scheme != "https" {
+		return nil, appErr.NewBadFormat("url must be an https url")
+	}
+	if !uc.config.AllowPrivateNetworks {
+		if err := netguard.CheckHost(ctx, uc.lookup, endpoint.Hostname()); err != nil {
+			return nil, appErr.NewBadFormat("url must point to a public address: " + err.Error())
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	subscription := &entity.WebhookSubscription{
+		MerchantID: auth.MerchantID(ctx),
+		URL:        endpoint.String(),
+		Secret:     secret,
+		EventTypes: input.EventTypes,
+		Active:     true,
+	}
+
+	if err := uc.repository.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("webhook.subscription.id", subscription.ID))
+
+	return &dto.CreateWebhookSubscriptionOutput{
+		WebhookSubscriptionOutput: webhookSubscriptionOutput(subscription),
+		Secret:                    secret,
+	}, nil
+}
+
+func newWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
+
+func webhookSubscriptionOutput(subscription *entity.WebhookSubscription) dto.WebhookSubscriptionOutput {
+	eventTypes := subscription.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return dto.WebhookSubscriptionOutput{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
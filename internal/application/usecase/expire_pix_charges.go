@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	"go-payments-api/pkg/log"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ExpirePixCharges = base.UseCase[dto.ExpirePixChargesInput, *dto.ExpirePixChargesOutput]
+
+type ExpirePixChargesImplementation struct {
+	payments   repository.PaymentRepository
+	pixCharges repository.PixChargeRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	providers  *provider.Registry
+}
+
+func NewExpirePixChargesUseCase(
+	payments repository.PaymentRepository,
+	pixCharges repository.PixChargeRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+	providers *provider.Registry,
+) *ExpirePixChargesImplementation {
+	return &ExpirePixChargesImplementation{
+		payments:   payments,
+		pixCharges: pixCharges,
+		outbox:     outbox,
+		transactor: transactor,
+		providers:  providers,
+	}
+}
+
+// Execute moves the payments of up to input.Limit charges expired at
+// input.Now to EXPIRED. A payment that fails to expire is logged and left
+// for the next run.
+func (uc *ExpirePixChargesImplementation) Execute(ctx context.Context, input dto.ExpirePixChargesInput) (*dto.ExpirePixChargesOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ExpirePixChargesUseCase.Execute")
+	defer span.End()
+
+	charges, err := uc.pixCharges.ListExpired(ctx, input.Now, input.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired pix charges: %w", err)
+	}
+
+	output := &dto.ExpirePixChargesOutput{Found: len(charges)}
+	for _, charge := range charges {
+		expired, err := uc.expire(ctx, charge)
+		if err != nil {
+			log.Logger.WithContext(ctx).Errorf("failed to expire payment %d: %v", charge.PaymentID, err)
+			continue
+		}
+
+		if expired {
+			output.Expired++
+		}
+	}
+
+	metrics.AddSpanAttributes(ctx,
+		attribute.Int("pix.charges.found", output.Found),
+		attribute.Int("pix.charges.expired", output.Expired),
+	)
+
+	return output, nil
+}
+
+// expire voids the charge with the provider and expires its payment, unless
+// the payment got paid or canceled in the meantime. The provider is called
+// before the payment is locked, so the status is checked again under the
+// lock.
+func (uc *ExpirePixChargesImplementation) expire(ctx context.Context, charge *entity.PixCharge) (bool, error) {
+	payment, err := uc.payments.FindByID(ctx, charge.PaymentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil || !payment.Status.CanTransitionTo(entity.StatusExpired) {
+		return false, nil
+	}
+
+	if err := uc.void(ctx, payment); err != nil {
+		return false, err
+	}
+
+	expired := false
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		payment, err := uc.payments.FindByIDForUpdate(ctx, charge.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to find payment: %w", err)
+		}
+
+		if payment == nil || !payment.Status.CanTransitionTo(entity.StatusExpired) {
+			return nil
+		}
+
+		previous := payment.Status
+		if err := payment.TransitionTo(entity.StatusExpired); err != nil {
+			return err
+		}
+
+		if err := uc.payments.UpdateStatus(ctx, payment, previous); err != nil {
+			return fmt.Errorf("failed to update payment status: %w", err)
+		}
+
+		expired = true
+		return enqueuePaymentEvent(ctx, uc.outbox, payment)
+	})
+
+	return expired, err
+}
+
+// void stops the provider from accepting the payment. References the
+// provider doesn't know have nothing to void.
+func (uc *ExpirePixChargesImplementation) void(ctx context.Context, payment *entity.Payment) error {
+	if payment.ProviderReference == "" {
+		return nil
+	}
+
+	p, err := uc.providers.For(payment.Method)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Void(ctx, payment.ProviderReference)
+	if errors.Is(err, provider.ErrUnknownReference) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to void payment with provider: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpirePixChargesExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	now := time.Now()
+	charges := repository.NewMockPixChargeRepository(ctrl)
+	charges.EXPECT().ListExpired(gomock.Any(), now, 10).Return([]*entity.PixCharge{
+		{PaymentID: 1},
+		{PaymentID: 2},
+		{PaymentID: 3},
+		{PaymentID: 4},
+	}, nil)
+
+	processing := func(id int64, reference string) *entity.Payment {
+		return &entity.Payment{
+			ID:                id,
+			Amount:            money.Money{Value: 10050, Currency: "BRL"},
+			Method:            entity.MethodPix,
+			Status:            entity.StatusProcessing,
+			ProviderReference: reference,
+		}
+	}
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByID(gomock.Any(), int64(1)).Return(processing(1, "ref-1"), nil)
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(processing(1, "ref-1"), nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(nil)
+
+	// Paid before the sweep
+	payments.EXPECT().FindByID(gomock.Any(), int64(2)).Return(&entity.Payment{
+		ID:     2,
+		Method: entity.MethodPix,
+		Status: entity.StatusCompleted,
+	}, nil)
+
+	payments.EXPECT().FindByID(gomock.Any(), int64(3)).Return(processing(3, "ref-3"), nil)
+
+	// Paid while the charge was voided
+	payments.EXPECT().FindByID(gomock.Any(), int64(4)).Return(processing(4, "ref-4"), nil)
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(4)).Return(&entity.Payment{
+		ID:                4,
+		Method:            entity.MethodPix,
+		Status:            entity.StatusCompleted,
+		ProviderReference: "ref-4",
+	}, nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "1", "payment.expired")
+
+	// Transactions are counted in depth, which must be zero while the
+	// provider is called
+	depth := 0
+	transactor := repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			depth++
+			defer func() { depth-- }()
+			return fn(ctx)
+		}).
+		Times(2)
+	outside := func(context.Context, string) { assert.Zero(t, depth) }
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Void(gomock.Any(), "ref-1").Do(outside).Return(&provider.Result{Reference: "ref-1", Status: provider.StatusVoided}, nil)
+	p.EXPECT().Void(gomock.Any(), "ref-3").Do(outside).Return(nil, errors.New("timeout"))
+	p.EXPECT().Void(gomock.Any(), "ref-4").Do(outside).Return(&provider.Result{Reference: "ref-4", Status: provider.StatusVoided}, nil)
+
+	output, err := NewExpirePixChargesUseCase(payments, charges, outbox, transactor, providers).Execute(context.Background(), dto.ExpirePixChargesInput{
+		Now:   now,
+		Limit: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, output.Found)
+	assert.Equal(t, 1, output.Expired)
+}
+
+func TestExpirePixChargesExecuteUnknownReference(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	charges := repository.NewMockPixChargeRepository(ctrl)
+	charges.EXPECT().ListExpired(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.PixCharge{{PaymentID: 1}}, nil)
+
+	payment := &entity.Payment{
+		ID:                1,
+		Amount:            money.Money{Value: 10050, Currency: "BRL"},
+		Method:            entity.MethodPix,
+		Status:            entity.StatusProcessing,
+		ProviderReference: "ref-1",
+	}
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByID(gomock.Any(), int64(1)).Return(payment, nil)
+	payments.EXPECT().FindByIDForUpdate(gomock.Any(), int64(1)).Return(payment, nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "1", "payment.expired")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Void(gomock.Any(), "ref-1").Return(nil, provider.ErrUnknownReference)
+
+	output, err := NewExpirePixChargesUseCase(payments, charges, outbox, mockTransactor(ctrl), providers).Execute(context.Background(), dto.ExpirePixChargesInput{
+		Now:   time.Now(),
+		Limit: 10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.Expired)
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetMerchant = base.UseCase[dto.GetMerchantInput, *dto.MerchantOutput]
+
+type GetMerchantImplementation struct {
+	repository repository.MerchantRepository
+}
+
+func NewGetMerchantUseCase(repository repository.MerchantRepository) *GetMerchantImplementation {
+	return &GetMerchantImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *GetMerchantImplementation) Execute(ctx context.Context, input dto.GetMerchantInput) (*dto.MerchantOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "GetMerchantUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("merchant.id", input.ID))
+
+	merchant, err := uc.repository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchant: %w", err)
+	}
+
+	if merchant == nil {
+		return nil, appErr.NewNotFound(fmt.Sprintf("merchant %d not found", input.ID))
+	}
+
+	output := merchantOutput(merchant)
+	return &output, nil
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetPayment = base.UseCase[dto.GetPaymentInput, *dto.GetPaymentOutput]
+
+type GetPaymentImplementation struct {
+	repository repository.PaymentRepository
+}
+
+func NewGetPaymentUseCase(repository repository.PaymentRepository) *GetPaymentImplementation {
+	return &GetPaymentImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *GetPaymentImplementation) Execute(ctx context.Context, input dto.GetPaymentInput) (*dto.GetPaymentOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "GetPaymentUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("payment.id", input.ID))
+
+	payment, err := uc.repository.FindByID(ctx, input.ID)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "payment.find.failed", attribute.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil || !auth.CanAccess(ctx, payment.MerchantID) {
+		return nil, appErr.NewNotFound(fmt.Sprintf("payment %d not found", input.ID))
+	}
+
+	return &dto.GetPaymentOutput{
+		ID:        payment.ID,
+		Amount:    payment.Amount,
+		Method:    payment.Method,
+		Status:    string(payment.Status),
+		CreatedAt: payment.CreatedAt,
+		UpdatedAt: payment.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPaymentExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	createdAt := time.Now()
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Payment{
+		ID:        1,
+		Amount:    money.Money{Value: 10050, Currency: "BRL"},
+		Method:    entity.MethodPix,
+		Status:    entity.StatusCreated,
+		CreatedAt: createdAt,
+	}, nil)
+
+	output, err := NewGetPaymentUseCase(repo).Execute(context.Background(), dto.GetPaymentInput{ID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.GetPaymentOutput{
+		ID:        1,
+		Amount:    money.Money{Value: 10050, Currency: "BRL"},
+		Method:    entity.MethodPix,
+		Status:    string(entity.StatusCreated),
+		CreatedAt: createdAt,
+	}, output)
+}
+
+func TestGetPaymentExecuteNotFound(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(2)).Return(nil, nil)
+
+	output, err := NewGetPaymentUseCase(repo).Execute(context.Background(), dto.GetPaymentInput{ID: 2})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.NotFound{}, err)
+}
+
+func TestGetPaymentExecuteOtherMerchant(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(4)).Return(&entity.Payment{ID: 4, MerchantID: 7}, nil).Times(2)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 8})
+	output, err := NewGetPaymentUseCase(repo).Execute(ctx, dto.GetPaymentInput{ID: 4})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.NotFound{}, err)
+
+	ctx = auth.NewContext(context.Background(), auth.Principal{MerchantID: 7})
+	output, err = NewGetPaymentUseCase(repo).Execute(ctx, dto.GetPaymentInput{ID: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), output.ID)
+}
+
+func TestGetPaymentExecuteRepositoryError(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(3)).Return(nil, errors.New("connection refused"))
+
+	output, err := NewGetPaymentUseCase(repo).Execute(context.Background(), dto.GetPaymentInput{ID: 3})
+
+	assert.Nil(t, output)
+	assert.ErrorContains(t, err, "connection refused")
+}
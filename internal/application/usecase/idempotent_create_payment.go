@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/log"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// IdempotentCreatePaymentImplementation decorates CreatePayment so requests
+// carrying an idempotency key create at most one payment per key. The key is
+// locked and saved in the transaction the payment is created in, so both
+// commit or roll back together, and completed with the response once the
+// provider processed the payment outside of it.
+type IdempotentCreatePaymentImplementation struct {
+	next       *CreatePaymentImplementation
+	repository repository.IdempotencyKeyRepository
+	transactor repository.Transactor
+}
+
+func NewIdempotentCreatePaymentUseCase(
+	next *CreatePaymentImplementation,
+	repository repository.IdempotencyKeyRepository,
+	transactor repository.Transactor,
+) *IdempotentCreatePaymentImplementation {
+	return &IdempotentCreatePaymentImplementation{
+		next:       next,
+		repository: repository,
+		transactor: transactor,
+	}
+}
+
+func (uc *IdempotentCreatePaymentImplementation) Execute(ctx context.Context, input dto.CreatePaymentInput) (*dto.CreatePaymentOutput, error) {
+	if input.IdempotencyKey == "" {
+		return uc.next.Execute(ctx, input)
+	}
+
+	ctx, span := metrics.StartSpan(ctx, "IdempotentCreatePaymentUseCase.Execute")
+	defer span.End()
+
+	fingerprint, err := fingerprintCreatePayment(input)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entity.IdempotencyKey{
+		MerchantID:  auth.MerchantID(ctx),
+		Key:         input.IdempotencyKey,
+		Fingerprint: fingerprint,
+	}
+
+	// The lock is only held while the payment is stored, the provider is
+	// called once the payment and the key are committed
+	var output *dto.CreatePaymentOutput
+	var payment *entity.Payment
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.repository.Lock(ctx, record.MerchantID, record.Key)
+		if err != nil {
+			return fmt.Errorf("failed to lock idempotency key: %w", err)
+		}
+
+		if stored != nil {
+			output, err = replay(ctx, stored, fingerprint)
+			return err
+		}
+
+		payment, err = uc.next.Create(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		record.PaymentID = payment.ID
+		if err := uc.repository.Save(ctx, record); err != nil {
+			return fmt.Errorf("failed to store idempotency key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output != nil {
+		return output, nil
+	}
+
+	output, err = uc.next.Process(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment was processed either way, so a key left in progress only
+	// makes retries point to it
+	record.StatusCode = http.StatusCreated
+	record.Response, err = json.Marshal(output)
+	if err == nil {
+		err = uc.repository.Complete(ctx, record)
+	}
+	if err != nil {
+		log.Logger.WithContext(ctx).Tag("payment_id", payment.ID).Errorf("failed to complete idempotency key: %v", err)
+	}
+
+	return output, nil
+}
+
+// replay returns the response stored for the key, as long as it was stored
+// for the same request body and the payment of the key was processed.
+func replay(ctx context.Context, stored *entity.IdempotencyKey, fingerprint string) (*dto.CreatePaymentOutput, error) {
+	if stored.Fingerprint != fingerprint {
+		metrics.AddSpanEvent(ctx, "idempotency.key.mismatch")
+		return nil, appErr.NewUnprocessable("idempotency key already used with a different request body")
+	}
+
+	if stored.InProgress() {
+		metrics.AddSpanEvent(ctx, "idempotency.key.in_progress")
+		return nil, appErr.NewConflict(fmt.Sprintf("idempotency key is in use by payment %d, which is still being processed", stored.PaymentID))
+	}
+
+	var output dto.CreatePaymentOutput
+	if err := json.Unmarshal(stored.Response, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	output.Replayed = true
+	output.StatusCode = stored.StatusCode
+
+	metrics.AddSpanAttributes(ctx, attribute.Bool("idempotency.replayed", true))
+	return &output, nil
+}
+
+// fingerprintCreatePayment hashes the request body fields, so a key reused
+// with a different body can be told apart from a retry.
+func fingerprintCreatePayment(input dto.CreatePaymentInput) (string, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint request: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
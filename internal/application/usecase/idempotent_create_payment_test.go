@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var idempotentInput = dto.CreatePaymentInput{
+	Amount:         money.Money{Value: 10050, Currency: "USD"},
+	Method:         entity.MethodCard,
+	IdempotencyKey: "key-1",
+}
+
+// newCapturedPayment returns a CreatePayment storing the payment 7 and
+// capturing it, and its transactor. Transactions opened by the transactor
+// are counted in depth, which must be zero while the provider is called.
+func newCapturedPayment(t *testing.T, ctrl *gomock.Controller) (*CreatePaymentImplementation, repository.Transactor) {
+	depth := 0
+	transactor := repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			depth++
+			defer func() { depth-- }()
+			return fn(ctx)
+		}).
+		AnyTimes()
+	outside := func(context.Context, *entity.Payment) { assert.Zero(t, depth) }
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	expectCreated(repo, 7)
+	expectReference(t, repo, "ref-7")
+	expectTransitions(t, repo, "ref-7", entity.StatusCreated, entity.StatusProcessing, entity.StatusCompleted)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "7", "payment.created")
+	expectPaymentEvent(t, outbox, "7", "payment.processing")
+	expectPaymentEvent(t, outbox, "7", "payment.completed")
+
+	providers, p := mockProvider(ctrl)
+	p.EXPECT().Authorize(gomock.Any(), gomock.Any()).Do(outside).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusAuthorized}, nil)
+	p.EXPECT().Capture(gomock.Any(), "ref-7", gomock.Any()).Return(&provider.Result{Reference: "ref-7", Status: provider.StatusCaptured}, nil)
+
+	return NewCreatePaymentUseCase(repo, repository.NewMockMerchantRepository(ctrl), outbox, transactor, providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig), transactor
+}
+
+func TestIdempotentCreatePaymentWithoutKey(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	next, _ := newCapturedPayment(t, ctrl)
+	input := idempotentInput
+	input.IdempotencyKey = ""
+
+	uc := &IdempotentCreatePaymentImplementation{next: next, repository: repository.NewMockIdempotencyKeyRepository(ctrl)}
+	output, err := uc.Execute(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+}
+
+func TestIdempotentCreatePaymentFirstRequest(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	// The key and the payment are saved in a transaction of their own,
+	// committed before the provider is called
+	next, transactor := newCapturedPayment(t, ctrl)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(nil, nil),
+		repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, record *entity.IdempotencyKey) error {
+			assert.Equal(t, "key-1", record.Key)
+			assert.Equal(t, int64(7), record.PaymentID)
+			assert.Nil(t, record.Response)
+			return nil
+		}),
+		repo.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, record *entity.IdempotencyKey) error {
+			assert.Equal(t, 201, record.StatusCode)
+			assert.JSONEq(t, `{"id":7,"amount":{"value":10050,"currency":"USD"},"method":"CARD","status":"COMPLETED","created_at":"0001-01-01T00:00:00Z"}`, string(record.Response))
+			return nil
+		}),
+	)
+
+	uc := &IdempotentCreatePaymentImplementation{next: next, repository: repo, transactor: transactor}
+	output, err := uc.Execute(context.Background(), idempotentInput)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), output.ID)
+	assert.False(t, output.Replayed)
+}
+
+func TestIdempotentCreatePaymentReplay(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	fingerprint, _ := fingerprintCreatePayment(idempotentInput)
+	response, _ := json.Marshal(dto.CreatePaymentOutput{ID: 1, Amount: idempotentInput.Amount, Method: entity.MethodCard})
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(&entity.IdempotencyKey{
+		Key:         "key-1",
+		Fingerprint: fingerprint,
+		PaymentID:   1,
+		StatusCode:  200,
+		Response:    response,
+	}, nil)
+
+	uc := &IdempotentCreatePaymentImplementation{repository: repo, transactor: mockTransactor(ctrl)}
+	output, err := uc.Execute(context.Background(), idempotentInput)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), output.ID)
+	assert.True(t, output.Replayed)
+	assert.Equal(t, 200, output.StatusCode)
+}
+
+func TestIdempotentCreatePaymentInProgress(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	fingerprint, _ := fingerprintCreatePayment(idempotentInput)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(&entity.IdempotencyKey{
+		Key:         "key-1",
+		Fingerprint: fingerprint,
+		PaymentID:   1,
+	}, nil)
+
+	uc := &IdempotentCreatePaymentImplementation{repository: repo, transactor: mockTransactor(ctrl)}
+	output, err := uc.Execute(context.Background(), idempotentInput)
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.Conflict{}, err)
+	assert.ErrorContains(t, err, "payment 1")
+}
+
+func TestIdempotentCreatePaymentKeyReusedWithDifferentBody(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	original, _ := fingerprintCreatePayment(idempotentInput)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(&entity.IdempotencyKey{Key: "key-1", Fingerprint: original}, nil)
+
+	input := idempotentInput
+	input.Amount = money.Money{Value: 2000, Currency: "USD"}
+
+	uc := &IdempotentCreatePaymentImplementation{repository: repo, transactor: mockTransactor(ctrl)}
+	output, err := uc.Execute(context.Background(), input)
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.Unprocessable{}, err)
+}
+
+func TestIdempotentCreatePaymentScopedToMerchant(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(7), "key-1").Return(nil, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, record *entity.IdempotencyKey) error {
+		assert.Equal(t, int64(7), record.MerchantID)
+		return nil
+	})
+	repo.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, record *entity.IdempotencyKey) error {
+		assert.Equal(t, int64(7), record.MerchantID)
+		return nil
+	})
+
+	next, _ := newCapturedPayment(t, ctrl)
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().FindByID(gomock.Any(), int64(7)).Return(&entity.Merchant{
+		ID:             7,
+		Status:         entity.MerchantActive,
+		PaymentMethods: []string{entity.MethodCard},
+	}, nil)
+	next.merchants = merchants
+
+	uc := &IdempotentCreatePaymentImplementation{next: next, repository: repo, transactor: mockTransactor(ctrl)}
+	_, err := uc.Execute(auth.NewContext(context.Background(), auth.Principal{MerchantID: 7}), idempotentInput)
+
+	assert.NoError(t, err)
+}
+
+func TestIdempotentCreatePaymentFailureSavesNothing(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(nil, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+	providers, _ := mockProvider(ctrl)
+	next := NewCreatePaymentUseCase(payments, repository.NewMockMerchantRepository(ctrl), repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl), providers, repository.NewMockPixChargeRepository(ctrl), testPixConfig)
+
+	uc := &IdempotentCreatePaymentImplementation{next: next, repository: repo, transactor: mockTransactor(ctrl)}
+	output, err := uc.Execute(context.Background(), idempotentInput)
+
+	assert.Nil(t, output)
+	assert.ErrorContains(t, err, "connection reset")
+}
+
+func TestIdempotentCreatePaymentCompleteFailure(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockIdempotencyKeyRepository(ctrl)
+	repo.EXPECT().Lock(gomock.Any(), int64(0), "key-1").Return(nil, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Complete(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	next, _ := newCapturedPayment(t, ctrl)
+
+	// The payment was processed, so it's returned even if the key stays in
+	// progress
+	uc := &IdempotentCreatePaymentImplementation{next: next, repository: repo, transactor: mockTransactor(ctrl)}
+	output, err := uc.Execute(context.Background(), idempotentInput)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.StatusCompleted), output.Status)
+}
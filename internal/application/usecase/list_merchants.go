@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	"go-payments-api/pkg/metrics"
+)
+
+type ListMerchants = base.UseCase[dto.ListMerchantsInput, *dto.ListMerchantsOutput]
+
+type ListMerchantsImplementation struct {
+	repository repository.MerchantRepository
+}
+
+func NewListMerchantsUseCase(repository repository.MerchantRepository) *ListMerchantsImplementation {
+	return &ListMerchantsImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *ListMerchantsImplementation) Execute(ctx context.Context, input dto.ListMerchantsInput) (*dto.ListMerchantsOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ListMerchantsUseCase.Execute")
+	defer span.End()
+
+	merchants, err := uc.repository.List(ctx, entity.MerchantStatus(input.Status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchants: %w", err)
+	}
+
+	output := &dto.ListMerchantsOutput{Data: make([]dto.MerchantOutput, 0, len(merchants))}
+	for _, merchant := range merchants {
+		output.Data = append(output.Data, merchantOutput(merchant))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListPayments = base.UseCase[dto.ListPaymentsInput, *dto.ListPaymentsOutput]
+
+type ListPaymentsImplementation struct {
+	repository repository.PaymentRepository
+}
+
+func NewListPaymentsUseCase(repository repository.PaymentRepository) *ListPaymentsImplementation {
+	return &ListPaymentsImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *ListPaymentsImplementation) Execute(ctx context.Context, input dto.ListPaymentsInput) (*dto.ListPaymentsOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ListPaymentsUseCase.Execute")
+	defer span.End()
+
+	minAmount, maxAmount, err := parseAmountRange(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.CreatedFrom.IsZero() && !input.CreatedTo.IsZero() && !input.CreatedFrom.Before(input.CreatedTo) {
+		return nil, appErr.NewBadFormat("created_from must be before created_to")
+	}
+
+	filter := repository.PaymentFilter{
+		MerchantID:  auth.MerchantID(ctx),
+		Status:      entity.PaymentStatus(input.Status),
+		Method:      input.Method,
+		Currency:    money.Currency(strings.ToUpper(input.Currency)),
+		MinAmount:   minAmount,
+		MaxAmount:   maxAmount,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+	}
+
+	if input.Cursor != "" {
+		cursor, err := decodePaymentCursor(input.Cursor)
+		if err != nil {
+			return nil, appErr.NewBadFormat("invalid cursor")
+		}
+		filter.After = cursor
+	}
+
+	limit := input.Limit.Int()
+	// one extra row tells whether there is a next page without a count query
+	filter.Limit = limit + 1
+
+	metrics.AddSpanAttributes(ctx,
+		attribute.String("payment.filter.status", input.Status),
+		attribute.String("payment.filter.method", input.Method),
+		attribute.Int("payment.page.limit", limit),
+	)
+
+	payments, err := uc.repository.List(ctx, filter)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "payment.list.failed", attribute.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	output := &dto.ListPaymentsOutput{
+		Data: make([]dto.GetPaymentOutput, 0, limit),
+	}
+
+	if len(payments) > limit {
+		payments = payments[:limit]
+		last := payments[limit-1]
+		output.HasMore = true
+		output.NextCursor = encodePaymentCursor(repository.PaymentCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+	}
+
+	for _, payment := range payments {
+		output.Data = append(output.Data, dto.GetPaymentOutput{
+			ID:        payment.ID,
+			Amount:    payment.Amount,
+			Method:    payment.Method,
+			Status:    string(payment.Status),
+			CreatedAt: payment.CreatedAt,
+			UpdatedAt: payment.UpdatedAt,
+		})
+	}
+
+	return output, nil
+}
+
+// parseAmountRange reads the amount bounds of the listing, which are only
+// meaningful together with a currency.
+func parseAmountRange(input dto.ListPaymentsInput) (min, max *money.Money, err error) {
+	if input.Currency != "" && !money.Currency(strings.ToUpper(input.Currency)).IsValid() {
+		return nil, nil, appErr.NewBadFormat(fmt.Sprintf("unknown currency: %s", input.Currency))
+	}
+
+	if input.MinAmount == "" && input.MaxAmount == "" {
+		return nil, nil, nil
+	}
+
+	if input.Currency == "" {
+		return nil, nil, appErr.NewBadFormat("currency is required to filter by amount")
+	}
+
+	parse := func(name, amount string) (*money.Money, error) {
+		if amount == "" {
+			return nil, nil
+		}
+
+		m, err := money.Parse(amount, input.Currency)
+		if err != nil {
+			return nil, appErr.NewBadFormat(fmt.Sprintf("invalid %s: %v", name, err))
+		}
+		return &m, nil
+	}
+
+	if min, err = parse("min_amount", input.MinAmount); err != nil {
+		return nil, nil, err
+	}
+	if max, err = parse("max_amount", input.MaxAmount); err != nil {
+		return nil, nil, err
+	}
+
+	if min != nil && max != nil && min.Value > max.Value {
+		return nil, nil, appErr.NewBadFormat("min_amount must be lower than or equal to max_amount")
+	}
+
+	return min, max, nil
+}
+
+// encodePaymentCursor serializes the keyset position as an opaque token so
+// clients don't rely on its format.
+func encodePaymentCursor(cursor repository.PaymentCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePaymentCursor(token string) (*repository.PaymentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed cursor %q", raw)
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.PaymentCursor{
+		CreatedAt: time.Unix(0, nanos).UTC(),
+		ID:        id,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/constants"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListPaymentsExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	now := time.Now().UTC()
+	payments := make([]*entity.Payment, 0, 11)
+	for i := 11; i > 0; i-- {
+		payments = append(payments, &entity.Payment{
+			ID:        int64(i),
+			Method:    entity.MethodPix,
+			Status:    entity.StatusCreated,
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), repository.PaymentFilter{
+		Status: entity.StatusCreated,
+		Method: entity.MethodPix,
+		Limit:  11,
+	}).Return(payments, nil)
+
+	output, err := NewListPaymentsUseCase(repo).Execute(context.Background(), dto.ListPaymentsInput{
+		Status: string(entity.StatusCreated),
+		Method: entity.MethodPix,
+		Limit:  constants.TEN,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Data, 10)
+	assert.True(t, output.HasMore)
+
+	cursor, err := decodePaymentCursor(output.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.ID)
+	assert.True(t, payments[9].CreatedAt.Equal(cursor.CreatedAt))
+}
+
+func TestListPaymentsExecuteLastPage(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	after := repository.PaymentCursor{CreatedAt: time.Unix(1700000000, 0).UTC(), ID: 42}
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), repository.PaymentFilter{
+		After: &after,
+		Limit: 51,
+	}).Return([]*entity.Payment{{ID: 41}}, nil)
+
+	output, err := NewListPaymentsUseCase(repo).Execute(context.Background(), dto.ListPaymentsInput{
+		Cursor: encodePaymentCursor(after),
+		Limit:  constants.FIFTY,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Data, 1)
+	assert.False(t, output.HasMore)
+	assert.Empty(t, output.NextCursor)
+}
+
+func TestListPaymentsExecuteAmountRange(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), repository.PaymentFilter{
+		Currency:  "BRL",
+		MinAmount: &money.Money{Value: 1000, Currency: "BRL"},
+		MaxAmount: &money.Money{Value: 50050, Currency: "BRL"},
+		Limit:     11,
+	}).Return(nil, nil)
+
+	output, err := NewListPaymentsUseCase(repo).Execute(context.Background(), dto.ListPaymentsInput{
+		Currency:  "brl",
+		MinAmount: "10",
+		MaxAmount: "500.50",
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, output.Data)
+}
+
+func TestListPaymentsExecuteInvalidInput(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	uc := NewListPaymentsUseCase(repository.NewMockPaymentRepository(ctrl))
+
+	cases := map[string]dto.ListPaymentsInput{
+		"invalid cursor":          {Cursor: "not a cursor"},
+		"inverted amounts":        {Currency: "BRL", MinAmount: "10", MaxAmount: "5"},
+		"amount without currency": {MinAmount: "10"},
+		"too many decimals":       {Currency: "BRL", MinAmount: "10.001"},
+		"unknown currency":        {Currency: "XYZ"},
+		"inverted time window":    {CreatedFrom: time.Now(), CreatedTo: time.Now().Add(-time.Hour)},
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			output, err := uc.Execute(context.Background(), input)
+
+			assert.Nil(t, output)
+			assert.IsType(t, appErr.BadFormat{}, err)
+		})
+	}
+}
+
+func TestListPaymentsExecuteScopedToMerchant(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), repository.PaymentFilter{
+		MerchantID: 7,
+		Limit:      11,
+	}).Return(nil, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 7})
+	output, err := NewListPaymentsUseCase(repo).Execute(ctx, dto.ListPaymentsInput{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, output.Data)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListRefunds = base.UseCase[dto.GetPaymentInput, *dto.ListRefundsOutput]
+
+type ListRefundsImplementation struct {
+	payments repository.PaymentRepository
+	refunds  repository.RefundRepository
+}
+
+func NewListRefundsUseCase(payments repository.PaymentRepository, refunds repository.RefundRepository) *ListRefundsImplementation {
+	return &ListRefundsImplementation{
+		payments: payments,
+		refunds:  refunds,
+	}
+}
+
+func (uc *ListRefundsImplementation) Execute(ctx context.Context, input dto.GetPaymentInput) (*dto.ListRefundsOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ListRefundsUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("payment.id", input.ID))
+
+	payment, err := uc.payments.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil || !auth.CanAccess(ctx, payment.MerchantID) {
+		return nil, appErr.NewNotFound(fmt.Sprintf("payment %d not found", input.ID))
+	}
+
+	refunds, err := uc.refunds.ListByPayment(ctx, payment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+
+	output := &dto.ListRefundsOutput{Data: make([]dto.RefundOutput, 0, len(refunds))}
+	for _, refund := range refunds {
+		output.Data = append(output.Data, refundOutput(refund))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListWebhookDeliveries = base.UseCase[dto.ListWebhookDeliveriesInput, *dto.ListWebhookDeliveriesOutput]
+
+type ListWebhookDeliveriesImplementation struct {
+	repository repository.WebhookDeliveryRepository
+}
+
+func NewListWebhookDeliveriesUseCase(repository repository.WebhookDeliveryRepository) *ListWebhookDeliveriesImplementation {
+	return &ListWebhookDeliveriesImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *ListWebhookDeliveriesImplementation) Execute(ctx context.Context, input dto.ListWebhookDeliveriesInput) (*dto.ListWebhookDeliveriesOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ListWebhookDeliveriesUseCase.Execute")
+	defer span.End()
+
+	filter := repository.WebhookDeliveryFilter{
+		MerchantID:     auth.MerchantID(ctx),
+		SubscriptionID: input.SubscriptionID,
+		Status:         entity.WebhookDeliveryStatus(input.Status),
+	}
+
+	if input.Cursor != "" {
+		id, err := decodeDeliveryCursor(input.Cursor)
+		if err != nil {
+			return nil, appErr.NewBadFormat("invalid cursor")
+		}
+		filter.BeforeID = id
+	}
+
+	limit := input.Limit.Int()
+	// one extra row tells whether there is a next page without a count query
+	filter.Limit = limit + 1
+
+	metrics.AddSpanAttributes(ctx,
+		attribute.String("webhook.filter.status", input.Status),
+		attribute.Int64("webhook.filter.subscription_id", input.SubscriptionID),
+		attribute.Int("webhook.page.limit", limit),
+	)
+
+	deliveries, err := uc.repository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	output := &dto.ListWebhookDeliveriesOutput{
+		Data: make([]dto.WebhookDeliveryOutput, 0, limit),
+	}
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		output.HasMore = true
+		output.NextCursor = encodeDeliveryCursor(deliveries[limit-1].ID)
+	}
+
+	for _, delivery := range deliveries {
+		output.Data = append(output.Data, webhookDeliveryOutput(delivery))
+	}
+
+	return output, nil
+}
+
+func encodeDeliveryCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeDeliveryCursor(token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(raw), 10, 64)
+}
+
+func webhookDeliveryOutput(delivery *entity.WebhookDelivery) dto.WebhookDeliveryOutput {
+	return dto.WebhookDeliveryOutput{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastError:      delivery.LastError,
+		ResponseStatus: delivery.ResponseStatus,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
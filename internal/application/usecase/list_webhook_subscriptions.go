@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	"go-payments-api/pkg/metrics"
+)
+
+type ListWebhookSubscriptions = base.UseCase[dto.ListWebhookSubscriptionsInput, *dto.ListWebhookSubscriptionsOutput]
+
+type ListWebhookSubscriptionsImplementation struct {
+	repository repository.WebhookSubscriptionRepository
+}
+
+func NewListWebhookSubscriptionsUseCase(repository repository.WebhookSubscriptionRepository) *ListWebhookSubscriptionsImplementation {
+	return &ListWebhookSubscriptionsImplementation{
+		repository: repository,
+	}
+}
+
+func (uc *ListWebhookSubscriptionsImplementation) Execute(ctx context.Context, _ dto.ListWebhookSubscriptionsInput) (*dto.ListWebhookSubscriptionsOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ListWebhookSubscriptionsUseCase.Execute")
+	defer span.End()
+
+	subscriptions, err := uc.repository.List(ctx, auth.MerchantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	output := &dto.ListWebhookSubscriptionsOutput{Data: make([]dto.WebhookSubscriptionOutput, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		output.Data = append(output.Data, webhookSubscriptionOutput(subscription))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateMerchantExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, merchant *entity.Merchant) error {
+			assert.Equal(t, entity.MerchantActive, merchant.Status)
+			assert.Equal(t, entity.DefaultSettlement, merchant.Settlement)
+			merchant.ID = 1
+			return nil
+		})
+
+	output, err := NewCreateMerchantUseCase(merchants).Execute(context.Background(), dto.CreateMerchantInput{
+		Name:           "Acme Store",
+		PaymentMethods: []string{entity.MethodPix},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), output.ID)
+	assert.Equal(t, []string{entity.MethodPix}, output.PaymentMethods)
+	assert.Equal(t, dto.Settlement{Schedule: "DAILY", DelayDays: 1}, output.Settlement)
+}
+
+func TestUpdateMerchantExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Merchant{
+		ID:             1,
+		Name:           "Acme Store",
+		Status:         entity.MerchantActive,
+		PaymentMethods: []string{entity.MethodPix},
+		Settlement:     entity.DefaultSettlement,
+	}, nil)
+	merchants.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, merchant *entity.Merchant) error {
+			assert.Equal(t, "Acme Store", merchant.Name)
+			assert.Equal(t, entity.MerchantSuspended, merchant.Status)
+			assert.Equal(t, []string{entity.MethodPix, entity.MethodCard}, merchant.PaymentMethods)
+			assert.Equal(t, entity.DefaultSettlement, merchant.Settlement)
+			return nil
+		})
+
+	status := string(entity.MerchantSuspended)
+	output, err := NewUpdateMerchantUseCase(merchants).Execute(context.Background(), dto.UpdateMerchantInput{
+		ID:             1,
+		Status:         &status,
+		PaymentMethods: []string{entity.MethodPix, entity.MethodCard},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, status, output.Status)
+}
+
+func TestUpdateMerchantExecuteClosed(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Merchant{ID: 1, Status: entity.MerchantClosed}, nil)
+
+	status := string(entity.MerchantActive)
+	output, err := NewUpdateMerchantUseCase(merchants).Execute(context.Background(), dto.UpdateMerchantInput{ID: 1, Status: &status})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.Conflict{}, err)
+}
+
+func TestGetMerchantExecuteNotFound(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	merchants := repository.NewMockMerchantRepository(ctrl)
+	merchants.EXPECT().FindByID(gomock.Any(), int64(2)).Return(nil, nil)
+
+	output, err := NewGetMerchantUseCase(merchants).Execute(context.Background(), dto.GetMerchantInput{ID: 2})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.NotFound{}, err)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/cloudevents"
+	"strconv"
+	"strings"
+)
+
+const (
+	paymentEventsTopic  = "payment.events"
+	paymentEventsSource = "/go-payments-api"
+
+	// Bump the schema version on breaking changes to the event data
+	paymentEventSchema = "urn:go-payments-api:schema:payment-event:1"
+	refundEventSchema  = "urn:go-payments-api:schema:refund-event:1"
+)
+
+// paymentEventType names the event published when a payment reaches status,
+// e.g. payment.completed.
+func paymentEventType(status entity.PaymentStatus) string {
+	return "payment." + strings.ToLower(string(status))
+}
+
+// enqueuePaymentEvent stores the payment.<status> event in the outbox. It
+// must run in the same transaction as the payment change so the event is
+// relayed if and only if the change is committed.
+func enqueuePaymentEvent(ctx context.Context, outbox repository.OutboxRepository, payment *entity.Payment) error {
+	return enqueueEvent(ctx, outbox, payment.ID, payment.MerchantID, paymentEventType(payment.Status),
+		fmt.Sprintf("payments/%d", payment.ID), paymentEventSchema, dto.PaymentEvent{
+			ID:        payment.ID,
+			Amount:    payment.Amount,
+			Method:    payment.Method,
+			Status:    string(payment.Status),
+			CreatedAt: payment.CreatedAt,
+		})
+}
+
+// refundEventType names the event published when a refund reaches status:
+// refund.created for new refunds, refund.<status> afterwards.
+func refundEventType(status entity.RefundStatus) string {
+	if status == entity.RefundStatusPending {
+		return "refund.created"
+	}
+	return "refund." + strings.ToLower(string(status))
+}
+
+// enqueueRefundEvent stores the refund.<status> event of the payment in the
+// outbox. Refund events share the payment topic and key so they're ordered
+// with the payment events.
+func enqueueRefundEvent(ctx context.Context, outbox repository.OutboxRepository, payment *entity.Payment, refund *entity.Refund) error {
+	return enqueueEvent(ctx, outbox, payment.ID, payment.MerchantID, refundEventType(refund.Status),
+		fmt.Sprintf("refunds/%d", refund.ID), refundEventSchema, dto.RefundEvent{
+			ID:        refund.ID,
+			PaymentID: refund.PaymentID,
+			Amount:    refund.Amount,
+			Status:    string(refund.Status),
+			Reason:    refund.Reason,
+			CreatedAt: refund.CreatedAt,
+		})
+}
+
+// enqueueEvent wraps data in a CloudEvents envelope traced to the span in
+// ctx and stores it in the outbox keyed by the payment it concerns. The
+// merchant of the payment is stored along, so only its webhook subscriptions
+// receive the event.
+func enqueueEvent(
+	ctx context.Context,
+	outbox repository.OutboxRepository,
+	paymentID, merchantID int64,
+	eventType, subject, schema string,
+	data interface{},
+) error {
+	event, err := cloudevents.New(ctx, paymentEventsSource, eventType, subject, schema, data)
+	if err != nil {
+		return fmt.Errorf("failed to build %s event: %w", eventType, err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	if err := outbox.Enqueue(ctx, &entity.OutboxMessage{
+		Topic:      paymentEventsTopic,
+		Key:        strconv.FormatInt(paymentID, 10),
+		MerchantID: merchantID,
+		Payload:    payload,
+		Headers:    event.Headers(),
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/cloudevents"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// mockTransactor returns a Transactor that runs every function it gets
+// without a real transaction.
+func mockTransactor(ctrl *gomock.Controller) *repository.MockTransactor {
+	transactor := repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	return transactor
+}
+
+// unmarshalEvent decodes the CloudEvents envelope in message and its data
+// into data.
+func unmarshalEvent(t *testing.T, message *entity.OutboxMessage, data interface{}) cloudevents.Event {
+	var envelope cloudevents.Event
+	assert.NoError(t, json.Unmarshal(message.Payload, &envelope))
+	assert.Equal(t, cloudevents.SpecVersion, envelope.SpecVersion)
+	assert.Equal(t, paymentEventsSource, envelope.Source)
+	assert.NotEmpty(t, envelope.ID)
+	assert.NotContains(t, message.Headers, cloudevents.HeaderContentType)
+	assert.NoError(t, json.Unmarshal(envelope.Data, data))
+	return envelope
+}
+
+// expectPaymentEvent makes outbox expect one event of eventType for the
+// payment with the given id.
+func expectPaymentEvent(t *testing.T, outbox *repository.MockOutboxRepository, id string, eventType string) {
+	outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *entity.OutboxMessage) error {
+			var event dto.PaymentEvent
+			envelope := unmarshalEvent(t, message, &event)
+			assert.Equal(t, paymentEventsTopic, message.Topic)
+			assert.Equal(t, id, message.Key)
+			assert.Equal(t, eventType, envelope.Type)
+			assert.Equal(t, paymentEventSchema, envelope.DataSchema)
+			assert.Equal(t, "payments/"+id, envelope.Subject)
+			return nil
+		})
+}
+
+// expectRefundEvent makes outbox expect one refund event of eventType.
+func expectRefundEvent(t *testing.T, outbox *repository.MockOutboxRepository, eventType string) *gomock.Call {
+	return outbox.EXPECT().Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *entity.OutboxMessage) error {
+			var event dto.RefundEvent
+			envelope := unmarshalEvent(t, message, &event)
+			assert.Equal(t, paymentEventsTopic, message.Topic)
+			assert.Equal(t, strconv.FormatInt(event.PaymentID, 10), message.Key)
+			assert.Equal(t, eventType, envelope.Type)
+			assert.Equal(t, refundEventSchema, envelope.DataSchema)
+			return nil
+		})
+}
+
+func TestPaymentEventType(t *testing.T) {
+	assert.Equal(t, "payment.created", paymentEventType(entity.StatusCreated))
+	assert.Equal(t, "payment.completed", paymentEventType(entity.StatusCompleted))
+}
+
+func TestRefundEventType(t *testing.T) {
+	assert.Equal(t, "refund.created", refundEventType(entity.RefundStatusPending))
+	assert.Equal(t, "refund.completed", refundEventType(entity.RefundStatusCompleted))
+	assert.Equal(t, "refund.failed", refundEventType(entity.RefundStatusFailed))
+}
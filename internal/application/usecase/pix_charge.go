@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/brcode"
+	"time"
+)
+
+// PixConfig describes the receiver of PIX charges and how long they last.
+type PixConfig struct {
+	Key          string
+	MerchantName string
+	MerchantCity string
+	Expiration   time.Duration
+	QRCodeSize   int
+}
+
+// issuePixCharge stores the BR Code the payer uses to pay payment.
+func issuePixCharge(ctx context.Context, charges repository.PixChargeRepository, config PixConfig, payment *entity.Payment) (*entity.PixCharge, error) {
+	charge := &entity.PixCharge{
+		PaymentID: payment.ID,
+		TxID:      entity.PixTxID(payment.ID),
+		ExpiresAt: time.Now().Add(config.Expiration),
+	}
+
+	var err error
+	charge.Payload, err = brcode.Payload{
+		Key:          config.Key,
+		MerchantName: config.MerchantName,
+		MerchantCity: config.MerchantCity,
+		Amount:       payment.Amount.Decimal(),
+		TxID:         charge.TxID,
+		OneTime:      true,
+	}.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pix charge: %w", err)
+	}
+
+	if err := charges.Create(ctx, charge); err != nil {
+		return nil, fmt.Errorf("failed to create pix charge: %w", err)
+	}
+
+	return charge, nil
+}
+
+func pixChargeOutput(charge *entity.PixCharge, qrCodeSize int) (*dto.PixChargeOutput, error) {
+	png, err := brcode.QRCode(charge.Payload, qrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render pix qr code: %w", err)
+	}
+
+	return &dto.PixChargeOutput{
+		TxID:      charge.TxID,
+		Payload:   charge.Payload,
+		QRCode:    base64.StdEncoding.EncodeToString(png),
+		ExpiresAt: charge.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+)
+
+const paymentStatusNotification = "payment.status"
+
+// providerPaymentStatuses maps the statuses providers report to the payment
+// status they lead to.
+var providerPaymentStatuses = map[provider.Status]entity.PaymentStatus{
+	provider.StatusPending:    entity.StatusProcessing,
+	provider.StatusAuthorized: entity.StatusProcessing,
+	provider.StatusCaptured:   entity.StatusCompleted,
+	provider.StatusDeclined:   entity.StatusFailed,
+	provider.StatusVoided:     entity.StatusCanceled,
+}
+
+// rejectedNotification is returned for notifications that can't be applied
+// as they are, so they are kept for replay instead of retried.
+type rejectedNotification struct {
+	reason string
+}
+
+func (e rejectedNotification) Error() string {
+	return e.reason
+}
+
+// applyProviderNotification moves the payment the notification refers to to
+// the status the provider reports. Notifications for a status the payment is
+// already in are accepted without changes.
+func applyProviderNotification(
+	ctx context.Context,
+	payments repository.PaymentRepository,
+	outbox repository.OutboxRepository,
+	notification dto.ProviderNotification,
+) error {
+	if notification.Type != paymentStatusNotification {
+		return rejectedNotification{fmt.Sprintf("unknown notification type %q", notification.Type)}
+	}
+
+	next, ok := providerPaymentStatuses[provider.Status(notification.Status)]
+	if !ok {
+		return rejectedNotification{fmt.Sprintf("unknown provider status %q", notification.Status)}
+	}
+
+	if notification.Reference == "" {
+		return rejectedNotification{"missing provider reference"}
+	}
+
+	payment, err := payments.FindByProviderReference(ctx, notification.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil {
+		return rejectedNotification{fmt.Sprintf("no payment with provider reference %s", notification.Reference)}
+	}
+
+	if payment.Status == next {
+		return nil
+	}
+
+	previous := payment.Status
+	if err := payment.TransitionTo(next); err != nil {
+		return rejectedNotification{err.Error()}
+	}
+
+	if err := payments.UpdateStatus(ctx, payment, previous); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	return enqueuePaymentEvent(ctx, outbox, payment)
+}
+
+// receiveProviderNotification stores the notification the provider sent as
+// body and applies it, in one transaction, unless an event with the same id
+// was already received from the provider, in which case the stored event is
+// returned and duplicate is true.
+func receiveProviderNotification(
+	ctx context.Context,
+	payments repository.PaymentRepository,
+	events repository.WebhookEventRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+	providerName string,
+	body []byte,
+	notification dto.ProviderNotification,
+) (event *entity.WebhookEvent, duplicate bool, err error) {
+	event = &entity.WebhookEvent{
+		Provider: providerName,
+		EventID:  notification.ID,
+		Type:     notification.Type,
+		Payload:  body,
+		Status:   entity.WebhookEventRejected,
+	}
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := events.Create(ctx, event)
+		if errors.Is(err, repository.ErrDuplicateWebhookEvent) {
+			duplicate = true
+			stored, err := events.Find(ctx, event.Provider, event.EventID)
+			if err != nil {
+				return fmt.Errorf("failed to find webhook event: %w", err)
+			}
+			if stored != nil {
+				event = stored
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to store webhook event: %w", err)
+		}
+
+		applyErr := applyProviderNotification(ctx, payments, outbox, notification)
+		if err := settleWebhookEvent(event, applyErr); err != nil {
+			return err
+		}
+
+		return events.Update(ctx, event)
+	})
+
+	return event, duplicate, err
+}
+
+// settleWebhookEvent records the outcome of applying event on it.
+// Rejections are kept as the event error; any other error is returned so the
+// transaction rolls back and the provider retries.
+func settleWebhookEvent(event *entity.WebhookEvent, applyErr error) error {
+	var rejected rejectedNotification
+	if applyErr != nil && !errors.As(applyErr, &rejected) {
+		return applyErr
+	}
+
+	event.Attempts++
+	event.Status = entity.WebhookEventProcessed
+	event.Error = ""
+	if applyErr != nil {
+		event.Status = entity.WebhookEventRejected
+		event.Error = rejected.reason
+	}
+
+	return nil
+}
+
+func webhookOutput(event *entity.WebhookEvent, duplicate bool) *dto.WebhookOutput {
+	return &dto.WebhookOutput{
+		EventID:   event.EventID,
+		Status:    string(event.Status),
+		Error:     event.Error,
+		Duplicate: duplicate,
+	}
+}
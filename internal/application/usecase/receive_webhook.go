@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/signature"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ReceiveWebhook = base.UseCase[dto.ReceiveWebhookInput, *dto.WebhookOutput]
+
+// WebhookConfig holds the secret each provider signs its webhooks with and
+// how far their timestamps may be from our clock.
+type WebhookConfig struct {
+	Secrets   map[string]string
+	Tolerance time.Duration
+}
+
+type ReceiveWebhookImplementation struct {
+	payments   repository.PaymentRepository
+	events     repository.WebhookEventRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	config     WebhookConfig
+	now        func() time.Time
+}
+
+func NewReceiveWebhookUseCase(
+	payments repository.PaymentRepository,
+	events repository.WebhookEventRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+	config WebhookConfig,
+) *ReceiveWebhookImplementation {
+	return &ReceiveWebhookImplementation{
+		payments:   payments,
+		events:     events,
+		outbox:     outbox,
+		transactor: transactor,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// Execute authenticates a provider notification and applies it once, no
+// matter how many times the provider delivers it.
+func (uc *ReceiveWebhookImplementation) Execute(ctx context.Context, input dto.ReceiveWebhookInput) (*dto.WebhookOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ReceiveWebhookUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.String("webhook.provider", input.Provider))
+
+	secret, ok := uc.config.Secrets[input.Provider]
+	if !ok {
+		return nil, appErr.NewNotFound(fmt.Sprintf("unknown webhook provider %s", input.Provider))
+	}
+
+	if err := signature.Verify(secret, input.Timestamp, input.Signature, input.Body, uc.now(), uc.config.Tolerance); err != nil {
+		metrics.AddSpanEvent(ctx, "webhook.signature.rejected", attribute.String("error", err.Error()))
+		return nil, appErr.NewUnauthorized(err.Error())
+	}
+
+	var notification dto.ProviderNotification
+	if err := json.Unmarshal(input.Body, &notification); err != nil || notification.ID == "" {
+		return nil, appErr.NewBadFormat("webhook body must be a notification with an id")
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.String("webhook.event.id", notification.ID))
+
+	event, duplicate, err := receiveProviderNotification(ctx, uc.payments, uc.events, uc.outbox, uc.transactor, input.Provider, input.Body, notification)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "webhook.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	return webhookOutput(event, duplicate), nil
+}
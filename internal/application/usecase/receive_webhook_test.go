@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/signature"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testWebhookConfig = WebhookConfig{
+	Secrets:   map[string]string{"simulator": "secret"},
+	Tolerance: 5 * time.Minute,
+}
+
+// signedWebhook returns the input of a webhook the simulator signed now.
+func signedWebhook(body string) dto.ReceiveWebhookInput {
+	now := time.Now()
+	return dto.ReceiveWebhookInput{
+		Provider:  "simulator",
+		Timestamp: strconv.FormatInt(now.Unix(), 10),
+		Signature: signature.Sign("secret", now, []byte(body)),
+		Body:      []byte(body),
+	}
+}
+
+func TestReceiveWebhookExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByProviderReference(gomock.Any(), "ref-1").Return(&entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10050, Currency: "BRL"},
+		Method: entity.MethodPix,
+		Status: entity.StatusProcessing,
+	}, nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(nil)
+
+	events := repository.NewMockWebhookEventRepository(ctrl)
+	events.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	events.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *entity.WebhookEvent) error {
+			assert.Equal(t, entity.WebhookEventProcessed, event.Status)
+			assert.Equal(t, 1, event.Attempts)
+			return nil
+		})
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "1", "payment.completed")
+
+	output, err := NewReceiveWebhookUseCase(payments, events, outbox, mockTransactor(ctrl), testWebhookConfig).
+		Execute(context.Background(), signedWebhook(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "evt_1", output.EventID)
+	assert.Equal(t, string(entity.WebhookEventProcessed), output.Status)
+	assert.False(t, output.Duplicate)
+}
+
+func TestReceiveWebhookExecuteRejected(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		payment *entity.Payment
+	}{
+		"unknown type":      {body: `{"id":"evt_1","type":"payment.dispute","reference":"ref-1","status":"CAPTURED"}`},
+		"unknown status":    {body: `{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CHARGEBACK"}`},
+		"unknown reference": {body: `{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`},
+		"invalid transition": {
+			body:    `{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`,
+			payment: &entity.Payment{ID: 1, Status: entity.StatusExpired},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			payments := repository.NewMockPaymentRepository(ctrl)
+			payments.EXPECT().FindByProviderReference(gomock.Any(), "ref-1").Return(tc.payment, nil).AnyTimes()
+
+			events := repository.NewMockWebhookEventRepository(ctrl)
+			events.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			events.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			output, err := NewReceiveWebhookUseCase(payments, events, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl), testWebhookConfig).
+				Execute(context.Background(), signedWebhook(tc.body))
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(entity.WebhookEventRejected), output.Status)
+			assert.NotEmpty(t, output.Error)
+		})
+	}
+}
+
+func TestReceiveWebhookExecuteDuplicate(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	events := repository.NewMockWebhookEventRepository(ctrl)
+	events.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateWebhookEvent)
+	events.EXPECT().Find(gomock.Any(), "simulator", "evt_1").Return(&entity.WebhookEvent{
+		EventID: "evt_1",
+		Status:  entity.WebhookEventProcessed,
+	}, nil)
+
+	output, err := NewReceiveWebhookUseCase(repository.NewMockPaymentRepository(ctrl), events, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl), testWebhookConfig).
+		Execute(context.Background(), signedWebhook(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`))
+
+	assert.NoError(t, err)
+	assert.True(t, output.Duplicate)
+	assert.Equal(t, string(entity.WebhookEventProcessed), output.Status)
+}
+
+func TestReceiveWebhookExecuteUnauthenticated(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	uc := NewReceiveWebhookUseCase(
+		repository.NewMockPaymentRepository(ctrl),
+		repository.NewMockWebhookEventRepository(ctrl),
+		repository.NewMockOutboxRepository(ctrl),
+		repository.NewMockTransactor(ctrl),
+		testWebhookConfig,
+	)
+
+	unknownProvider := signedWebhook(`{"id":"evt_1"}`)
+	unknownProvider.Provider = "acme"
+
+	tampered := signedWebhook(`{"id":"evt_1"}`)
+	tampered.Body = []byte(`{"id":"evt_2"}`)
+
+	unsigned := signedWebhook(`{"id":"evt_1"}`)
+	unsigned.Signature = ""
+
+	cases := map[string]struct {
+		input dto.ReceiveWebhookInput
+		want  error
+	}{
+		"unknown provider": {input: unknownProvider, want: appErr.NotFound{}},
+		"tampered body":    {input: tampered, want: appErr.Unauthorized{}},
+		"unsigned":         {input: unsigned, want: appErr.Unauthorized{}},
+		"missing event id": {input: signedWebhook(`{"type":"payment.status"}`), want: appErr.BadFormat{}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			output, err := uc.Execute(context.Background(), tc.input)
+
+			assert.Nil(t, output)
+			assert.IsType(t, tc.want, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RedeliverWebhook = base.UseCase[dto.RedeliverWebhookInput, *dto.WebhookDeliveryOutput]
+
+type RedeliverWebhookImplementation struct {
+	repository repository.WebhookDeliveryRepository
+}
+
+func NewRedeliverWebhookUseCase(repository repository.WebhookDeliveryRepository) *RedeliverWebhookImplementation {
+	return &RedeliverWebhookImplementation{
+		repository: repository,
+	}
+}
+
+// Execute schedules a delivered or dead-lettered delivery to be sent again
+// with a fresh set of attempts. Pending deliveries are left to the
+// dispatcher, which is the only one touching them.
+func (uc *RedeliverWebhookImplementation) Execute(ctx context.Context, input dto.RedeliverWebhookInput) (*dto.WebhookDeliveryOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "RedeliverWebhookUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("webhook.delivery.id", input.ID))
+
+	delivery, err := uc.repository.Find(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+
+	if delivery == nil || !auth.CanAccess(ctx, delivery.MerchantID) {
+		return nil, appErr.NewNotFound(fmt.Sprintf("webhook delivery %d not found", input.ID))
+	}
+
+	if delivery.Status == entity.WebhookDeliveryPending {
+		return nil, appErr.NewConflict(fmt.Sprintf("webhook delivery %d is already scheduled", input.ID))
+	}
+
+	delivery.Redeliver(time.Now())
+
+	if err := uc.repository.Update(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+
+	output := webhookDeliveryOutput(delivery)
+	return &output, nil
+}
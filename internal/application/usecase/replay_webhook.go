@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ReplayWebhook = base.UseCase[dto.ReplayWebhookInput, *dto.WebhookOutput]
+
+type ReplayWebhookImplementation struct {
+	payments   repository.PaymentRepository
+	events     repository.WebhookEventRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+}
+
+func NewReplayWebhookUseCase(
+	payments repository.PaymentRepository,
+	events repository.WebhookEventRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+) *ReplayWebhookImplementation {
+	return &ReplayWebhookImplementation{
+		payments:   payments,
+		events:     events,
+		outbox:     outbox,
+		transactor: transactor,
+	}
+}
+
+// Execute applies a rejected webhook event again, e.g. after the payment it
+// refers to was fixed.
+func (uc *ReplayWebhookImplementation) Execute(ctx context.Context, input dto.ReplayWebhookInput) (*dto.WebhookOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "ReplayWebhookUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx,
+		attribute.String("webhook.provider", input.Provider),
+		attribute.String("webhook.event.id", input.EventID),
+	)
+
+	var event *entity.WebhookEvent
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		event, err = uc.events.Find(ctx, input.Provider, input.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to find webhook event: %w", err)
+		}
+
+		if event == nil {
+			return appErr.NewNotFound(fmt.Sprintf("webhook event %s from %s not found", input.EventID, input.Provider))
+		}
+
+		if event.Status != entity.WebhookEventRejected {
+			return appErr.NewConflict(fmt.Sprintf("webhook event %s is %s, only rejected events can be replayed", event.EventID, event.Status))
+		}
+
+		var notification dto.ProviderNotification
+		if err := json.Unmarshal(event.Payload, &notification); err != nil {
+			return fmt.Errorf("invalid payload stored for webhook event %d: %w", event.ID, err)
+		}
+
+		applyErr := applyProviderNotification(ctx, uc.payments, uc.outbox, notification)
+		if err := settleWebhookEvent(event, applyErr); err != nil {
+			return err
+		}
+
+		return uc.events.Update(ctx, event)
+	})
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "webhook.replay.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	return webhookOutput(event, false), nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayWebhookExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	events := repository.NewMockWebhookEventRepository(ctrl)
+	events.EXPECT().Find(gomock.Any(), "simulator", "evt_1").Return(&entity.WebhookEvent{
+		EventID:  "evt_1",
+		Payload:  []byte(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"DECLINED"}`),
+		Status:   entity.WebhookEventRejected,
+		Error:    "no payment with provider reference ref-1",
+		Attempts: 1,
+	}, nil)
+	events.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, event *entity.WebhookEvent) error {
+			assert.Equal(t, 2, event.Attempts)
+			assert.Empty(t, event.Error)
+			return nil
+		})
+
+	payments := repository.NewMockPaymentRepository(ctrl)
+	payments.EXPECT().FindByProviderReference(gomock.Any(), "ref-1").Return(&entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10050, Currency: "BRL"},
+		Status: entity.StatusProcessing,
+	}, nil)
+	payments.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(nil)
+
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	expectPaymentEvent(t, outbox, "1", "payment.failed")
+
+	output, err := NewReplayWebhookUseCase(payments, events, outbox, mockTransactor(ctrl)).Execute(context.Background(), dto.ReplayWebhookInput{
+		Provider: "simulator",
+		EventID:  "evt_1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.WebhookEventProcessed), output.Status)
+}
+
+func TestReplayWebhookExecuteInvalid(t *testing.T) {
+	cases := map[string]struct {
+		event *entity.WebhookEvent
+		want  error
+	}{
+		"not found": {event: nil, want: appErr.NotFound{}},
+		"processed": {event: &entity.WebhookEvent{EventID: "evt_1", Status: entity.WebhookEventProcessed}, want: appErr.Conflict{}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			events := repository.NewMockWebhookEventRepository(ctrl)
+			events.EXPECT().Find(gomock.Any(), "simulator", "evt_1").Return(tc.event, nil)
+
+			output, err := NewReplayWebhookUseCase(repository.NewMockPaymentRepository(ctrl), events, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).Execute(context.Background(), dto.ReplayWebhookInput{
+				Provider: "simulator",
+				EventID:  "evt_1",
+			})
+
+			assert.Nil(t, output)
+			assert.IsType(t, tc.want, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type UpdateMerchant = base.UseCase[dto.UpdateMerchantInput, *dto.MerchantOutput]
+
+type UpdateMerchantImplementation struct {
+	repository repository.MerchantRepository
+}
+
+func NewUpdateMerchantUseCase(repository repository.MerchantRepository) *UpdateMerchantImplementation {
+	return &UpdateMerchantImplementation{
+		repository: repository,
+	}
+}
+
+// Execute changes the fields set in input. Closed merchants keep their
+// payments for reporting but can't be changed or reopened.
+func (uc *UpdateMerchantImplementation) Execute(ctx context.Context, input dto.UpdateMerchantInput) (*dto.MerchantOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "UpdateMerchantUseCase.Execute")
+	defer span.End()
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("merchant.id", input.ID))
+
+	merchant, err := uc.repository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchant: %w", err)
+	}
+
+	if merchant == nil {
+		return nil, appErr.NewNotFound(fmt.Sprintf("merchant %d not found", input.ID))
+	}
+
+	if merchant.Status == entity.MerchantClosed {
+		return nil, appErr.NewConflict(fmt.Sprintf("merchant %d is closed", input.ID))
+	}
+
+	if input.Name != nil {
+		merchant.Name = *input.Name
+	}
+	if input.Status != nil {
+		merchant.Status = entity.MerchantStatus(*input.Status)
+	}
+	if input.PaymentMethods != nil {
+		merchant.PaymentMethods = input.PaymentMethods
+	}
+	if input.Settlement != nil {
+		merchant.Settlement = settlement(*input.Settlement)
+	}
+
+	if err := uc.repository.Update(ctx, merchant); err != nil {
+		return nil, fmt.Errorf("failed to update merchant: %w", err)
+	}
+
+	output := merchantOutput(merchant)
+	return &output, nil
+}
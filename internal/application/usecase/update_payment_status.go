@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type UpdatePaymentStatus = base.UseCase[dto.UpdatePaymentStatusInput, *dto.UpdatePaymentStatusOutput]
+
+// manualStatuses are the statuses a payment can be moved to by hand. The
+// others follow a capture, a refund or an expiry, which this use case
+// doesn't perform.
+var manualStatuses = map[entity.PaymentStatus]bool{
+	entity.StatusProcessing: true,
+	entity.StatusFailed:     true,
+	entity.StatusCanceled:   true,
+}
+
+type UpdatePaymentStatusImplementation struct {
+	repository repository.PaymentRepository
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+}
+
+func NewUpdatePaymentStatusUseCase(
+	repository repository.PaymentRepository,
+	outbox repository.OutboxRepository,
+	transactor repository.Transactor,
+) *UpdatePaymentStatusImplementation {
+	return &UpdatePaymentStatusImplementation{
+		repository: repository,
+		outbox:     outbox,
+		transactor: transactor,
+	}
+}
+
+func (uc *UpdatePaymentStatusImplementation) Execute(ctx context.Context, input dto.UpdatePaymentStatusInput) (*dto.UpdatePaymentStatusOutput, error) {
+	ctx, span := metrics.StartSpan(ctx, "UpdatePaymentStatusUseCase.Execute")
+	defer span.End()
+
+	next := entity.PaymentStatus(input.Status)
+	if !next.IsValid() {
+		return nil, appErr.NewBadFormat(fmt.Sprintf("invalid payment status: %s", input.Status))
+	}
+	if !manualStatuses[next] {
+		return nil, appErr.NewBadFormat(fmt.Sprintf("payments can't be moved to %s by hand", input.Status))
+	}
+
+	metrics.AddSpanAttributes(ctx,
+		attribute.Int64("payment.id", input.ID),
+		attribute.String("payment.status.next", input.Status),
+	)
+
+	payment, err := uc.repository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	if payment == nil || !auth.CanAccess(ctx, payment.MerchantID) {
+		return nil, appErr.NewNotFound(fmt.Sprintf("payment %d not found", input.ID))
+	}
+
+	previous := payment.Status
+	if err := payment.TransitionTo(next); err != nil {
+		metrics.AddSpanEvent(ctx, "payment.transition.rejected", attribute.String("error", err.Error()))
+		return nil, appErr.NewConflict(err.Error())
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.UpdateStatus(ctx, payment, previous); err != nil {
+			return err
+		}
+
+		return enqueuePaymentEvent(ctx, uc.outbox, payment)
+	})
+	if errors.Is(err, repository.ErrStaleStatus) {
+		return nil, appErr.NewConflict(err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	return &dto.UpdatePaymentStatusOutput{
+		ID:             payment.ID,
+		PreviousStatus: string(previous),
+		Status:         string(payment.Status),
+		UpdatedAt:      payment.UpdatedAt,
+	}, nil
+}
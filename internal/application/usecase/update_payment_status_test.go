@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdatePaymentStatusExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	outbox := repository.NewMockOutboxRepository(ctrl)
+
+	repo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10050, Currency: "BRL"},
+		Method: entity.MethodPix,
+		Status: entity.StatusCreated,
+	}, nil)
+	repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusCreated).
+		DoAndReturn(func(_ context.Context, payment *entity.Payment, _ entity.PaymentStatus) error {
+			assert.Equal(t, entity.StatusProcessing, payment.Status)
+			return nil
+		})
+	expectPaymentEvent(t, outbox, "1", "payment.processing")
+
+	output, err := NewUpdatePaymentStatusUseCase(repo, outbox, mockTransactor(ctrl)).Execute(context.Background(), dto.UpdatePaymentStatusInput{
+		ID:     1,
+		Status: string(entity.StatusProcessing),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.StatusCreated), output.PreviousStatus)
+	assert.Equal(t, string(entity.StatusProcessing), output.Status)
+}
+
+func TestUpdatePaymentStatusExecuteInvalidTransition(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Payment{
+		ID:     1,
+		Status: entity.StatusCompleted,
+	}, nil)
+
+	output, err := NewUpdatePaymentStatusUseCase(repo, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).Execute(context.Background(), dto.UpdatePaymentStatusInput{
+		ID:     1,
+		Status: string(entity.StatusCanceled),
+	})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.Conflict{}, err)
+}
+
+func TestUpdatePaymentStatusExecuteMonetaryStatus(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	// Completing or refunding by hand would skip the provider
+	for _, status := range []entity.PaymentStatus{entity.StatusCompleted, entity.StatusRefunded, entity.StatusPartiallyRefunded, entity.StatusExpired} {
+		output, err := NewUpdatePaymentStatusUseCase(repository.NewMockPaymentRepository(ctrl), repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).Execute(context.Background(), dto.UpdatePaymentStatusInput{
+			ID:     1,
+			Status: string(status),
+		})
+
+		assert.Nil(t, output)
+		assert.IsType(t, appErr.BadFormat{}, err, status)
+	}
+}
+
+func TestUpdatePaymentStatusExecuteStaleStatus(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&entity.Payment{
+		ID:     1,
+		Status: entity.StatusProcessing,
+	}, nil)
+	repo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), entity.StatusProcessing).Return(repository.ErrStaleStatus)
+
+	output, err := NewUpdatePaymentStatusUseCase(repo, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).Execute(context.Background(), dto.UpdatePaymentStatusInput{
+		ID:     1,
+		Status: string(entity.StatusCanceled),
+	})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.Conflict{}, err)
+}
+
+func TestUpdatePaymentStatusExecuteNotFound(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	repo := repository.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), int64(9)).Return(nil, nil)
+
+	output, err := NewUpdatePaymentStatusUseCase(repo, repository.NewMockOutboxRepository(ctrl), mockTransactor(ctrl)).Execute(context.Background(), dto.UpdatePaymentStatusInput{
+		ID:     9,
+		Status: string(entity.StatusCanceled),
+	})
+
+	assert.Nil(t, output)
+	assert.IsType(t, appErr.NotFound{}, err)
+}
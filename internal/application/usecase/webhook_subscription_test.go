@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookSubscriptionExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	subscriptions := repository.NewMockWebhookSubscriptionRepository(ctrl)
+	subscriptions.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, subscription *entity.WebhookSubscription) error {
+			assert.True(t, subscription.Active)
+			assert.Equal(t, int64(3), subscription.MerchantID)
+			assert.Equal(t, []string{"payment.completed"}, subscription.EventTypes)
+			subscription.ID = 1
+			return nil
+		})
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+	output, err := newCreateWebhookSubscription(subscriptions, WebhookSubscriptionConfig{RequireHTTPS: true}).Execute(ctx, dto.CreateWebhookSubscriptionInput{
+		URL:        "https://merchant.example.com/webhooks",
+		EventTypes: []string{"payment.completed"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), output.ID)
+	assert.True(t, strings.HasPrefix(output.Secret, "whsec_"))
+	assert.Len(t, output.Secret, len("whsec_")+64)
+}
+
+func TestCreateWebhookSubscriptionExecuteInvalidURL(t *testing.T) {
+	cases := map[string]string{
+		"ftp":                 "ftp://merchant.example.com/webhooks",
+		"http":                "http://merchant.example.com/webhooks",
+		"loopback":            "https://127.0.0.1:9464/metrics",
+		"loopback ipv6":       "https://[::1]/webhooks",
+		"metadata":            "https://169.254.169.254/latest/meta-data",
+		"private":             "https://10.0.0.5/webhooks",
+		"resolves to private": "https://internal.example.com/webhooks",
+		"unresolved":          "https://unknown.example.com/webhooks",
+	}
+
+	for name, url := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			output, err := newCreateWebhookSubscription(repository.NewMockWebhookSubscriptionRepository(ctrl), WebhookSubscriptionConfig{RequireHTTPS: true}).Execute(context.Background(), dto.CreateWebhookSubscriptionInput{
+				URL: url,
+			})
+
+			assert.Nil(t, output)
+			assert.IsType(t, appErr.BadFormat{}, err)
+		})
+	}
+}
+
+func TestCreateWebhookSubscriptionExecuteAllowPrivateNetworks(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	subscriptions := repository.NewMockWebhookSubscriptionRepository(ctrl)
+	subscriptions.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	output, err := newCreateWebhookSubscription(subscriptions, WebhookSubscriptionConfig{AllowPrivateNetworks: true}).Execute(context.Background(), dto.CreateWebhookSubscriptionInput{
+		URL: "http://localhost:9000/webhooks",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/webhooks", output.URL)
+}
+
+func newCreateWebhookSubscription(subscriptions repository.WebhookSubscriptionRepository, config WebhookSubscriptionConfig) *CreateWebhookSubscriptionImplementation {
+	useCase := NewCreateWebhookSubscriptionUseCase(subscriptions, config)
+	useCase.lookup = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "merchant.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}}, nil
+		case "internal.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("10.0.0.5")}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	return useCase
+}
+
+func TestListWebhookSubscriptionsExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	subscriptions := repository.NewMockWebhookSubscriptionRepository(ctrl)
+	subscriptions.EXPECT().List(gomock.Any(), int64(3)).Return([]*entity.WebhookSubscription{
+		{ID: 1, MerchantID: 3, URL: "https://merchant.example.com/webhooks", Active: true},
+	}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+	output, err := NewListWebhookSubscriptionsUseCase(subscriptions).Execute(ctx, dto.ListWebhookSubscriptionsInput{})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Data, 1)
+	assert.Equal(t, []string{}, output.Data[0].EventTypes)
+}
+
+func TestListWebhookDeliveriesExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	deliveries := repository.NewMockWebhookDeliveryRepository(ctrl)
+	deliveries.EXPECT().List(gomock.Any(), repository.WebhookDeliveryFilter{
+		MerchantID: 3,
+		Status:     entity.WebhookDeliveryDead,
+		BeforeID:   30,
+		Limit:      11,
+	}).DoAndReturn(func(_ context.Context, _ repository.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+		var page []*entity.WebhookDelivery
+		for id := int64(29); id >= 19; id-- {
+			page = append(page, &entity.WebhookDelivery{ID: id, Status: entity.WebhookDeliveryDead})
+		}
+		return page, nil
+	})
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+	output, err := NewListWebhookDeliveriesUseCase(deliveries).Execute(ctx, dto.ListWebhookDeliveriesInput{
+		Status: "DEAD",
+		Cursor: encodeDeliveryCursor(30),
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Data, 10)
+	assert.True(t, output.HasMore)
+	assert.Equal(t, encodeDeliveryCursor(20), output.NextCursor)
+}
+
+func TestRedeliverWebhookExecute(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	deliveries := repository.NewMockWebhookDeliveryRepository(ctrl)
+	deliveries.EXPECT().Find(gomock.Any(), int64(7)).Return(&entity.WebhookDelivery{
+		ID:        7,
+		Status:    entity.WebhookDeliveryDead,
+		Attempts:  8,
+		LastError: "endpoint responded with status 503",
+	}, nil)
+	deliveries.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery) error {
+			assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+			assert.Zero(t, delivery.Attempts)
+			assert.Empty(t, delivery.LastError)
+			return nil
+		})
+
+	output, err := NewRedeliverWebhookUseCase(deliveries).Execute(context.Background(), dto.RedeliverWebhookInput{ID: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.WebhookDeliveryPending), output.Status)
+}
+
+func TestRedeliverWebhookExecuteInvalid(t *testing.T) {
+	cases := map[string]struct {
+		delivery *entity.WebhookDelivery
+		want     error
+	}{
+		"not found":      {delivery: nil, want: appErr.NotFound{}},
+		"other merchant": {delivery: &entity.WebhookDelivery{ID: 7, MerchantID: 4, Status: entity.WebhookDeliveryDead}, want: appErr.NotFound{}},
+		"pending":        {delivery: &entity.WebhookDelivery{ID: 7, MerchantID: 3, Status: entity.WebhookDeliveryPending}, want: appErr.Conflict{}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			deliveries := repository.NewMockWebhookDeliveryRepository(ctrl)
+			deliveries.EXPECT().Find(gomock.Any(), int64(7)).Return(tc.delivery, nil)
+
+			ctx := auth.NewContext(context.Background(), auth.Principal{MerchantID: 3})
+			output, err := NewRedeliverWebhookUseCase(deliveries).Execute(ctx, dto.RedeliverWebhookInput{ID: 7})
+
+			assert.Nil(t, output)
+			assert.IsType(t, tc.want, err)
+		})
+	}
+}
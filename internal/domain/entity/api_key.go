@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// ApiKey authenticates requests of a merchant sent with the X-API-KEY
+// header. Only the SHA-256 of the key is stored; Prefix keeps its first
+// characters so it can be told apart from the merchant's other keys.
+type ApiKey struct {
+	ID         int64      `json:"id" db:"id"`
+	MerchantID int64      `json:"merchant_id" db:"merchant_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	Hash       string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsUsable reports whether the key can still authenticate requests at now.
+func (k *ApiKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// IdempotencyKey stores the outcome of a request made with an
+// Idempotency-Key header so retries can be answered without redoing it. The
+// key is saved with the payment it created before the payment is processed,
+// and completed with the response once it is.
+type IdempotencyKey struct {
+	MerchantID  int64     `json:"merchant_id" db:"merchant_id"`
+	Key         string    `json:"key" db:"key"`
+	Fingerprint string    `json:"fingerprint" db:"fingerprint"`
+	PaymentID   int64     `json:"payment_id" db:"payment_id"`
+	StatusCode  int       `json:"status_code" db:"status_code"`
+	Response    []byte    `json:"response" db:"response"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// InProgress tells the request of the key is still processing its payment,
+// or stopped before storing the response.
+func (k *IdempotencyKey) InProgress() bool {
+	return k.Response == nil
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+type MerchantStatus string
+
+const (
+	MerchantActive    MerchantStatus = "ACTIVE"
+	MerchantSuspended MerchantStatus = "SUSPENDED"
+	MerchantClosed    MerchantStatus = "CLOSED"
+)
+
+type SettlementSchedule string
+
+const (
+	SettlementDaily   SettlementSchedule = "DAILY"
+	SettlementWeekly  SettlementSchedule = "WEEKLY"
+	SettlementMonthly SettlementSchedule = "MONTHLY"
+)
+
+// Settlement tells how often the merchant is paid out and how many days
+// after capture a payment becomes available, D+DelayDays.
+type Settlement struct {
+	Schedule  SettlementSchedule `json:"schedule" db:"settlement_schedule"`
+	DelayDays int                `json:"delay_days" db:"settlement_delay_days"`
+}
+
+// DefaultSettlement pays merchants out every day, one day after capture.
+var DefaultSettlement = Settlement{Schedule: SettlementDaily, DelayDays: 1}
+
+// Merchant owns payments and API keys. Only active merchants can take new
+// payments, and only with the PaymentMethods they enabled.
+type Merchant struct {
+	ID             int64          `json:"id" db:"id"`
+	Name           string         `json:"name" db:"name"`
+	Status         MerchantStatus `json:"status" db:"status"`
+	PaymentMethods []string       `json:"payment_methods" db:"payment_methods"`
+	Settlement     Settlement     `json:"settlement"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+func (m *Merchant) IsActive() bool {
+	return m.Status == MerchantActive
+}
+
+// AcceptsMethod reports whether the merchant enabled method.
+func (m *Merchant) AcceptsMethod(method string) bool {
+	for _, enabled := range m.PaymentMethods {
+		if enabled == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchantAcceptsMethod(t *testing.T) {
+	merchant := &Merchant{Status: MerchantActive, PaymentMethods: []string{MethodPix}}
+
+	assert.True(t, merchant.IsActive())
+	assert.True(t, merchant.AcceptsMethod(MethodPix))
+	assert.False(t, merchant.AcceptsMethod(MethodCard))
+
+	merchant.Status = MerchantSuspended
+	assert.False(t, merchant.IsActive())
+}
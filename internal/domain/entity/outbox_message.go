@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OutboxMessage is an event stored in the same transaction as the change that
+// produced it, waiting to be relayed to the message broker.
+type OutboxMessage struct {
+	ID            int64             `json:"id" db:"id"`
+	Topic         string            `json:"topic" db:"topic"`
+	Key           string            `json:"key" db:"message_key"`
+	MerchantID    int64             `json:"merchant_id" db:"merchant_id"`
+	Payload       []byte            `json:"payload" db:"payload"`
+	Headers       map[string]string `json:"headers" db:"headers"`
+	Attempts      int               `json:"attempts" db:"attempts"`
+	LastError     string            `json:"last_error" db:"last_error"`
+	NextAttemptAt time.Time         `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time        `json:"published_at" db:"published_at"`
+}
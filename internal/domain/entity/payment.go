@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"go-payments-api/internal/domain/money"
+	"time"
+)
 
 type PaymentStatus string
 
@@ -8,6 +12,12 @@ const (
 	StatusCreated    PaymentStatus = "CREATED"
 	StatusProcessing PaymentStatus = "PROCESSING"
 	StatusCompleted  PaymentStatus = "COMPLETED"
+	StatusFailed     PaymentStatus = "FAILED"
+	StatusCanceled   PaymentStatus = "CANCELED"
+	StatusRefunded   PaymentStatus = "REFUNDED"
+	StatusExpired    PaymentStatus = "EXPIRED"
+
+	StatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
 )
 
 const (
@@ -15,10 +25,80 @@ const (
 	MethodCard string = "CARD"
 )
 
+// paymentTransitions maps every status to the statuses it can move to.
+// Statuses without entries are terminal.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	StatusCreated:    {StatusProcessing, StatusFailed, StatusCanceled, StatusExpired},
+	StatusProcessing: {StatusCompleted, StatusFailed, StatusCanceled, StatusExpired},
+	StatusCompleted:  {StatusRefunded, StatusPartiallyRefunded},
+
+	StatusPartiallyRefunded: {StatusRefunded},
+}
+
 type Payment struct {
 	ID        int64         `json:"id" db:"id"`
-	Amount    float64       `json:"amount" db:"amount"`
+	Amount    money.Money   `json:"amount" db:"amount"`
 	Method    string        `json:"method" db:"method"`
 	Status    PaymentStatus `json:"status" db:"status"`
 	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" db:"updated_at"`
+
+	// ProviderReference identifies the payment on the provider processing it
+	ProviderReference string `json:"provider_reference" db:"provider_reference"`
+
+	// MerchantID owns the payment, 0 for payments created before merchants
+	MerchantID int64 `json:"merchant_id" db:"merchant_id"`
+}
+
+// InvalidTransitionError is returned when a payment is asked to move to a
+// status that is not reachable from its current one.
+type InvalidTransitionError struct {
+	From PaymentStatus
+	To   PaymentStatus
+}
+
+func (e InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid payment status transition from %s to %s", e.From, e.To)
+}
+
+// IsValid reports whether s is one of the known payment statuses.
+func (s PaymentStatus) IsValid() bool {
+	switch s {
+	case StatusCreated, StatusProcessing, StatusCompleted, StatusFailed,
+		StatusCanceled, StatusRefunded, StatusExpired, StatusPartiallyRefunded:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether no other status can be reached from s.
+func (s PaymentStatus) IsTerminal() bool {
+	return len(paymentTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether a payment in status s can move to next.
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, allowed := range paymentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRefundable reports whether refunds can still be issued for payments in
+// status s.
+func (s PaymentStatus) IsRefundable() bool {
+	return s == StatusCompleted || s == StatusPartiallyRefunded
+}
+
+// TransitionTo moves the payment to the next status, returning an
+// InvalidTransitionError when the state machine doesn't allow it.
+func (p *Payment) TransitionTo(next PaymentStatus) error {
+	if !p.Status.CanTransitionTo(next) {
+		return InvalidTransitionError{From: p.Status, To: next}
+	}
+
+	p.Status = next
+	return nil
 }
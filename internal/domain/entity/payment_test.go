@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"errors"
+	"go-payments-api/internal/domain/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentTransitionTo(t *testing.T) {
+	cases := []struct {
+		from PaymentStatus
+		to   PaymentStatus
+		ok   bool
+	}{
+		{from: StatusCreated, to: StatusProcessing, ok: true},
+		{from: StatusCreated, to: StatusFailed, ok: true},
+		{from: StatusCreated, to: StatusCanceled, ok: true},
+		{from: StatusCreated, to: StatusExpired, ok: true},
+		{from: StatusCreated, to: StatusCompleted, ok: false},
+		{from: StatusProcessing, to: StatusCompleted, ok: true},
+		{from: StatusProcessing, to: StatusFailed, ok: true},
+		{from: StatusProcessing, to: StatusExpired, ok: true},
+		{from: StatusProcessing, to: StatusCreated, ok: false},
+		{from: StatusCompleted, to: StatusRefunded, ok: true},
+		{from: StatusCompleted, to: StatusCanceled, ok: false},
+		{from: StatusCompleted, to: StatusPartiallyRefunded, ok: true},
+		{from: StatusPartiallyRefunded, to: StatusRefunded, ok: true},
+		{from: StatusPartiallyRefunded, to: StatusCompleted, ok: false},
+		{from: StatusRefunded, to: StatusCompleted, ok: false},
+		{from: StatusExpired, to: StatusProcessing, ok: false},
+		{from: StatusCreated, to: StatusCreated, ok: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			payment := &Payment{Status: tc.from}
+
+			err := payment.TransitionTo(tc.to)
+
+			if tc.ok {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.to, payment.Status)
+				return
+			}
+
+			assert.Equal(t, InvalidTransitionError{From: tc.from, To: tc.to}, err)
+			assert.Equal(t, tc.from, payment.Status)
+		})
+	}
+}
+
+func TestPaymentStatusIsTerminal(t *testing.T) {
+	for _, status := range []PaymentStatus{StatusFailed, StatusCanceled, StatusRefunded, StatusExpired} {
+		assert.True(t, status.IsTerminal(), status)
+	}
+
+	for _, status := range []PaymentStatus{StatusCreated, StatusProcessing, StatusCompleted, StatusPartiallyRefunded} {
+		assert.False(t, status.IsTerminal(), status)
+	}
+}
+
+func TestPaymentStatusIsValid(t *testing.T) {
+	assert.True(t, StatusCompleted.IsValid())
+	assert.False(t, PaymentStatus("UNKNOWN").IsValid())
+}
+
+func TestPaymentRefundedStatus(t *testing.T) {
+	payment := &Payment{Amount: money.Money{Value: 1000, Currency: "BRL"}}
+
+	status, err := payment.RefundedStatus(money.Money{Value: 400, Currency: "BRL"})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPartiallyRefunded, status)
+
+	status, err = payment.RefundedStatus(money.Money{Value: 1000, Currency: "BRL"})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRefunded, status)
+
+	_, err = payment.RefundedStatus(money.Money{Value: 1000, Currency: "USD"})
+	assert.Error(t, err)
+}
+
+func TestRefundSettle(t *testing.T) {
+	refund := &Refund{Status: RefundStatusPending}
+
+	assert.NoError(t, refund.Complete())
+	assert.Equal(t, RefundStatusCompleted, refund.Status)
+	assert.Error(t, refund.Fail())
+	assert.Equal(t, RefundStatusCompleted, refund.Status)
+}
+
+func TestPixCharge(t *testing.T) {
+	now := time.Now()
+	charge := &PixCharge{ExpiresAt: now}
+
+	assert.False(t, charge.IsExpired(now.Add(-time.Second)))
+	assert.True(t, charge.IsExpired(now))
+	assert.Equal(t, "PAY0000000000000000000042", PixTxID(42))
+}
+
+func TestWebhookDeliveryAttempts(t *testing.T) {
+	now := time.Now()
+	delivery := &WebhookDelivery{Status: WebhookDeliveryPending}
+
+	delivery.Failed(errors.New("connection refused"), 0, now.Add(time.Minute), 2)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+
+	delivery.Failed(errors.New("internal server error"), 500, now.Add(2*time.Minute), 2)
+	assert.Equal(t, WebhookDeliveryDead, delivery.Status)
+	assert.Equal(t, 500, delivery.ResponseStatus)
+
+	delivery.Redeliver(now)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	assert.Equal(t, now, delivery.NextAttemptAt)
+
+	delivery.Delivered(204)
+	assert.Equal(t, WebhookDeliveryDelivered, delivery.Status)
+	assert.Empty(t, delivery.LastError)
+}
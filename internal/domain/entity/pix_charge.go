@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PixCharge is the PIX QR code issued for a payment. The payer has until
+// ExpiresAt to pay it.
+type PixCharge struct {
+	PaymentID int64     `json:"payment_id" db:"payment_id"`
+	TxID      string    `json:"txid" db:"txid"`
+	Payload   string    `json:"payload" db:"payload"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PixTxID returns the txid of the charge of a payment, unique per payment
+// and within the 25 alphanumeric characters BR Codes allow.
+func PixTxID(paymentID int64) string {
+	return fmt.Sprintf("PAY%022d", paymentID)
+}
+
+// IsExpired reports whether the charge can no longer be paid at now.
+func (c *PixCharge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
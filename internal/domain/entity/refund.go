@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"fmt"
+	"go-payments-api/internal/domain/money"
+	"time"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "PENDING"
+	RefundStatusCompleted RefundStatus = "COMPLETED"
+	RefundStatusFailed    RefundStatus = "FAILED"
+)
+
+type Refund struct {
+	ID        int64        `json:"id" db:"id"`
+	PaymentID int64        `json:"payment_id" db:"payment_id"`
+	Amount    money.Money  `json:"amount" db:"amount"`
+	Status    RefundStatus `json:"status" db:"status"`
+	Reason    string       `json:"reason" db:"reason"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Complete settles a pending refund.
+func (r *Refund) Complete() error {
+	return r.settle(RefundStatusCompleted)
+}
+
+// Fail marks a pending refund as failed, releasing its amount to be refunded
+// again.
+func (r *Refund) Fail() error {
+	return r.settle(RefundStatusFailed)
+}
+
+func (r *Refund) settle(status RefundStatus) error {
+	if r.Status != RefundStatusPending {
+		return fmt.Errorf("refund %d is already %s", r.ID, r.Status)
+	}
+
+	r.Status = status
+	return nil
+}
+
+// RefundedStatus returns the status a refundable payment moves to once
+// refunded out of its amount has been refunded.
+func (p *Payment) RefundedStatus(refunded money.Money) (PaymentStatus, error) {
+	cmp, err := refunded.Cmp(p.Amount)
+	if err != nil {
+		return "", err
+	}
+
+	if cmp >= 0 {
+		return StatusRefunded, nil
+	}
+	return StatusPartiallyRefunded, nil
+}
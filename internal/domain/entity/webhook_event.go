@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type WebhookEventStatus string
+
+const (
+	WebhookEventProcessed WebhookEventStatus = "PROCESSED"
+	WebhookEventRejected  WebhookEventStatus = "REJECTED"
+)
+
+// WebhookEvent is a notification received from a payment provider. Events
+// are unique per provider and event id, so redeliveries are applied once.
+// Rejected events keep the reason and can be replayed.
+type WebhookEvent struct {
+	ID          int64              `json:"id" db:"id"`
+	Provider    string             `json:"provider" db:"provider"`
+	EventID     string             `json:"event_id" db:"event_id"`
+	Type        string             `json:"type" db:"type"`
+	Payload     []byte             `json:"payload" db:"payload"`
+	Status      WebhookEventStatus `json:"status" db:"status"`
+	Error       string             `json:"error" db:"error"`
+	Attempts    int                `json:"attempts" db:"attempts"`
+	ReceivedAt  time.Time          `json:"received_at" db:"received_at"`
+	ProcessedAt time.Time          `json:"processed_at" db:"processed_at"`
+}
@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+// WebhookSubscription is a merchant endpoint that receives the events of the
+// merchant payments. An empty EventTypes subscribes to every event.
+type WebhookSubscription struct {
+	ID         int64     `json:"id" db:"id"`
+	MerchantID int64     `json:"merchant_id" db:"merchant_id"`
+	URL        string    `json:"url" db:"url"`
+	Secret     string    `json:"-" db:"secret"`
+	EventTypes []string  `json:"event_types" db:"event_types"`
+	Active     bool      `json:"active" db:"active"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery is one event to be sent to one subscription. Failed
+// attempts are retried until the delivery succeeds or runs out of attempts
+// and is dead-lettered.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id" db:"id"`
+	SubscriptionID int64                 `json:"subscription_id" db:"subscription_id"`
+	MerchantID     int64                 `json:"merchant_id" db:"merchant_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	Payload        []byte                `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError      string                `json:"last_error" db:"last_error"`
+	ResponseStatus int                   `json:"response_status" db:"response_status"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at" db:"updated_at"`
+
+	// Subscription is loaded along with deliveries due to be sent
+	Subscription *WebhookSubscription `json:"-" db:"-"`
+}
+
+// Delivered records a successful attempt answered with responseStatus.
+func (d *WebhookDelivery) Delivered(responseStatus int) {
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+}
+
+// Failed records a failed attempt. The delivery is retried at next, or
+// dead-lettered once it reaches maxAttempts.
+func (d *WebhookDelivery) Failed(err error, responseStatus int, next time.Time, maxAttempts int) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = err.Error()
+	d.NextAttemptAt = next
+
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryDead
+	}
+}
+
+// Redeliver schedules the delivery to be sent again right away with a fresh
+// set of attempts, whatever happened to it before.
+func (d *WebhookDelivery) Redeliver(now time.Time) {
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.LastError = ""
+}
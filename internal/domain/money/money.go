@@ -0,0 +1,211 @@
+// Package money provides an exact representation of monetary amounts as an
+// integer number of minor units (cents, for instance) of an ISO-4217
+// currency.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrOverflow         = errors.New("amount overflow")
+	ErrInvalidAmount    = errors.New("invalid amount")
+)
+
+type Currency string
+
+// exponents holds how many decimal places each supported currency has.
+var exponents = map[Currency]int{
+	"BRL": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"ARS": 2,
+	"MXN": 2,
+	"CLP": 0,
+	"JPY": 0,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+// Exponent returns the number of decimal places of the currency.
+func (c Currency) Exponent() (int, error) {
+	exp, ok := exponents[c]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(c))
+	}
+	return exp, nil
+}
+
+func (c Currency) IsValid() bool {
+	_, ok := exponents[c]
+	return ok
+}
+
+// Money is an amount in minor units of a currency, e.g. {10050, "BRL"} is
+// R$ 100,50. Its JSON form is {"value": 10050, "currency": "BRL"}.
+type Money struct {
+	Value    int64    `json:"value" example:"10050"`
+	Currency Currency `json:"currency" example:"BRL" swaggertype:"string"`
+}
+
+// New returns value minor units of currency.
+func New(value int64, currency string) (Money, error) {
+	m := Money{Value: value, Currency: Currency(strings.ToUpper(currency))}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// Parse reads a decimal string like "100.50" as an amount of currency. It
+// fails instead of rounding when the string has more significant decimal
+// places than the currency allows.
+func Parse(amount string, currency string) (Money, error) {
+	cur := Currency(strings.ToUpper(currency))
+	exp, err := cur.Exponent()
+	if err != nil {
+		return Money{}, err
+	}
+
+	negative := strings.HasPrefix(amount, "-")
+	digits := strings.TrimPrefix(amount, "-")
+
+	integer, fraction, _ := strings.Cut(digits, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if integer == "" || len(fraction) > exp || !isDigits(integer) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q for %s", ErrInvalidAmount, amount, cur)
+	}
+
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+
+	value, err := strconv.ParseInt(sign+integer+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, amount)
+	}
+
+	return Money{Value: value, Currency: cur}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) Validate() error {
+	if !m.Currency.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, string(m.Currency))
+	}
+	return nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Value == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Value > 0
+}
+
+// Decimal formats the amount with the currency's decimal places, e.g.
+// "100.50". The result is accepted by Parse.
+func (m Money) Decimal() string {
+	exp, err := m.Currency.Exponent()
+	if err != nil || exp == 0 {
+		return strconv.FormatInt(m.Value, 10)
+	}
+
+	sign := ""
+	value := m.Value
+	if value < 0 {
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(absUint(value), 10)
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func absUint(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// Add returns m + o, failing when currencies differ or the sum overflows.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+
+	if (o.Value > 0 && m.Value > math.MaxInt64-o.Value) || (o.Value < 0 && m.Value < math.MinInt64-o.Value) {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{Value: m.Value + o.Value, Currency: m.Currency}, nil
+}
+
+// Sub returns m - o, failing when currencies differ or the result overflows.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Value == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Value: -o.Value, Currency: o.Currency})
+}
+
+// Cmp returns -1, 0 or +1 when m is lower, equal or greater than o. Both
+// must have the same currency.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+
+	switch {
+	case m.Value < o.Value:
+		return -1, nil
+	case m.Value > o.Value:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	type plain Money
+
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	parsed, err := New(p.Value, string(p.Currency))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,142 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+		want     Money
+	}{
+		{amount: "100.50", currency: "BRL", want: Money{Value: 10050, Currency: "BRL"}},
+		{amount: "100.5", currency: "brl", want: Money{Value: 10050, Currency: "BRL"}},
+		{amount: "100", currency: "USD", want: Money{Value: 10000, Currency: "USD"}},
+		{amount: "0.01", currency: "EUR", want: Money{Value: 1, Currency: "EUR"}},
+		{amount: "100.5000", currency: "BRL", want: Money{Value: 10050, Currency: "BRL"}},
+		{amount: "-3.25", currency: "BRL", want: Money{Value: -325, Currency: "BRL"}},
+		{amount: "1500", currency: "JPY", want: Money{Value: 1500, Currency: "JPY"}},
+		{amount: "1.234", currency: "KWD", want: Money{Value: 1234, Currency: "KWD"}},
+		{amount: "999999999999.99", currency: "BRL", want: Money{Value: 99999999999999, Currency: "BRL"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.amount+" "+tc.currency, func(t *testing.T) {
+			got, err := Parse(tc.amount, tc.currency)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+		want     error
+	}{
+		{amount: "1.001", currency: "BRL", want: ErrInvalidAmount},
+		{amount: "1.5", currency: "JPY", want: ErrInvalidAmount},
+		{amount: "abc", currency: "BRL", want: ErrInvalidAmount},
+		{amount: ".50", currency: "BRL", want: ErrInvalidAmount},
+		{amount: "1e3", currency: "BRL", want: ErrInvalidAmount},
+		{amount: "10", currency: "XXX", want: ErrUnknownCurrency},
+		{amount: "99999999999999999999", currency: "BRL", want: ErrOverflow},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.amount+" "+tc.currency, func(t *testing.T) {
+			_, err := Parse(tc.amount, tc.currency)
+
+			assert.True(t, errors.Is(err, tc.want), "got %v, want %v", err, tc.want)
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	cases := []struct {
+		money Money
+		want  string
+	}{
+		{money: Money{Value: 10050, Currency: "BRL"}, want: "100.50"},
+		{money: Money{Value: 5, Currency: "BRL"}, want: "0.05"},
+		{money: Money{Value: 0, Currency: "USD"}, want: "0.00"},
+		{money: Money{Value: -325, Currency: "BRL"}, want: "-3.25"},
+		{money: Money{Value: 1500, Currency: "JPY"}, want: "1500"},
+		{money: Money{Value: 1, Currency: "KWD"}, want: "0.001"},
+		{money: Money{Value: math.MinInt64, Currency: "BRL"}, want: "-92233720368547758.08"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.money.Decimal())
+
+			parsed, err := Parse(tc.money.Decimal(), string(tc.money.Currency))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.money, parsed)
+		})
+	}
+}
+
+func TestAddIsExact(t *testing.T) {
+	a, _ := Parse("0.1", "BRL")
+	b, _ := Parse("0.2", "BRL")
+
+	sum, err := a.Add(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.30", sum.Decimal())
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	brl := Money{Value: 100, Currency: "BRL"}
+	usd := Money{Value: 100, Currency: "USD"}
+	max := Money{Value: math.MaxInt64, Currency: "BRL"}
+
+	_, err := brl.Add(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = max.Add(brl)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = brl.Sub(Money{Value: math.MinInt64, Currency: "BRL"})
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = brl.Cmp(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	diff, err := brl.Sub(Money{Value: 150, Currency: "BRL"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-50), diff.Value)
+
+	cmp, err := brl.Cmp(max)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := Money{Value: 9007199254740993, Currency: "BRL"}
+
+	data, err := json.Marshal(original)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value":9007199254740993,"currency":"BRL"}`, string(data))
+
+	var decoded Money
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded)
+}
+
+func TestJSONValidatesCurrency(t *testing.T) {
+	var m Money
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"value":100,"currency":"ZZZ"}`), &m), ErrUnknownCurrency)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"value":100}`), &m), ErrUnknownCurrency)
+	assert.Error(t, json.Unmarshal([]byte(`{"value":1.5,"currency":"BRL"}`), &m))
+}
@@ -4,25 +4,80 @@ import (
 	"context"
 	"go-payments-api/internal/application"
 	"go-payments-api/internal/infrastructure/api/handler"
+	"go-payments-api/internal/infrastructure/api/middleware"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/internal/infrastructure/messaging/outbox"
+	"go-payments-api/internal/infrastructure/rpc"
+	"go-payments-api/internal/infrastructure/sweeper"
+	"go-payments-api/internal/infrastructure/webhook"
 	"go-payments-api/internal/settings"
 	"go-payments-api/pkg/api"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 )
 
 type Application struct {
 	BaseApp *application.App
 	Server  api.Server[*gin.Engine]
 
+	// GrpcServer serves the services of api/proto next to Server
+	GrpcServer     api.Server[*grpc.Server]
+	PaymentService *rpc.PaymentService
+
+	// Workers
+	OutboxRelay           *outbox.Relay
+	PixExpirySweeper      *sweeper.PixExpiry
+	WebhookDispatcher     *webhook.Dispatcher
+	StatusUpdatesConsumer *kafka.Consumer
+
+	// Middlewares
+	CorsMiddleware      *middleware.Cors
+	AuthMiddleware      *middleware.Auth
+	RateLimitMiddleware *middleware.RateLimit
+	MetricsMiddleware   *middleware.Metrics
+
 	// Health
 	HealthHandler *handler.Health
 
 	// Payments
 	CreatePaymentHandler *handler.CreatePayment
+	GetPaymentHandler    *handler.GetPayment
+	ListPaymentsHandler  *handler.ListPayments
+
+	UpdatePaymentStatusHandler *handler.UpdatePaymentStatus
+
+	// Refunds
+	CreateRefundHandler *handler.CreateRefund
+	ListRefundsHandler  *handler.ListRefunds
+
+	// Webhooks
+	ReceiveWebhookHandler *handler.ReceiveWebhook
+	ReplayWebhookHandler  *handler.ReplayWebhook
+
+	// Merchant Webhooks
+	CreateWebhookSubscriptionHandler *handler.CreateWebhookSubscription
+	ListWebhookSubscriptionsHandler  *handler.ListWebhookSubscriptions
+	ListWebhookDeliveriesHandler     *handler.ListWebhookDeliveries
+	RedeliverWebhookHandler          *handler.RedeliverWebhook
+
+	// Merchants
+	CreateMerchantHandler *handler.CreateMerchant
+	GetMerchantHandler    *handler.GetMerchant
+	ListMerchantsHandler  *handler.ListMerchants
+	UpdateMerchantHandler *handler.UpdateMerchant
+	CloseMerchantHandler  *handler.CloseMerchant
+
+	// Logging
+	GetLogLevelHandler *handler.GetLogLevel
+	SetLogLevelHandler *handler.SetLogLevel
 }
 
 func init() {
@@ -39,30 +94,116 @@ func (a *Application) Start() {
 	a.BaseApp.Start(settings.Settings.Metrics.Name)
 
 	a.SetupRoutes()
+	a.SetupServices()
+
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	workers.Add(4)
+	go func() {
+		defer workers.Done()
+		a.OutboxRelay.Run(ctx)
+	}()
+	go func() {
+		defer workers.Done()
+		a.PixExpirySweeper.Run(ctx)
+	}()
+	go func() {
+		defer workers.Done()
+		a.WebhookDispatcher.Run(ctx)
+	}()
+	go func() {
+		defer workers.Done()
+		a.StatusUpdatesConsumer.Run(ctx)
+	}()
 
-	ctx := context.Background()
 	quitSig := make(chan os.Signal, 1)
-	signal.Notify(quitSig, os.Interrupt)
+	signal.Notify(quitSig, os.Interrupt, syscall.SIGTERM)
+
+	metricsServer := a.startMetricsServer()
+
+	grpcFailed := make(chan struct{})
+	go func() {
+		if err := a.GrpcServer.Start(); err != nil {
+			a.BaseApp.Logger.Errorf("Failed to start gRPC server: %v", err)
+			close(grpcFailed)
+		}
+	}()
 
 	go func() {
 		select {
 		case <-quitSig:
-			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
+		case <-grpcFailed:
+		case <-ctx.Done():
+			return
+		}
+		stopWorkers()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
+		// The servers drain within the same timeout
+		var servers sync.WaitGroup
+		servers.Add(3)
+		go func() {
+			defer servers.Done()
+			if err := a.GrpcServer.Shutdown(ctx); err != nil {
+				a.BaseApp.Logger.Errorf("gRPC server forced to shutdown: %v", err)
+			}
+		}()
+		go func() {
+			defer servers.Done()
 			if err := a.Server.Shutdown(ctx); err != nil {
 				a.BaseApp.Logger.Errorf("Server forced to shutdown: %v", err)
 			}
-			return
-		case <-ctx.Done():
-			return
-		}
+		}()
+		go func() {
+			defer servers.Done()
+			if metricsServer == nil {
+				return
+			}
+			if err := metricsServer.Shutdown(ctx); err != nil {
+				a.BaseApp.Logger.Errorf("Metrics server forced to shutdown: %v", err)
+			}
+		}()
+		servers.Wait()
 	}()
 
 	if err := a.Server.Start(); err != nil {
 		a.BaseApp.Logger.Errorf("Failed to start server: %v", err)
 	}
 
+	stopWorkers()
+	workers.Wait()
+
 	a.BaseApp.Logger.Infof("Server exited properly")
 	a.BaseApp.Stop()
 }
+
+// startMetricsServer serves the Prometheus scrapes on the metrics port, apart
+// from the API so /metrics isn't reachable through the public listener. It
+// returns nil when the prometheus exporter is off.
+func (a *Application) startMetricsServer() *http.Server {
+	handler := a.BaseApp.MeterProvider.Handler
+	if handler == nil {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", handler)
+	server := &http.Server{
+		Addr:              settings.Settings.Metrics.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		a.BaseApp.Logger.Infof("starting metrics server on port %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			a.BaseApp.Logger.Errorf("Failed to start metrics server: %v", err)
+		}
+	}()
+
+	return server
+}
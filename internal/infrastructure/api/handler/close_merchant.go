@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CloseMerchant closes a merchant through the UpdateMerchant use case. The
+// merchant is kept, with its payments, but takes no new payments.
+type CloseMerchant struct {
+	UseCase   usecase.UpdateMerchant
+	Presenter api.Presenter
+}
+
+// CloseMerchant godoc
+// @Summary      Close a merchant
+// @Description  Move a merchant to CLOSED for good. Its payments and API keys are kept but it can't take new payments. Requires the admin scope
+// @Tags         Merchants
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Merchant ID"
+// @Success      200  {object}  dto.MerchantOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      403  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/merchants/{id} [delete]
+func (h *CloseMerchant) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "CloseMerchantHandler.Handle")
+		defer span.End()
+
+		var uri dto.GetMerchantInput
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid merchant id"))
+			return
+		}
+
+		status := string(entity.MerchantClosed)
+		output, err := h.UseCase.Execute(reqCtx, dto.UpdateMerchantInput{ID: uri.ID, Status: &status})
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateMerchant struct {
+	UseCase   usecase.CreateMerchant
+	Presenter api.Presenter
+}
+
+// CreateMerchant godoc
+// @Summary      Create a merchant
+// @Description  Register a merchant with the payment methods it accepts and its settlement schedule. Requires the admin scope
+// @Tags         Merchants
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateMerchantInput  true  "Merchant"
+// @Success      201      {object}  dto.MerchantOutput
+// @Failure      400      {object}  api.HttpError
+// @Failure      401      {object}  api.HttpError
+// @Failure      403      {object}  api.HttpError
+// @Failure      429      {object}  api.HttpError
+// @Failure      500      {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/merchants [post]
+func (h *CreateMerchant) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "CreateMerchantHandler.Handle")
+		defer span.End()
+
+		var input dto.CreateMerchantInput
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusCreated)
+	}
+}
@@ -13,6 +13,12 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength  = 255
+)
+
 type CreatePayment struct {
 	UseCase   usecase.CreatePayment
 	Presenter api.Presenter
@@ -20,14 +26,21 @@ type CreatePayment struct {
 
 // CreatePayment godoc
 // @Summary      Create a new payment
-// @Description  Create a new payment and publish event to Kafka
+// @Description  Create a new payment, process it with the provider of its method and publish its events to Kafka
 // @Tags         Payments
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Key that makes retries of this request return the original response"
 // @Param        payment body dto.CreatePaymentInput true "Payment data"
 // @Success      201  {object}  dto.CreatePaymentOutput
 // @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      422  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
 // @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /payments [post]
 func (h *CreatePayment) Handle() func(ctx *gin.Context) {
 	return func(ctx *gin.Context) {
@@ -48,6 +61,12 @@ func (h *CreatePayment) Handle() func(ctx *gin.Context) {
 			return
 		}
 
+		input.IdempotencyKey = ctx.GetHeader(idempotencyKeyHeader)
+		if len(input.IdempotencyKey) > idempotencyKeyMaxLength {
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Idempotency-Key header is too long"))
+			return
+		}
+
 		// Execute use case
 		output, err := h.UseCase.Execute(reqCtx, input)
 		if err != nil {
@@ -57,6 +76,13 @@ func (h *CreatePayment) Handle() func(ctx *gin.Context) {
 		}
 
 		metrics.AddSpanAttributes(reqCtx, attribute.Int64("payment.created.id", output.ID))
-		h.Presenter.Present(ctx, output, http.StatusCreated)
+		status := http.StatusCreated
+		if output.Replayed {
+			ctx.Header(idempotentReplayedHeader, "true")
+			if output.StatusCode != 0 {
+				status = output.StatusCode
+			}
+		}
+		h.Presenter.Present(ctx, output, status)
 	}
 }
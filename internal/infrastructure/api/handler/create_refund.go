@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateRefund struct {
+	UseCase   usecase.CreateRefund
+	Presenter api.Presenter
+}
+
+// CreateRefund godoc
+// @Summary      Refund a payment
+// @Description  Refund a completed payment fully or partially. Without an amount, whatever is left to refund is refunded. Refunds rejected by the provider come back with status FAILED
+// @Tags         Refunds
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                     true   "Payment ID"
+// @Param        refund  body  dto.CreateRefundInput  false  "Refund data"
+// @Success      201  {object}  dto.CreateRefundOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      422  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /payments/{id}/refunds [post]
+func (h *CreateRefund) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "CreateRefundHandler.Handle")
+		defer span.End()
+
+		var uri dto.GetPaymentInput
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid payment id"))
+			return
+		}
+
+		// An empty body asks for a full refund
+		var input dto.CreateRefundInput
+		if err := ctx.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+		input.PaymentID = uri.ID
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusCreated)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateRefundHandle(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	useCase := base.NewMockUseCase[dto.CreateRefundInput, *dto.CreateRefundOutput](ctrl)
+	useCase.EXPECT().
+		Execute(gomock.Any(), dto.CreateRefundInput{PaymentID: 1}).
+		Return(&dto.CreateRefundOutput{RefundOutput: dto.RefundOutput{ID: 1, PaymentID: 1}}, nil)
+	useCase.EXPECT().
+		Execute(gomock.Any(), dto.CreateRefundInput{PaymentID: 1, Amount: &money.Money{Value: 500, Currency: "BRL"}}).
+		Return(nil, appErr.NewUnprocessable("refund amount exceeds the refundable"))
+
+	h := &CreateRefund{UseCase: useCase, Presenter: presenter.NewJson()}
+	_, router, _ := api.MockGin()
+	router.POST("/payments/:id/refunds", h.Handle())
+
+	cases := []struct {
+		path string
+		body string
+		want int
+	}{
+		{path: "/payments/1/refunds", want: http.StatusCreated},
+		{path: "/payments/1/refunds", body: `{"amount":{"value":500,"currency":"BRL"}}`, want: http.StatusUnprocessableEntity},
+		{path: "/payments/1/refunds", body: `{"amount":{"value":500,"currency":"XXX"}}`, want: http.StatusBadRequest},
+		{path: "/payments/abc/refunds", want: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tc.want, recorder.Code, tc.path+" "+tc.body)
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CreateWebhookSubscription struct {
+	UseCase   usecase.CreateWebhookSubscription
+	Presenter api.Presenter
+}
+
+// CreateWebhookSubscription godoc
+// @Summary      Subscribe to payment events
+// @Description  Register an endpoint to receive signed payment and refund events. The signing secret is only returned here
+// @Tags         Merchant Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateWebhookSubscriptionInput  true  "Subscription"
+// @Success      201      {object}  dto.CreateWebhookSubscriptionOutput
+// @Failure      400      {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      429      {object}  api.HttpError
+// @Failure      500      {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhook-subscriptions [post]
+func (h *CreateWebhookSubscription) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "CreateWebhookSubscriptionHandler.Handle")
+		defer span.End()
+
+		var input dto.CreateWebhookSubscriptionInput
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusCreated)
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"go-payments-api/pkg/api"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevel is the level of the application logger: debug, info, warn, error
+// or fatal.
+type LogLevel struct {
+	Level string `json:"level" binding:"required" example:"debug"`
+}
+
+type GetLogLevel struct {
+	Logger    log.Logger
+	Presenter api.Presenter
+}
+
+// GetLogLevel godoc
+// @Summary      Get the log level
+// @Description  Get the level of the application logger. Requires the admin scope
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  LogLevel
+// @Failure      401  {object}  api.HttpError
+// @Failure      403  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/log-level [get]
+func (h *GetLogLevel) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		_, span := metrics.StartSpan(ctx.Request.Context(), "GetLogLevelHandler.Handle")
+		defer span.End()
+
+		h.Presenter.Present(ctx, LogLevel{Level: h.Logger.Level().String()}, http.StatusOK)
+	}
+}
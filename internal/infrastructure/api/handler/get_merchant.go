@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetMerchant struct {
+	UseCase   usecase.GetMerchant
+	Presenter api.Presenter
+}
+
+// GetMerchant godoc
+// @Summary      Get a merchant
+// @Description  Get a merchant by its ID. Requires the admin scope
+// @Tags         Merchants
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Merchant ID"
+// @Success      200  {object}  dto.MerchantOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      403  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/merchants/{id} [get]
+func (h *GetMerchant) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "GetMerchantHandler.Handle")
+		defer span.End()
+
+		var input dto.GetMerchantInput
+		if err := ctx.ShouldBindUri(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid merchant id"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
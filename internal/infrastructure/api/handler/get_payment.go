@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetPayment struct {
+	UseCase   usecase.GetPayment
+	Presenter api.Presenter
+}
+
+// GetPayment godoc
+// @Summary      Get a payment
+// @Description  Get a payment by its ID
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Payment ID"
+// @Success      200  {object}  dto.GetPaymentOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /payments/{id} [get]
+func (h *GetPayment) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "GetPaymentHandler.Handle")
+		defer span.End()
+
+		var input dto.GetPaymentInput
+		if err := ctx.ShouldBindUri(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid payment id"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
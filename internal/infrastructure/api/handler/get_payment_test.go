@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPaymentHandle(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	useCase := base.NewMockUseCase[dto.GetPaymentInput, *dto.GetPaymentOutput](ctrl)
+	useCase.EXPECT().
+		Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+		Return(&dto.GetPaymentOutput{ID: 1, Method: "PIX", Status: "CREATED"}, nil)
+	useCase.EXPECT().
+		Execute(gomock.Any(), dto.GetPaymentInput{ID: 2}).
+		Return(nil, appErr.NewNotFound("payment 2 not found"))
+
+	h := &GetPayment{UseCase: useCase, Presenter: presenter.NewJson()}
+	_, router, _ := api.MockGin()
+	router.GET("/payments/:id", h.Handle())
+
+	cases := []struct {
+		path string
+		want int
+	}{
+		{path: "/payments/1", want: http.StatusOK},
+		{path: "/payments/2", want: http.StatusNotFound},
+		{path: "/payments/abc", want: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, tc.path, nil)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tc.want, recorder.Code, tc.path)
+	}
+}
@@ -18,6 +18,7 @@ type Health struct {
 // @Accept       json
 // @Produce      json
 // @Success      200  {booblean}  true "Service is healthy"
+// @Failure      429  {object}  api.HttpError
 // @Router       /v1/payments/health [get]
 func (h *Health) Handle() func(ctx *gin.Context) {
 	return func(ctx *gin.Context) {
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListMerchants struct {
+	UseCase   usecase.ListMerchants
+	Presenter api.Presenter
+}
+
+// ListMerchants godoc
+// @Summary      List merchants
+// @Description  List merchants ordered by ID, optionally filtered by status. Requires the admin scope
+// @Tags         Merchants
+// @Accept       json
+// @Produce      json
+// @Param        status  query     string  false  "Merchant status"  Enums(ACTIVE, SUSPENDED, CLOSED)
+// @Success      200     {object}  dto.ListMerchantsOutput
+// @Failure      400     {object}  api.HttpError
+// @Failure      401     {object}  api.HttpError
+// @Failure      403     {object}  api.HttpError
+// @Failure      429     {object}  api.HttpError
+// @Failure      500     {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/merchants [get]
+func (h *ListMerchants) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ListMerchantsHandler.Handle")
+		defer span.End()
+
+		var input dto.ListMerchantsInput
+		if err := ctx.ShouldBindQuery(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid query parameters"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
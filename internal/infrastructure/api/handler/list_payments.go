@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListPayments struct {
+	UseCase   usecase.ListPayments
+	Presenter api.Presenter
+}
+
+// ListPayments godoc
+// @Summary      List payments
+// @Description  List payments filtered by status, method, currency, amount range and creation window using cursor pagination
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        status        query     string  false  "Payment status"  Enums(CREATED, PROCESSING, COMPLETED, FAILED, CANCELED, REFUNDED, EXPIRED, PARTIALLY_REFUNDED)
+// @Param        method        query     string  false  "Payment method"  Enums(PIX, CARD)
+// @Param        currency      query     string  false  "ISO-4217 currency code, required with min_amount or max_amount"
+// @Param        min_amount    query     string  false  "Minimum amount as a decimal, e.g. 10.00"
+// @Param        max_amount    query     string  false  "Maximum amount as a decimal, e.g. 500.00"
+// @Param        created_from  query     string  false  "Created at or after (RFC3339)"
+// @Param        created_to    query     string  false  "Created before (RFC3339)"
+// @Param        cursor        query     string  false  "Cursor returned as next_cursor by the previous page"
+// @Param        limit         query     string  false  "Page length"  Enums(10, 50, 100)
+// @Success      200  {object}  dto.ListPaymentsOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /payments [get]
+func (h *ListPayments) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ListPaymentsHandler.Handle")
+		defer span.End()
+
+		var input dto.ListPaymentsInput
+		if err := ctx.ShouldBindQuery(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid query parameters"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListWebhookDeliveries struct {
+	UseCase   usecase.ListWebhookDeliveries
+	Presenter api.Presenter
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  List merchant webhook deliveries, newest first, filtered by subscription and status using cursor pagination
+// @Tags         Merchant Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        subscription_id  query     int     false  "Subscription ID"
+// @Param        status           query     string  false  "Delivery status"  Enums(PENDING, DELIVERED, DEAD)
+// @Param        cursor           query     string  false  "Cursor returned as next_cursor by the previous page"
+// @Param        limit            query     string  false  "Page length"  Enums(10, 50, 100)
+// @Success      200  {object}  dto.ListWebhookDeliveriesOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhook-deliveries [get]
+func (h *ListWebhookDeliveries) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ListWebhookDeliveriesHandler.Handle")
+		defer span.End()
+
+		var input dto.ListWebhookDeliveriesInput
+		if err := ctx.ShouldBindQuery(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid query parameters"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListWebhookSubscriptions struct {
+	UseCase   usecase.ListWebhookSubscriptions
+	Presenter api.Presenter
+}
+
+// ListWebhookSubscriptions godoc
+// @Summary      List webhook subscriptions
+// @Description  List the endpoints subscribed to payment events
+// @Tags         Merchant Webhooks
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  dto.ListWebhookSubscriptionsOutput
+// @Failure      401  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhook-subscriptions [get]
+func (h *ListWebhookSubscriptions) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ListWebhookSubscriptionsHandler.Handle")
+		defer span.End()
+
+		output, err := h.UseCase.Execute(reqCtx, dto.ListWebhookSubscriptionsInput{})
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
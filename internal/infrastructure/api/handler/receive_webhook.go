@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/signature"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ReceiveWebhook struct {
+	UseCase   usecase.ReceiveWebhook
+	Presenter api.Presenter
+}
+
+// ReceiveWebhook godoc
+// @Summary      Receive a provider webhook
+// @Description  Receive a payment status notification from a provider. The body must be signed with the provider secret: X-Webhook-Signature is sha256=<hex HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>">. Events are applied once per id; events that can't be applied are stored as REJECTED for replay
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        provider             path    string                    true  "Provider name"
+// @Param        X-Webhook-Timestamp  header  string                    true  "Unix timestamp the signature covers"
+// @Param        X-Webhook-Signature  header  string                    true  "sha256=<hex signature>"
+// @Param        notification         body    dto.ProviderNotification  true  "Notification"
+// @Success      200  {object}  dto.WebhookOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Router       /webhooks/{provider} [post]
+func (h *ReceiveWebhook) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ReceiveWebhookHandler.Handle")
+		defer span.End()
+
+		// The signature covers the raw body, so it's read as is
+		body, err := ctx.GetRawData()
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, dto.ReceiveWebhookInput{
+			Provider:  ctx.Param("provider"),
+			Timestamp: ctx.GetHeader(signature.HeaderTimestamp),
+			Signature: ctx.GetHeader(signature.HeaderSignature),
+			Body:      body,
+		})
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/signature"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReceiveWebhookHandle(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	body := `{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`
+
+	useCase := base.NewMockUseCase[dto.ReceiveWebhookInput, *dto.WebhookOutput](ctrl)
+	useCase.EXPECT().
+		Execute(gomock.Any(), dto.ReceiveWebhookInput{
+			Provider:  "simulator",
+			Timestamp: "1700000000",
+			Signature: "sha256=abc",
+			Body:      []byte(body),
+		}).
+		Return(&dto.WebhookOutput{EventID: "evt_1", Status: "PROCESSED"}, nil)
+	useCase.EXPECT().
+		Execute(gomock.Any(), gomock.Any()).
+		Return(nil, appErr.NewUnauthorized("invalid webhook signature"))
+
+	h := &ReceiveWebhook{UseCase: useCase, Presenter: presenter.NewJson()}
+	_, router, _ := api.MockGin()
+	router.POST("/webhooks/:provider", h.Handle())
+
+	cases := []struct {
+		signature string
+		want      int
+	}{
+		{signature: "sha256=abc", want: http.StatusOK},
+		{signature: "sha256=def", want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/simulator", strings.NewReader(body))
+		req.Header.Set(signature.HeaderTimestamp, "1700000000")
+		req.Header.Set(signature.HeaderSignature, tc.signature)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tc.want, recorder.Code, tc.signature)
+	}
+}
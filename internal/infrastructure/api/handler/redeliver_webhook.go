@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RedeliverWebhook struct {
+	UseCase   usecase.RedeliverWebhook
+	Presenter api.Presenter
+}
+
+// RedeliverWebhook godoc
+// @Summary      Redeliver a webhook
+// @Description  Schedule a delivered or dead-lettered webhook delivery to be sent again with a fresh set of attempts
+// @Tags         Merchant Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Delivery ID"
+// @Success      202  {object}  dto.WebhookDeliveryOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /webhook-deliveries/{id}/redeliver [post]
+func (h *RedeliverWebhook) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "RedeliverWebhookHandler.Handle")
+		defer span.End()
+
+		var input dto.RedeliverWebhookInput
+		if err := ctx.ShouldBindUri(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid delivery ID"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusAccepted)
+	}
+}
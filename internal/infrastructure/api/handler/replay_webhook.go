@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ReplayWebhook struct {
+	UseCase   usecase.ReplayWebhook
+	Presenter api.Presenter
+}
+
+// ReplayWebhook godoc
+// @Summary      Replay a rejected provider webhook
+// @Description  Apply a provider webhook event that was rejected again
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        provider  path  string  true  "Provider name"
+// @Param        event_id  path  string  true  "Event ID"
+// @Success      200  {object}  dto.WebhookOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      403  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/webhooks/{provider}/events/{event_id}/replay [post]
+func (h *ReplayWebhook) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "ReplayWebhookHandler.Handle")
+		defer span.End()
+
+		var input dto.ReplayWebhookInput
+		if err := ctx.ShouldBindUri(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid webhook event"))
+			return
+		}
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
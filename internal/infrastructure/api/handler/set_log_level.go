@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type SetLogLevel struct {
+	Logger    log.Logger
+	Presenter api.Presenter
+}
+
+// SetLogLevel godoc
+// @Summary      Change the log level
+// @Description  Change the level of the application logger while running, until the next restart, which goes back to LOG_LEVEL. Requires the admin scope
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      LogLevel  true  "New level"
+// @Success      200      {object}  LogLevel
+// @Failure      400      {object}  api.HttpError
+// @Failure      401      {object}  api.HttpError
+// @Failure      403      {object}  api.HttpError
+// @Failure      429      {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/log-level [put]
+func (h *SetLogLevel) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "SetLogLevelHandler.Handle")
+		defer span.End()
+
+		var input LogLevel
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+
+		level, err := log.LevelFromString(input.Level)
+		if err != nil {
+			h.Presenter.Error(ctx, appErr.NewBadFormat(err.Error()))
+			return
+		}
+
+		previous := h.Logger.Level()
+		h.Logger.SetLevel(level)
+
+		// Logged as a warning so the change shows up at any level but fatal
+		principal, _ := auth.FromContext(reqCtx)
+		h.Logger.WithContext(reqCtx).Warnf("log level changed from %s to %s by %s", previous, level, principal.Subject)
+
+		h.Presenter.Present(ctx, LogLevel{Level: level.String()}, http.StatusOK)
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLogLevelHandle(t *testing.T) {
+	test.Setup(t, nil)
+
+	logger := log.NewLogrus(log.WithLevel(log.LevelInfo), log.Output(io.Discard))
+	h := &SetLogLevel{Logger: logger, Presenter: presenter.NewJson()}
+	_, router, _ := api.MockGin()
+	router.PUT("/admin/log-level", h.Handle())
+
+	cases := []struct {
+		body  string
+		want  int
+		level log.Level
+	}{
+		{body: `{"level":"debug"}`, want: http.StatusOK, level: log.LevelDebug},
+		{body: `{"level":"verbose"}`, want: http.StatusBadRequest, level: log.LevelDebug},
+		{body: `{}`, want: http.StatusBadRequest, level: log.LevelDebug},
+		{body: `{"level":"WARN"}`, want: http.StatusOK, level: log.LevelWarn},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tc.want, recorder.Code, tc.body)
+		assert.Equal(t, tc.level, logger.Level(), tc.body)
+	}
+}
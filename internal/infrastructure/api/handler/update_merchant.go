@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type UpdateMerchant struct {
+	UseCase   usecase.UpdateMerchant
+	Presenter api.Presenter
+}
+
+// UpdateMerchant godoc
+// @Summary      Update a merchant
+// @Description  Change the name, status, payment methods or settlement of a merchant, keeping the fields left out. Closed merchants can't be changed. Requires the admin scope
+// @Tags         Merchants
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "Merchant ID"
+// @Param        request  body      dto.UpdateMerchantInput  true  "Fields to change"
+// @Success      200      {object}  dto.MerchantOutput
+// @Failure      400      {object}  api.HttpError
+// @Failure      401      {object}  api.HttpError
+// @Failure      403      {object}  api.HttpError
+// @Failure      404      {object}  api.HttpError
+// @Failure      409      {object}  api.HttpError
+// @Failure      429      {object}  api.HttpError
+// @Failure      500      {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /admin/merchants/{id} [patch]
+func (h *UpdateMerchant) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "UpdateMerchantHandler.Handle")
+		defer span.End()
+
+		var uri dto.GetMerchantInput
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid merchant id"))
+			return
+		}
+
+		var input dto.UpdateMerchantInput
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+		input.ID = uri.ID
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type UpdatePaymentStatus struct {
+	UseCase   usecase.UpdatePaymentStatus
+	Presenter api.Presenter
+}
+
+// UpdatePaymentStatus godoc
+// @Summary      Update a payment status
+// @Description  Move a payment to PROCESSING, FAILED or CANCELED following the payment state machine and publish a payment.<status> event to Kafka. Captures and refunds go through the provider, so COMPLETED and the refunded statuses are rejected
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int                           true  "Payment ID"
+// @Param        status  body  dto.UpdatePaymentStatusInput  true  "Next status"
+// @Success      200  {object}  dto.UpdatePaymentStatusOutput
+// @Failure      400  {object}  api.HttpError
+// @Failure      401  {object}  api.HttpError
+// @Failure      404  {object}  api.HttpError
+// @Failure      409  {object}  api.HttpError
+// @Failure      429  {object}  api.HttpError
+// @Failure      500  {object}  api.HttpError
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /payments/{id}/status [patch]
+func (h *UpdatePaymentStatus) Handle() func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		reqCtx, span := metrics.StartSpan(ctx.Request.Context(), "UpdatePaymentStatusHandler.Handle")
+		defer span.End()
+
+		var uri dto.GetPaymentInput
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid payment id"))
+			return
+		}
+
+		var input dto.UpdatePaymentStatusInput
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			metrics.AddSpanEvent(reqCtx, "bind.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, appErr.HttpBadRequest("Invalid request body"))
+			return
+		}
+		input.ID = uri.ID
+
+		output, err := h.UseCase.Execute(reqCtx, input)
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "usecase.failed", attribute.String("error", err.Error()))
+			h.Presenter.Error(ctx, err)
+			return
+		}
+
+		h.Presenter.Present(ctx, output, http.StatusOK)
+	}
+}
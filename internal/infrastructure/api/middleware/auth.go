@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	apiKeyHeader        = "X-API-KEY"
+	authorizationHeader = "Authorization"
+)
+
+// Auth rejects requests without a valid X-API-KEY header or bearer token and
+// puts the principal of the others in the request context.
+type Auth struct {
+	Authenticator *auth.Authenticator
+	Presenter     api.Presenter
+}
+
+func (a Auth) Handle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := ctx.Request.Context()
+
+		principal, err := a.Authenticator.Authenticate(reqCtx, auth.Credentials{
+			ApiKey:      ctx.GetHeader(apiKeyHeader),
+			BearerToken: auth.BearerToken(ctx.GetHeader(authorizationHeader)),
+		})
+		if err != nil {
+			metrics.AddSpanEvent(reqCtx, "auth.failed", attribute.String("error", err.Error()))
+			a.Presenter.Error(ctx, err)
+			ctx.Abort()
+			return
+		}
+
+		metrics.AddSpanAttributes(reqCtx,
+			attribute.String("auth.method", string(principal.Method)),
+			attribute.Int64("merchant.id", principal.MerchantID),
+		)
+
+		ctx.Request = ctx.Request.WithContext(auth.NewContext(reqCtx, principal))
+		ctx.Next()
+	}
+}
+
+// RequireScope rejects requests whose principal lacks scope. It runs after
+// Handle, which puts the principal in the request context.
+func (a Auth) RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := ctx.Request.Context()
+
+		principal, ok := auth.FromContext(reqCtx)
+		if !ok || !principal.HasScope(scope) {
+			metrics.AddSpanEvent(reqCtx, "auth.scope.missing", attribute.String("auth.scope", scope))
+			a.Presenter.Error(ctx, appErr.NewForbidden(fmt.Sprintf("missing scope %s", scope)))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/test"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthHandle(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	apiKeys := repository.NewMockApiKeyRepository(ctrl)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_valid")).
+		Return(&entity.ApiKey{MerchantID: 7, Prefix: "pk_valid"}, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_unknown")).Return(nil, nil)
+
+	middleware := Auth{Authenticator: auth.NewAuthenticator(apiKeys, nil), Presenter: presenter.NewJson()}
+
+	_, router, _ := api.MockGin()
+	router.GET("/payments", middleware.Handle(), func(ctx *gin.Context) {
+		principal, ok := auth.FromContext(ctx.Request.Context())
+		assert.True(t, ok)
+		assert.Equal(t, int64(7), principal.MerchantID)
+		ctx.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{name: "api key", headers: map[string]string{"X-API-KEY": "pk_valid"}, want: http.StatusOK},
+		{name: "unknown api key", headers: map[string]string{"X-API-KEY": "pk_unknown"}, want: http.StatusUnauthorized},
+		{name: "bearer without verifier", headers: map[string]string{"Authorization": "Bearer token"}, want: http.StatusUnauthorized},
+		{name: "no credentials", want: http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/payments", nil)
+		for name, value := range tc.headers {
+			req.Header.Set(name, value)
+		}
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, tc.want, recorder.Code, tc.name)
+	}
+}
+
+func TestAuthRequireScope(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	apiKeys := repository.NewMockApiKeyRepository(ctrl)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_admin")).
+		Return(&entity.ApiKey{MerchantID: 1, Prefix: "pk_admin", Scopes: []string{auth.ScopeAdmin}}, nil)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_merchant")).
+		Return(&entity.ApiKey{MerchantID: 7, Prefix: "pk_merchant"}, nil)
+
+	middleware := Auth{Authenticator: auth.NewAuthenticator(apiKeys, nil), Presenter: presenter.NewJson()}
+
+	_, router, _ := api.MockGin()
+	router.GET("/admin/merchants", middleware.Handle(), middleware.RequireScope(auth.ScopeAdmin), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	for key, want := range map[string]int{"pk_admin": http.StatusOK, "pk_merchant": http.StatusForbidden} {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/merchants", nil)
+		req.Header.Set("X-API-KEY", key)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, want, recorder.Code, key)
+	}
+}
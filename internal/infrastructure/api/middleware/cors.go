@@ -1,21 +1,253 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// CorsConfig is the CORS policy of the API. Origins are exact, such as
+// https://shop.example.com, patterns where * stands for a host label or a
+// port, such as https://*.example.com, or "*" for any origin, which can't
+// be combined with credentials.
+type CorsConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+
+	// RouteOrigins replaces AllowedOrigins for the routes under each path
+	// prefix, the longest prefix winning
+	RouteOrigins map[string][]string
+}
+
+// Cors answers preflight requests and adds the CORS headers to responses
+// for allowed origins. It runs on every request, registered on the router,
+// since preflights don't match any route.
 type Cors struct {
-	Presenter api.Presenter
+	origins corsOrigins
+	routes  []corsRoute
+
+	methods     []string
+	headers     []string
+	anyHeader   bool
+	exposed     string
+	credentials bool
+	maxAge      string
+
+	presenter api.Presenter
+}
+
+type corsRoute struct {
+	prefix  string
+	origins corsOrigins
 }
 
-func (c Cors) Handle() gin.HandlerFunc {
+// corsOrigins matches request origins against the allowed ones.
+type corsOrigins struct {
+	any      bool
+	exact    map[string]bool
+	patterns []*regexp.Regexp
+}
+
+func NewCors(config CorsConfig, presenter api.Presenter) (*Cors, error) {
+	origins, err := newCorsOrigins(config.AllowedOrigins, config.AllowCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	cors := &Cors{
+		origins:     origins,
+		methods:     upper(config.AllowedMethods),
+		exposed:     strings.Join(config.ExposedHeaders, ", "),
+		credentials: config.AllowCredentials,
+		presenter:   presenter,
+	}
+
+	for _, header := range config.AllowedHeaders {
+		if header == "*" {
+			cors.anyHeader = true
+			continue
+		}
+		cors.headers = append(cors.headers, http.CanonicalHeaderKey(header))
+	}
+
+	if config.MaxAge > 0 {
+		cors.maxAge = strconv.Itoa(int(config.MaxAge.Seconds()))
+	}
+
+	for prefix, allowed := range config.RouteOrigins {
+		origins, err := newCorsOrigins(allowed, config.AllowCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS origins for %s: %w", prefix, err)
+		}
+		cors.routes = append(cors.routes, corsRoute{prefix: prefix, origins: origins})
+	}
+	sort.Slice(cors.routes, func(i, j int) bool {
+		return len(cors.routes[i].prefix) > len(cors.routes[j].prefix)
+	})
+
+	return cors, nil
+}
+
+func (c *Cors) Handle() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		ctx.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		ctx.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		ctx.Next()
+		origin := ctx.GetHeader("Origin")
+		preflight := ctx.Request.Method == http.MethodOptions && ctx.GetHeader("Access-Control-Request-Method") != ""
+
+		header := ctx.Writer.Header()
+		header.Add("Vary", "Origin")
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			ctx.Next()
+			return
+		}
+
+		origins := c.originsFor(ctx.Request.URL.Path)
+		if !origins.allows(origin) {
+			if preflight {
+				c.presenter.Error(ctx, appErr.NewForbidden(fmt.Sprintf("origin %s not allowed", origin)))
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		if origins.any && !c.credentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if c.exposed != "" {
+				header.Set("Access-Control-Expose-Headers", c.exposed)
+			}
+			ctx.Next()
+			return
+		}
+
+		if err := c.checkPreflight(ctx); err != nil {
+			c.presenter.Error(ctx, err)
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", strings.Join(c.methods, ", "))
+		if requested := ctx.GetHeader("Access-Control-Request-Headers"); requested != "" {
+			header.Set("Access-Control-Allow-Headers", requested)
+		}
+		if c.maxAge != "" {
+			header.Set("Access-Control-Max-Age", c.maxAge)
+		}
+
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// checkPreflight rejects preflights asking for a method or header the
+// policy doesn't allow.
+func (c *Cors) checkPreflight(ctx *gin.Context) error {
+	method := ctx.GetHeader("Access-Control-Request-Method")
+	if !contains(c.methods, strings.ToUpper(method)) {
+		return appErr.NewForbidden(fmt.Sprintf("method %s not allowed", method))
+	}
+
+	if c.anyHeader {
+		return nil
+	}
+	for _, requested := range strings.Split(ctx.GetHeader("Access-Control-Request-Headers"), ",") {
+		requested = http.CanonicalHeaderKey(strings.TrimSpace(requested))
+		if requested != "" && !contains(c.headers, requested) {
+			return appErr.NewForbidden(fmt.Sprintf("header %s not allowed", requested))
+		}
+	}
+	return nil
+}
+
+func (c *Cors) originsFor(path string) corsOrigins {
+	for _, route := range c.routes {
+		if strings.HasPrefix(path, route.prefix) {
+			return route.origins
+		}
+	}
+	return c.origins
+}
+
+// originLabel is what * stands for in an origin pattern: a host label or a
+// port, never a scheme separator or a path.
+const originLabel = `[a-z0-9-]+`
+
+func newCorsOrigins(allowed []string, credentials bool) (corsOrigins, error) {
+	origins := corsOrigins{exact: map[string]bool{}}
+
+	for _, origin := range allowed {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "":
+			continue
+		case origin == "*":
+			if credentials {
+				return corsOrigins{}, errors.New("the * origin can't be allowed with credentials, list the origins instead")
+			}
+			origins.any = true
+		case strings.Contains(origin, "*"):
+			parts := strings.Split(origin, "*")
+			for i := range parts {
+				parts[i] = regexp.QuoteMeta(parts[i])
+			}
+			origins.patterns = append(origins.patterns, regexp.MustCompile("^"+strings.Join(parts, originLabel)+"$"))
+		default:
+			origins.exact[origin] = true
+		}
+	}
+
+	return origins, nil
+}
+
+func (o corsOrigins) allows(origin string) bool {
+	origin = strings.ToLower(origin)
+	if o.any || o.exact[origin] {
+		return true
+	}
+	for _, pattern := range o.patterns {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func upper(values []string) []string {
+	upper := make([]string, 0, len(values))
+	for _, value := range values {
+		upper = append(upper, strings.ToUpper(strings.TrimSpace(value)))
+	}
+	return upper
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
 }
@@ -1 +1,121 @@
 package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/test"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func corsRouter(t *testing.T, config CorsConfig) *gin.Engine {
+	cors, err := NewCors(config, presenter.NewJson())
+	assert.NoError(t, err)
+
+	_, router, _ := api.MockGin()
+	router.Use(cors.Handle())
+	router.POST("/v1/payments/payments", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+	router.GET("/v1/payments/admin/merchants", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	return router
+}
+
+func corsRequest(router *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCorsHandle(t *testing.T) {
+	test.Setup(t, nil)
+
+	router := corsRouter(t, CorsConfig{
+		AllowedOrigins:   []string{"https://checkout.example.com", "https://*.shop.example.com", "http://localhost:*"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-API-KEY", "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Trace-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+		RouteOrigins: map[string][]string{
+			"/v1/payments/admin": {"https://admin.example.com"},
+		},
+	})
+
+	recorder := corsRequest(router, http.MethodOptions, "/v1/payments/payments", map[string]string{
+		"Origin":                         "https://acme.shop.example.com",
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "content-type, x-api-key",
+	})
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, "https://acme.shop.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST", recorder.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "content-type, x-api-key", recorder.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+
+	recorder = corsRequest(router, http.MethodPost, "/v1/payments/payments", map[string]string{"Origin": "http://localhost:3000"})
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "http://localhost:3000", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Trace-ID", recorder.Header().Get("Access-Control-Expose-Headers"))
+	assert.Contains(t, recorder.Header().Values("Vary"), "Origin")
+
+	rejected := map[string]map[string]string{
+		"unknown origin":    {"Origin": "https://evil.example.com", "Access-Control-Request-Method": "POST"},
+		"nested subdomain":  {"Origin": "https://a.b.shop.example.com", "Access-Control-Request-Method": "POST"},
+		"method not listed": {"Origin": "https://checkout.example.com", "Access-Control-Request-Method": "DELETE"},
+		"header not listed": {"Origin": "https://checkout.example.com", "Access-Control-Request-Method": "POST", "Access-Control-Request-Headers": "X-Other"},
+	}
+	for name, headers := range rejected {
+		recorder := corsRequest(router, http.MethodOptions, "/v1/payments/payments", headers)
+		assert.Equal(t, http.StatusForbidden, recorder.Code, name)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Methods"), name)
+	}
+
+	// Requests from other origins go through without CORS headers
+	recorder = corsRequest(router, http.MethodPost, "/v1/payments/payments", map[string]string{"Origin": "https://evil.example.com"})
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+
+	// Admin routes only allow their own origins
+	recorder = corsRequest(router, http.MethodGet, "/v1/payments/admin/merchants", map[string]string{"Origin": "https://checkout.example.com"})
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	recorder = corsRequest(router, http.MethodGet, "/v1/payments/admin/merchants", map[string]string{"Origin": "https://admin.example.com"})
+	assert.Equal(t, "https://admin.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsHandleAnyOrigin(t *testing.T) {
+	test.Setup(t, nil)
+
+	router := corsRouter(t, CorsConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"POST"}, AllowedHeaders: []string{"*"}})
+
+	recorder := corsRequest(router, http.MethodOptions, "/v1/payments/payments", map[string]string{
+		"Origin":                         "https://anywhere.example.com",
+		"Access-Control-Request-Method":  "POST",
+		"Access-Control-Request-Headers": "X-Anything",
+	})
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "X-Anything", recorder.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestNewCorsRejectsAnyOriginWithCredentials(t *testing.T) {
+	_, err := NewCors(CorsConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, presenter.NewJson())
+	assert.Error(t, err)
+
+	_, err = NewCors(CorsConfig{
+		AllowedOrigins:   []string{"https://checkout.example.com"},
+		AllowCredentials: true,
+		RouteOrigins:     map[string][]string{"/v1/payments/payments": {"*"}},
+	}, presenter.NewJson())
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"go-payments-api/pkg/metrics"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// unmatchedRoute labels the requests matching no route, so scans of random
+// paths don't create a series per path.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the duration of every request by route, method and
+// status, from which the rate, errors and duration of each route are read.
+type Metrics struct{}
+
+func (m *Metrics) Handle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		status := ctx.Writer.Status()
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", ctx.Request.Method),
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", status),
+		}
+		if status >= http.StatusInternalServerError {
+			attrs = append(attrs, attribute.String("error.type", strconv.Itoa(status)))
+		}
+
+		metrics.RecordDuration(ctx.Request.Context(), metrics.HTTPServerDuration, start, attrs...)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/test"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsHandle(t *testing.T) {
+	test.Setup(t, nil)
+
+	// metrics.Meter comes from the global provider, which forwards to the
+	// first provider set
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	_, router, _ := api.MockGin()
+	router.Use((&Metrics{}).Handle())
+	router.GET("/v1/payments/payments/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	router.POST("/v1/payments/payments", func(ctx *gin.Context) { ctx.Status(http.StatusServiceUnavailable) })
+
+	corsRequest(router, http.MethodGet, "/v1/payments/payments/1", nil)
+	corsRequest(router, http.MethodGet, "/v1/payments/payments/2", nil)
+	corsRequest(router, http.MethodPost, "/v1/payments/payments", nil)
+	corsRequest(router, http.MethodGet, "/wp-admin", nil)
+
+	var data metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &data))
+
+	counts := map[string]uint64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != metrics.HTTPServerDuration.Name {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				method, _ := point.Attributes.Value("http.request.method")
+				route, _ := point.Attributes.Value("http.route")
+				status, _ := point.Attributes.Value("http.response.status_code")
+				errorType, _ := point.Attributes.Value("error.type")
+				counts[method.AsString()+" "+route.AsString()+" "+status.Emit()+" "+errorType.AsString()] = point.Count
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]uint64{
+		"GET /v1/payments/payments/:id 200 ": 2,
+		"POST /v1/payments/payments 503 503": 1,
+		"GET unmatched 404 ":                 1,
+	}, counts)
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/ratelimit"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// What the requests of a rule are limited by
+const (
+	RateLimitByIP       = "ip"
+	RateLimitByApiKey   = "api_key"
+	RateLimitByMerchant = "merchant"
+)
+
+// RateLimit rejects requests over the rate limit rules of their route group
+// with 429 and tells clients where they stand with X-RateLimit-* headers.
+type RateLimit struct {
+	Store     ratelimit.Store
+	Presenter api.Presenter
+}
+
+// Handle limits the requests of group with rules, spending a token of each
+// rule in order until one rejects the request, so rejected requests don't
+// spend the rules after it. Rules by API key or merchant read the principal
+// Auth puts in the context, so Handle goes after it, and fall back to the
+// client IP without one. When the store fails requests are let through.
+func (r RateLimit) Handle(group string, rules ...ratelimit.Rule) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := ctx.Request.Context()
+
+		var (
+			reported ratelimit.Result
+			taken    bool
+		)
+		for i, rule := range rules {
+			key := fmt.Sprintf("%s:%d:%s", group, i, rateLimitKey(ctx, rule.By))
+
+			result, err := r.Store.Take(reqCtx, key, rule.Limit)
+			if err != nil {
+				metrics.AddSpanEvent(reqCtx, "ratelimit.failed", attribute.String("error", err.Error()))
+				continue
+			}
+
+			if !taken || tighter(result, reported) {
+				reported = result
+			}
+			taken = true
+
+			if !result.Allowed {
+				break
+			}
+		}
+
+		if !taken {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("X-RateLimit-Limit", strconv.Itoa(reported.Limit))
+		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(reported.Remaining))
+		ctx.Header("X-RateLimit-Reset", seconds(reported.Reset))
+
+		if !reported.Allowed {
+			ctx.Header("Retry-After", seconds(reported.RetryAfter))
+			metrics.AddSpanEvent(reqCtx, "ratelimit.exceeded", attribute.String("ratelimit.group", group))
+			r.Presenter.Error(ctx, appErr.NewTooManyRequests("rate limit exceeded"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// rateLimitKey returns who the request counts against under by. JWTs without
+// a subject count against their merchant, so they don't share a bucket.
+func rateLimitKey(ctx *gin.Context, by string) string {
+	principal, ok := auth.FromContext(ctx.Request.Context())
+
+	switch {
+	case ok && by == RateLimitByApiKey && principal.ApiKeyID != 0:
+		return fmt.Sprintf("api_key:%d", principal.ApiKeyID)
+	case ok && by == RateLimitByApiKey && principal.Subject != "":
+		return "jwt:" + principal.Subject
+	case ok && principal.MerchantID != 0 && (by == RateLimitByApiKey || by == RateLimitByMerchant):
+		return fmt.Sprintf("merchant:%d", principal.MerchantID)
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+// tighter reports whether a is the result to report over b: a rejection
+// over an allowance, the longest wait among rejections and the fewest
+// tokens left among allowances.
+func tighter(a, b ratelimit.Result) bool {
+	switch {
+	case a.Allowed != b.Allowed:
+		return !a.Allowed
+	case !a.Allowed:
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+// seconds rounds d up to whole seconds, as the headers carry.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
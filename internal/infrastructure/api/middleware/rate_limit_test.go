@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/api/presenter"
+	"go-payments-api/pkg/ratelimit"
+	"go-payments-api/test"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHandle(t *testing.T) {
+	test.Setup(t, nil)
+
+	middleware := RateLimit{Store: ratelimit.NewMemoryStore(), Presenter: presenter.NewJson()}
+
+	_, router, _ := api.MockGin()
+	router.GET("/payments",
+		func(ctx *gin.Context) {
+			if merchant := ctx.GetHeader("X-Merchant"); merchant != "" {
+				principal := auth.Principal{MerchantID: int64(len(merchant))}
+				ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), principal))
+			}
+		},
+		middleware.Handle("authenticated",
+			ratelimit.Rule{Limit: ratelimit.Limit{Requests: 10, Period: time.Second, Burst: 10}, By: RateLimitByIP},
+			ratelimit.Rule{Limit: ratelimit.Limit{Requests: 1, Period: time.Minute, Burst: 2}, By: RateLimitByMerchant},
+		),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+	)
+
+	request := func(merchant string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/payments", nil)
+		req.Header.Set("X-Merchant", merchant)
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := request("a")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("X-RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, request("a").Code)
+
+	recorder = request("a")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "0", recorder.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+
+	// Another merchant behind the same IP has a quota of its own
+	assert.Equal(t, http.StatusOK, request("bb").Code)
+}
+
+func TestRateLimitHandleStopsAtRejection(t *testing.T) {
+	test.Setup(t, nil)
+
+	middleware := RateLimit{Store: ratelimit.NewMemoryStore(), Presenter: presenter.NewJson()}
+
+	_, router, _ := api.MockGin()
+	router.GET("/payments",
+		func(ctx *gin.Context) {
+			principal := auth.Principal{MerchantID: int64(len(ctx.GetHeader("X-Merchant")))}
+			ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), principal))
+		},
+		middleware.Handle("authenticated",
+			ratelimit.Rule{Limit: ratelimit.Limit{Requests: 1, Period: time.Minute, Burst: 1}, By: RateLimitByMerchant},
+			ratelimit.Rule{Limit: ratelimit.Limit{Requests: 1, Period: time.Minute, Burst: 2}, By: RateLimitByIP},
+		),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+	)
+
+	request := func(merchant string) int {
+		recorder := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/payments", nil)
+		req.Header.Set("X-Merchant", merchant)
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("a"))
+	assert.Equal(t, http.StatusTooManyRequests, request("a"))
+
+	// The rejected request didn't spend the IP rule
+	assert.Equal(t, http.StatusOK, request("bb"))
+	assert.Equal(t, http.StatusTooManyRequests, request("ccc"))
+}
+
+func TestRateLimitKey(t *testing.T) {
+	cases := []struct {
+		name      string
+		principal *auth.Principal
+		by        string
+		want      string
+	}{
+		{name: "api key", principal: &auth.Principal{ApiKeyID: 4, MerchantID: 3}, by: RateLimitByApiKey, want: "api_key:4"},
+		{name: "jwt", principal: &auth.Principal{Subject: "user-1", MerchantID: 3}, by: RateLimitByApiKey, want: "jwt:user-1"},
+		{name: "jwt without subject", principal: &auth.Principal{MerchantID: 3}, by: RateLimitByApiKey, want: "merchant:3"},
+		{name: "jwt without subject nor merchant", principal: &auth.Principal{}, by: RateLimitByApiKey, want: "ip:10.0.0.1"},
+		{name: "merchant", principal: &auth.Principal{MerchantID: 3}, by: RateLimitByMerchant, want: "merchant:3"},
+		{name: "ip", principal: &auth.Principal{ApiKeyID: 4, MerchantID: 3}, by: RateLimitByIP, want: "ip:10.0.0.1"},
+		{name: "anonymous", by: RateLimitByApiKey, want: "ip:10.0.0.1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _, _ := api.MockGin()
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.RemoteAddr = "10.0.0.1:1234"
+			if tc.principal != nil {
+				ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), *tc.principal))
+			}
+
+			assert.Equal(t, tc.want, rateLimitKey(ctx, tc.by))
+		})
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimitHandleStoreError(t *testing.T) {
+	test.Setup(t, nil)
+
+	middleware := RateLimit{Store: failingStore{}, Presenter: presenter.NewJson()}
+
+	_, router, recorder := api.MockGin()
+	router.GET("/health",
+		middleware.Handle("public", ratelimit.Rule{Limit: ratelimit.Limit{Requests: 1, Period: time.Second}, By: RateLimitByIP}),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health", nil)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("X-RateLimit-Limit"))
+}
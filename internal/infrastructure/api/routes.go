@@ -1,8 +1,9 @@
 package api
 
 import (
-    "fmt"
     "go-payments-api/docs"
+    "go-payments-api/internal/application/auth"
+    "go-payments-api/internal/settings"
 
     "github.com/gin-gonic/gin"
     swaggerFiles "github.com/swaggo/files"
@@ -16,6 +17,9 @@ const (
 func (a *Application) SetupRoutes() {
     router := a.Server.GetRouter()
 
+    // Metrics and CORS run on every request, preflights included, before routing
+    router.Use(a.MetricsMiddleware.Handle(), a.CorsMiddleware.Handle())
+
     // Swagger Docs
     docs.SwaggerInfo.Title = "Go Payments API"
     docs.SwaggerInfo.BasePath = prefix
@@ -29,17 +33,66 @@ func (a *Application) SetupRoutes() {
     router.GET("/docs/payments/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
     // Base Routes
-    base := router.Group(prefix)
+    base := router.Group(prefix, a.RateLimitMiddleware.Handle("public", settings.Settings.RateLimit.Public...))
     {
         // Health Check
         base.GET("/health", a.HealthHandler.Handle())
-        
+
+        // Provider Webhooks, authenticated by their signatures
+        base.POST("/webhooks/:provider", a.ReceiveWebhookHandler.Handle())
+    }
+
+    // Authenticated Routes, scoped to the merchant of the API key or token.
+    // Requests are limited by IP before authentication too, so guessing
+    // credentials is limited as well
+    authenticated := router.Group(prefix,
+        a.RateLimitMiddleware.Handle("pre_auth", settings.Settings.RateLimit.PreAuth...),
+        a.AuthMiddleware.Handle(),
+        a.RateLimitMiddleware.Handle("authenticated", settings.Settings.RateLimit.Authenticated...),
+    )
+    {
         // Payments
-        base.POST("/payments", a.CreatePaymentHandler.Handle())
+        authenticated.POST("/payments", a.CreatePaymentHandler.Handle())
+        authenticated.GET("/payments", a.ListPaymentsHandler.Handle())
+        authenticated.GET("/payments/:id", a.GetPaymentHandler.Handle())
+        authenticated.PATCH("/payments/:id/status", a.UpdatePaymentStatusHandler.Handle())
+
+        // Refunds
+        authenticated.POST("/payments/:id/refunds", a.CreateRefundHandler.Handle())
+        authenticated.GET("/payments/:id/refunds", a.ListRefundsHandler.Handle())
+
+        // Merchant Webhooks
+        authenticated.POST("/webhook-subscriptions", a.CreateWebhookSubscriptionHandler.Handle())
+        authenticated.GET("/webhook-subscriptions", a.ListWebhookSubscriptionsHandler.Handle())
+        authenticated.GET("/webhook-deliveries", a.ListWebhookDeliveriesHandler.Handle())
+        authenticated.POST("/webhook-deliveries/:id/redeliver", a.RedeliverWebhookHandler.Handle())
+    }
+
+    // Admin Routes, for API keys or tokens with the admin scope
+    admin := router.Group(prefix+"/admin",
+        a.RateLimitMiddleware.Handle("pre_auth", settings.Settings.RateLimit.PreAuth...),
+        a.AuthMiddleware.Handle(),
+        a.AuthMiddleware.RequireScope(auth.ScopeAdmin),
+        a.RateLimitMiddleware.Handle("admin", settings.Settings.RateLimit.Admin...),
+    )
+    {
+        // Merchants
+        admin.POST("/merchants", a.CreateMerchantHandler.Handle())
+        admin.GET("/merchants", a.ListMerchantsHandler.Handle())
+        admin.GET("/merchants/:id", a.GetMerchantHandler.Handle())
+        admin.PATCH("/merchants/:id", a.UpdateMerchantHandler.Handle())
+        admin.DELETE("/merchants/:id", a.CloseMerchantHandler.Handle())
+
+        // Provider Webhooks, which aren't owned by any merchant
+        admin.POST("/webhooks/:provider/events/:event_id/replay", a.ReplayWebhookHandler.Handle())
+
+        // Logging
+        admin.GET("/log-level", a.GetLogLevelHandler.Handle())
+        admin.PUT("/log-level", a.SetLogLevelHandler.Handle())
     }
 
     // Log Registered Routes for Debugging
     for _, route := range router.Routes() {
-        fmt.Printf("Registered route: %s %s\n", route.Method, route.Path)
+        a.BaseApp.Logger.Debugf("registered route %s %s", route.Method, route.Path)
     }
 }
\ No newline at end of file
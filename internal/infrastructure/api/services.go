@@ -0,0 +1,17 @@
+package api
+
+import (
+	paymentsv1 "go-payments-api/api/proto/payments/v1"
+)
+
+func (a *Application) SetupServices() {
+	server := a.GrpcServer.GetRouter()
+
+	// Payments
+	paymentsv1.RegisterPaymentServiceServer(server, a.PaymentService)
+
+	// Log Registered Services for Debugging
+	for name := range server.GetServiceInfo() {
+		a.BaseApp.Logger.Debugf("registered service %s", name)
+	}
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+
+	"github.com/lib/pq"
+)
+
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+func NewApiKeyRepository(db *sql.DB) repository.ApiKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error) {
+	query := `
+        SELECT id, merchant_id, name, prefix, key_hash, scopes, expires_at, revoked_at, created_at
+        FROM api_keys
+        WHERE key_hash = $1
+    `
+
+	key := &entity.ApiKey{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, hash).Scan(
+		&key.ID,
+		&key.MerchantID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		pq.Array(&key.Scopes),
+		&key.ExpiresAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
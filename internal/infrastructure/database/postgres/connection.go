@@ -4,7 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"go-payments-api/internal/settings"
-	"log"
+	log "go-payments-api/pkg/log/implement"
 	"os"
 	"path/filepath"
 	"sort"
@@ -12,13 +12,20 @@ import (
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DB struct {
-	conn *sql.DB
+	conn   *sql.DB
+	logger log.Logger
 }
 
-func NewConnection() (*DB, error) {
+// NewConnection opens the database with every query traced by
+// tracerProvider. The query durations are recorded by the repositories, so
+// the metrics of otelsql are left off.
+func NewConnection(logger log.Logger, tracerProvider trace.TracerProvider) (*DB, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		settings.Settings.Database.Host,
@@ -28,7 +35,12 @@ func NewConnection() (*DB, error) {
 		settings.Settings.Database.Name,
 	)
 
-	conn, err := sql.Open("postgres", connStr)
+	conn, err := otelsql.Open("postgres", connStr,
+		otelsql.WithTracerProvider(tracerProvider),
+		otelsql.WithMeterProvider(noop.NewMeterProvider()),
+		otelsql.WithDBSystem("postgresql"),
+		otelsql.WithDBName(settings.Settings.Database.Name),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -42,18 +54,18 @@ func NewConnection() (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("✅ Database connection established")
+	logger.Infof("database connection established")
 
 	// Run migrations automatically
-	if err := runMigrationsFromFiles(conn); err != nil {
+	if err := runMigrationsFromFiles(conn, logger); err != nil {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return &DB{conn: conn}, nil
+	return &DB{conn: conn, logger: logger}, nil
 }
 
-func runMigrationsFromFiles(conn *sql.DB) error {
-	log.Println("🔄 Running database migrations...")
+func runMigrationsFromFiles(conn *sql.DB, logger log.Logger) error {
+	logger.Infof("running database migrations")
 
 	// Create schema_migrations table
 	_, err := conn.Exec(`
@@ -83,7 +95,7 @@ func runMigrationsFromFiles(conn *sql.DB) error {
 	}
 
 	if len(sqlFiles) == 0 {
-		log.Println("⚠️  No migration files found in scripts/migrations")
+		logger.Infof("no migration files found in %s", migrationsPath)
 		return nil
 	}
 
@@ -108,11 +120,11 @@ func runMigrationsFromFiles(conn *sql.DB) error {
 		}
 
 		if exists {
-			log.Printf("⏭️  Skipping migration %d: %s (already applied)", version, filename)
+			logger.Debugf("skipping migration %d: %s (already applied)", version, filename)
 			continue
 		}
 
-		log.Printf("⚙️  Applying migration %d: %s", version, filename)
+		logger.Infof("applying migration %d: %s", version, filename)
 
 		// Read SQL file
 		sqlContent, err := os.ReadFile(filepath.Join(migrationsPath, filename))
@@ -147,10 +159,10 @@ func runMigrationsFromFiles(conn *sql.DB) error {
 			return fmt.Errorf("failed to commit migration %d: %w", version, err)
 		}
 
-		log.Printf("✅ Applied migration %d: %s", version, filename)
+		logger.Infof("applied migration %d: %s", version, filename)
 	}
 
-	log.Println("✅ All migrations completed successfully")
+	logger.Infof("all migrations completed")
 	return nil
 }
 
@@ -160,7 +172,7 @@ func (db *DB) GetConnection() *sql.DB {
 
 func (db *DB) Close() error {
 	if db.conn != nil {
-		log.Printf("Closing PostgreSQL connection")
+		db.logger.Infof("closing postgres connection")
 		return db.conn.Close()
 	}
 	return nil
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+type idempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyKeyRepository(db *sql.DB) repository.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+// Lock takes an advisory lock on the key held by the transaction carried by
+// ctx, so concurrent requests with the same key wait here until the first one
+// commits or rolls back and then read what it stored. Called outside a
+// transaction the lock would be released right away.
+func (r *idempotencyKeyRepository) Lock(ctx context.Context, merchantID int64, key string) (*entity.IdempotencyKey, error) {
+	exec := conn(ctx, r.db)
+	if _, err := exec.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, $2))", key, merchantID); err != nil {
+		return nil, err
+	}
+
+	query := `
+        SELECT merchant_id, key, fingerprint, COALESCE(payment_id, 0), status_code, response, created_at
+        FROM idempotency_keys
+        WHERE merchant_id = $1 AND key = $2
+    `
+
+	record := &entity.IdempotencyKey{}
+	err := exec.QueryRowContext(ctx, query, merchantID, key).Scan(
+		&record.MerchantID,
+		&record.Key,
+		&record.Fingerprint,
+		&record.PaymentID,
+		&record.StatusCode,
+		&record.Response,
+		&record.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (r *idempotencyKeyRepository) Save(ctx context.Context, record *entity.IdempotencyKey) error {
+	query := `
+        INSERT INTO idempotency_keys (merchant_id, key, fingerprint, payment_id, status_code, response, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+
+	record.CreatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		record.MerchantID,
+		record.Key,
+		record.Fingerprint,
+		record.PaymentID,
+		record.StatusCode,
+		record.Response,
+		record.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, record *entity.IdempotencyKey) error {
+	query := `
+        UPDATE idempotency_keys
+        SET status_code = $3, response = $4
+        WHERE merchant_id = $1 AND key = $2
+    `
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, record.MerchantID, record.Key, record.StatusCode, record.Response)
+	return err
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const merchantColumns = `id, name, status, payment_methods, settlement_schedule, settlement_delay_days, created_at, updated_at`
+
+type merchantRepository struct {
+	db *sql.DB
+}
+
+func NewMerchantRepository(db *sql.DB) repository.MerchantRepository {
+	return &merchantRepository{db: db}
+}
+
+func (r *merchantRepository) Create(ctx context.Context, merchant *entity.Merchant) error {
+	query := `
+        INSERT INTO merchants (name, status, payment_methods, settlement_schedule, settlement_delay_days, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $6)
+        RETURNING id
+    `
+
+	merchant.CreatedAt = time.Now()
+	merchant.UpdatedAt = merchant.CreatedAt
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		merchant.Name,
+		merchant.Status,
+		pq.Array(merchant.PaymentMethods),
+		merchant.Settlement.Schedule,
+		merchant.Settlement.DelayDays,
+		merchant.CreatedAt,
+	).Scan(&merchant.ID)
+}
+
+func (r *merchantRepository) FindByID(ctx context.Context, id int64) (*entity.Merchant, error) {
+	query := `SELECT ` + merchantColumns + ` FROM merchants WHERE id = $1`
+
+	merchant, err := scanMerchant(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return merchant, nil
+}
+
+func (r *merchantRepository) List(ctx context.Context, status entity.MerchantStatus) ([]*entity.Merchant, error) {
+	query := `SELECT ` + merchantColumns + ` FROM merchants `
+	var args []interface{}
+	if status != "" {
+		query += `WHERE status = $1 `
+		args = append(args, status)
+	}
+	query += `ORDER BY id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merchants []*entity.Merchant
+	for rows.Next() {
+		merchant, err := scanMerchant(rows)
+		if err != nil {
+			return nil, err
+		}
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants, rows.Err()
+}
+
+func (r *merchantRepository) Update(ctx context.Context, merchant *entity.Merchant) error {
+	query := `
+        UPDATE merchants
+        SET name = $2, status = $3, payment_methods = $4, settlement_schedule = $5, settlement_delay_days = $6, updated_at = $7
+        WHERE id = $1
+    `
+
+	merchant.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		merchant.ID,
+		merchant.Name,
+		merchant.Status,
+		pq.Array(merchant.PaymentMethods),
+		merchant.Settlement.Schedule,
+		merchant.Settlement.DelayDays,
+		merchant.UpdatedAt,
+	)
+	return err
+}
+
+func scanMerchant(row scanner) (*entity.Merchant, error) {
+	merchant := &entity.Merchant{}
+	err := row.Scan(
+		&merchant.ID,
+		&merchant.Name,
+		&merchant.Status,
+		pq.Array(&merchant.PaymentMethods),
+		&merchant.Settlement.Schedule,
+		&merchant.Settlement.DelayDays,
+		&merchant.CreatedAt,
+		&merchant.UpdatedAt,
+	)
+	return merchant, err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-payments-api/pkg/metrics"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// timedExecutor records the duration of the queries run through exec by
+// their operation, such as SELECT or INSERT. QueryContext is timed until the
+// first rows are returned, not until they are read.
+type timedExecutor struct {
+	exec executor
+}
+
+func timed(exec executor) executor {
+	return &timedExecutor{exec: exec}
+}
+
+func (t *timedExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := t.exec.ExecContext(ctx, query, args...)
+	recordQuery(ctx, query, start, err)
+	return result, err
+}
+
+func (t *timedExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := t.exec.QueryContext(ctx, query, args...)
+	recordQuery(ctx, query, start, err)
+	return rows, err
+}
+
+func (t *timedExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := t.exec.QueryRowContext(ctx, query, args...)
+	recordQuery(ctx, query, start, row.Err())
+	return row
+}
+
+func recordQuery(ctx context.Context, query string, start time.Time, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", "postgresql"),
+		attribute.String("db.operation.name", operation(query)),
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		attrs = append(attrs, attribute.String("error.type", errorType(err)))
+	}
+
+	metrics.RecordDuration(ctx, metrics.DBClientDuration, start, attrs...)
+}
+
+// operation is the first keyword of query, upper cased.
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// errorType is the SQLSTATE of err, such as 23505 for unique violations, or
+// _OTHER when it didn't come from the server.
+func errorType(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return "_OTHER"
+}
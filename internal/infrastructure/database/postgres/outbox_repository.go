@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+type outboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Enqueue(ctx context.Context, message *entity.OutboxMessage) error {
+	query := `
+        INSERT INTO outbox (topic, message_key, merchant_id, payload, headers, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+
+	message.CreatedAt = time.Now()
+	message.NextAttemptAt = message.CreatedAt
+
+	headers, err := json.Marshal(message.Headers)
+	if err != nil {
+		return err
+	}
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		message.Topic,
+		message.Key,
+		message.MerchantID,
+		message.Payload,
+		headers,
+		message.NextAttemptAt,
+		message.CreatedAt,
+	).Scan(&message.ID)
+}
+
+// FetchPending locks the returned rows when called inside a transaction, and
+// skips rows locked by another relay so several instances can drain the
+// outbox concurrently.
+func (r *outboxRepository) FetchPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	query := `
+        SELECT o.id, o.topic, o.message_key, o.merchant_id, o.payload, o.headers, o.attempts, o.last_error, o.next_attempt_at, o.created_at
+        FROM outbox o
+        WHERE o.published_at IS NULL
+          AND o.next_attempt_at <= $1
+          AND NOT EXISTS (
+              SELECT 1 FROM outbox p
+              WHERE p.published_at IS NULL
+                AND p.topic = o.topic
+                AND p.message_key = o.message_key
+                AND p.id < o.id
+          )
+        ORDER BY o.id
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    `
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*entity.OutboxMessage
+	for rows.Next() {
+		var (
+			message = &entity.OutboxMessage{}
+			headers []byte
+		)
+		if err := rows.Scan(
+			&message.ID,
+			&message.Topic,
+			&message.Key,
+			&message.MerchantID,
+			&message.Payload,
+			&headers,
+			&message.Attempts,
+			&message.LastError,
+			&message.NextAttemptAt,
+			&message.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(headers, &message.Headers); err != nil {
+			return nil, fmt.Errorf("invalid headers stored for outbox message %d: %w", message.ID, err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE outbox SET published_at = $1 WHERE id = $2", time.Now(), id)
+	return err
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, message *entity.OutboxMessage) error {
+	query := `
+        UPDATE outbox
+        SET attempts = $1, last_error = $2, next_attempt_at = $3
+        WHERE id = $4
+    `
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		message.Attempts,
+		message.LastError,
+		message.NextAttemptAt,
+		message.ID,
+	)
+	return err
+}
+
+func (r *outboxRepository) Stats(ctx context.Context) (int64, time.Time, error) {
+	query := `
+        SELECT COUNT(*), COALESCE(MIN(created_at), NOW())
+        FROM outbox
+        WHERE published_at IS NULL
+    `
+
+	var (
+		pending int64
+		oldest  time.Time
+	)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(&pending, &oldest)
+
+	return pending, oldest, err
+}
@@ -3,58 +3,124 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"go-payments-api/internal/application/gateway/repository"
 	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq"
 )
 
+const paymentColumns = "id, amount, currency, method, status, created_at, updated_at, provider_reference, merchant_id"
+
 type paymentRepository struct {
 	db *sql.DB
 }
 
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPayment reads a row selected with paymentColumns. The amount is read
+// as its decimal text so no precision is lost on the way to money.Money.
+func scanPayment(row scanner) (*entity.Payment, error) {
+	var (
+		payment    = &entity.Payment{}
+		amount     string
+		currency   string
+		merchantID sql.NullInt64
+	)
+
+	if err := row.Scan(
+		&payment.ID,
+		&amount,
+		&currency,
+		&payment.Method,
+		&payment.Status,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+		&payment.ProviderReference,
+		&merchantID,
+	); err != nil {
+		return nil, err
+	}
+	payment.MerchantID = merchantID.Int64
+
+	var err error
+	payment.Amount, err = money.Parse(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount stored for payment %d: %w", payment.ID, err)
+	}
+
+	return payment, nil
+}
+
 func NewPaymentRepository(db *sql.DB) repository.PaymentRepository {
 	return &paymentRepository{db: db}
 }
 
 func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
 	query := `
-        INSERT INTO payments (amount, method, status, created_at)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO payments (amount, currency, method, status, created_at, updated_at, merchant_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING id
     `
 
 	payment.CreatedAt = time.Now()
+	payment.UpdatedAt = payment.CreatedAt
 	payment.Status = entity.StatusCreated
 
-	err := r.db.QueryRowContext(
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
 		query,
-		payment.Amount,
+		payment.Amount.Decimal(),
+		payment.Amount.Currency,
 		payment.Method,
 		payment.Status,
 		payment.CreatedAt,
+		payment.UpdatedAt,
+		sql.NullInt64{Int64: payment.MerchantID, Valid: payment.MerchantID != 0},
 	).Scan(&payment.ID)
 
 	return err
 }
 
 func (r *paymentRepository) FindByID(ctx context.Context, id int64) (*entity.Payment, error) {
+	return r.findByID(ctx, id, "")
+}
+
+func (r *paymentRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Payment, error) {
+	return r.findByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *paymentRepository) FindByProviderReference(ctx context.Context, reference string) (*entity.Payment, error) {
 	query := `
-        SELECT id, amount, method, status, created_at
+        SELECT ` + paymentColumns + `
         FROM payments
-        WHERE id = $1
+        WHERE provider_reference = $1
+        FOR UPDATE
     `
 
-	payment := &entity.Payment{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&payment.ID,
-		&payment.Amount,
-		&payment.Method,
-		&payment.Status,
-		&payment.CreatedAt,
-	)
+	payment, err := scanPayment(conn(ctx, r.db).QueryRowContext(ctx, query, reference))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return payment, err
+}
+
+func (r *paymentRepository) findByID(ctx context.Context, id int64, lock string) (*entity.Payment, error) {
+	query := `
+        SELECT ` + paymentColumns + `
+        FROM payments
+        WHERE id = $1
+    ` + lock
+
+	payment, err := scanPayment(conn(ctx, r.db).QueryRowContext(ctx, query, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -62,3 +128,118 @@ func (r *paymentRepository) FindByID(ctx context.Context, id int64) (*entity.Pay
 
 	return payment, err
 }
+
+func (r *paymentRepository) List(ctx context.Context, filter repository.PaymentFilter) ([]*entity.Payment, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	// where appends a condition replacing each ? placeholder by the next
+	// positional parameter
+	where := func(condition string, values ...interface{}) {
+		for _, value := range values {
+			args = append(args, value)
+			condition = strings.Replace(condition, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if filter.MerchantID != 0 {
+		where("merchant_id = ?", filter.MerchantID)
+	}
+	if filter.Status != "" {
+		where("status = ?", filter.Status)
+	}
+	if filter.Method != "" {
+		where("method = ?", filter.Method)
+	}
+	if filter.Currency != "" {
+		where("currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		where("amount >= ?", filter.MinAmount.Decimal())
+	}
+	if filter.MaxAmount != nil {
+		where("amount <= ?", filter.MaxAmount.Decimal())
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		where("created_at < ?", filter.CreatedTo)
+	}
+	if filter.After != nil {
+		where("(created_at, id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	query := `
+        SELECT ` + paymentColumns + `
+        FROM payments
+    `
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf("ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*entity.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+func (r *paymentRepository) UpdateStatus(ctx context.Context, payment *entity.Payment, from entity.PaymentStatus) error {
+	payment.UpdatedAt = time.Now()
+
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx executor) error {
+		result, err := tx.ExecContext(ctx, `
+            UPDATE payments
+            SET status = $1, updated_at = $2, provider_reference = $3
+            WHERE id = $4 AND status = $5
+        `, payment.Status, payment.UpdatedAt, payment.ProviderReference, payment.ID, from)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return repository.ErrStaleStatus
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO payment_status_history (payment_id, from_status, to_status, created_at)
+            VALUES ($1, $2, $3, $4)
+        `, payment.ID, from, payment.Status, payment.UpdatedAt)
+
+		return err
+	})
+}
+
+func (r *paymentRepository) SetProviderReference(ctx context.Context, payment *entity.Payment) error {
+	payment.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+        UPDATE payments
+        SET provider_reference = $1, updated_at = $2
+        WHERE id = $3
+    `, payment.ProviderReference, payment.UpdatedAt, payment.ID)
+
+	return err
+}
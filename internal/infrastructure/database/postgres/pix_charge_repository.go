@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+const pixChargeColumns = "c.payment_id, c.txid, c.payload, c.expires_at, c.created_at"
+
+type pixChargeRepository struct {
+	db *sql.DB
+}
+
+func NewPixChargeRepository(db *sql.DB) repository.PixChargeRepository {
+	return &pixChargeRepository{db: db}
+}
+
+func scanPixCharge(row scanner) (*entity.PixCharge, error) {
+	charge := &entity.PixCharge{}
+	err := row.Scan(
+		&charge.PaymentID,
+		&charge.TxID,
+		&charge.Payload,
+		&charge.ExpiresAt,
+		&charge.CreatedAt,
+	)
+	return charge, err
+}
+
+func (r *pixChargeRepository) Create(ctx context.Context, charge *entity.PixCharge) error {
+	query := `
+        INSERT INTO pix_charges (payment_id, txid, payload, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+
+	charge.CreatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		charge.PaymentID,
+		charge.TxID,
+		charge.Payload,
+		charge.ExpiresAt,
+		charge.CreatedAt,
+	)
+	return err
+}
+
+func (r *pixChargeRepository) FindByPayment(ctx context.Context, paymentID int64) (*entity.PixCharge, error) {
+	query := `
+        SELECT ` + pixChargeColumns + `
+        FROM pix_charges c
+        WHERE c.payment_id = $1
+    `
+
+	charge, err := scanPixCharge(conn(ctx, r.db).QueryRowContext(ctx, query, paymentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return charge, err
+}
+
+func (r *pixChargeRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.PixCharge, error) {
+	query := `
+        SELECT ` + pixChargeColumns + `
+        FROM pix_charges c
+        JOIN payments p ON p.id = c.payment_id
+        WHERE c.expires_at <= $1 AND p.status IN ($2, $3)
+        ORDER BY c.expires_at
+        LIMIT $4
+    `
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now, entity.StatusCreated, entity.StatusProcessing, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var charges []*entity.PixCharge
+	for rows.Next() {
+		charge, err := scanPixCharge(rows)
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, charge)
+	}
+
+	return charges, rows.Err()
+}
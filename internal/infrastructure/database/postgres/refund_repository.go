@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"time"
+)
+
+const refundColumns = "id, payment_id, amount, currency, status, reason, created_at, updated_at"
+
+type refundRepository struct {
+	db *sql.DB
+}
+
+func NewRefundRepository(db *sql.DB) repository.RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func scanRefund(row scanner) (*entity.Refund, error) {
+	var (
+		refund   = &entity.Refund{}
+		amount   string
+		currency string
+	)
+
+	if err := row.Scan(
+		&refund.ID,
+		&refund.PaymentID,
+		&amount,
+		&currency,
+		&refund.Status,
+		&refund.Reason,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	refund.Amount, err = money.Parse(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount stored for refund %d: %w", refund.ID, err)
+	}
+
+	return refund, nil
+}
+
+func (r *refundRepository) Create(ctx context.Context, refund *entity.Refund) error {
+	query := `
+        INSERT INTO refunds (payment_id, amount, currency, status, reason, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+
+	refund.CreatedAt = time.Now()
+	refund.UpdatedAt = refund.CreatedAt
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		refund.PaymentID,
+		refund.Amount.Decimal(),
+		refund.Amount.Currency,
+		refund.Status,
+		refund.Reason,
+		refund.CreatedAt,
+		refund.UpdatedAt,
+	).Scan(&refund.ID)
+}
+
+func (r *refundRepository) UpdateStatus(ctx context.Context, refund *entity.Refund) error {
+	refund.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"UPDATE refunds SET status = $1, updated_at = $2 WHERE id = $3",
+		refund.Status,
+		refund.UpdatedAt,
+		refund.ID,
+	)
+	return err
+}
+
+func (r *refundRepository) ListByPayment(ctx context.Context, paymentID int64) ([]*entity.Refund, error) {
+	query := `
+        SELECT ` + refundColumns + `
+        FROM refunds
+        WHERE payment_id = $1
+        ORDER BY id
+    `
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refunds []*entity.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, rows.Err()
+}
+
+func (r *refundRepository) TotalByPayment(ctx context.Context, paymentID int64, currency money.Currency) (money.Money, error) {
+	query := `
+        SELECT COALESCE(SUM(amount), 0)
+        FROM refunds
+        WHERE payment_id = $1 AND currency = $2 AND status <> $3
+    `
+
+	var total string
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, paymentID, currency, entity.RefundStatusFailed).Scan(&total); err != nil {
+		return money.Money{}, err
+	}
+
+	return money.Parse(total, string(currency))
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+)
+
+type txKey struct{}
+
+// executor is the subset of *sql.DB and *sql.Tx used by repositories, so
+// they run the same queries inside or outside a transaction.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) repository.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, t.db, func(ctx context.Context, _ executor) error {
+		return fn(ctx)
+	})
+}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return timed(tx)
+	}
+	return timed(db)
+}
+
+// withinTransaction joins the transaction carried by ctx or starts a new one
+// that is committed when fn succeeds.
+func withinTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context, exec executor) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx, timed(tx))
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx), timed(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"strings"
+	"time"
+)
+
+const webhookDeliveryColumns = "id, subscription_id, merchant_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at"
+
+type webhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+func scanWebhookDelivery(row scanner, extra ...interface{}) (*entity.WebhookDelivery, error) {
+	delivery := &entity.WebhookDelivery{}
+
+	dest := append([]interface{}{
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.MerchantID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastError,
+		&delivery.ResponseStatus,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func NewWebhookDeliveryRepository(db *sql.DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Schedule(ctx context.Context, merchantID int64, eventType string, payload []byte) (int64, error) {
+	query := `
+        INSERT INTO webhook_deliveries (subscription_id, merchant_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+        SELECT id, merchant_id, $1, $2, $3, $4, $4, $4
+        FROM webhook_subscriptions
+        WHERE active AND merchant_id = $5 AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+    `
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, eventType, payload, entity.WebhookDeliveryPending, time.Now(), merchantID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// ClaimDue claims the deliveries in a single statement, skipping rows locked
+// by another dispatcher claiming at the same time.
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now, until time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+        WITH due AS (
+            SELECT id
+            FROM webhook_deliveries
+            WHERE status = $1 AND next_attempt_at <= $2
+            ORDER BY next_attempt_at, id
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = $4
+        FROM due, webhook_subscriptions s
+        WHERE d.id = due.id AND s.id = d.subscription_id
+        RETURNING d.` + strings.ReplaceAll(webhookDeliveryColumns, ", ", ", d.") + `, s.url, s.secret
+    `
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, entity.WebhookDeliveryPending, now, limit, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		subscription := &entity.WebhookSubscription{Active: true}
+
+		delivery, err := scanWebhookDelivery(rows, &subscription.URL, &subscription.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		subscription.ID = delivery.SubscriptionID
+		subscription.MerchantID = delivery.MerchantID
+		delivery.Subscription = subscription
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *webhookDeliveryRepository) Find(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE id = $1
+    `
+
+	delivery, err := scanWebhookDelivery(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return delivery, err
+}
+
+func (r *webhookDeliveryRepository) List(ctx context.Context, filter repository.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	where := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.MerchantID != 0 {
+		where("merchant_id = $%d", filter.MerchantID)
+	}
+	if filter.SubscriptionID != 0 {
+		where("subscription_id = $%d", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		where("status = $%d", filter.Status)
+	}
+	if filter.BeforeID != 0 {
+		where("id < $%d", filter.BeforeID)
+	}
+
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+    `
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf("ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, response_status = $5, updated_at = $6
+        WHERE id = $7
+    `,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.ResponseStatus,
+		delivery.UpdatedAt,
+		delivery.ID,
+	)
+
+	return err
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+)
+
+const webhookEventColumns = "id, provider, event_id, type, payload, status, error, attempts, received_at, processed_at"
+
+type webhookEventRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookEventRepository(db *sql.DB) repository.WebhookEventRepository {
+	return &webhookEventRepository{db: db}
+}
+
+func (r *webhookEventRepository) Create(ctx context.Context, event *entity.WebhookEvent) error {
+	query := `
+        INSERT INTO webhook_events (provider, event_id, type, payload, status, error, attempts, received_at, processed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (provider, event_id) DO NOTHING
+        RETURNING id
+    `
+
+	event.ReceivedAt = time.Now()
+	event.ProcessedAt = event.ReceivedAt
+
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		event.Provider,
+		event.EventID,
+		event.Type,
+		event.Payload,
+		event.Status,
+		event.Error,
+		event.Attempts,
+		event.ReceivedAt,
+		event.ProcessedAt,
+	).Scan(&event.ID)
+
+	if err == sql.ErrNoRows {
+		return repository.ErrDuplicateWebhookEvent
+	}
+
+	return err
+}
+
+func (r *webhookEventRepository) Find(ctx context.Context, provider string, eventID string) (*entity.WebhookEvent, error) {
+	query := `
+        SELECT ` + webhookEventColumns + `
+        FROM webhook_events
+        WHERE provider = $1 AND event_id = $2
+    `
+
+	event := &entity.WebhookEvent{}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, provider, eventID).Scan(
+		&event.ID,
+		&event.Provider,
+		&event.EventID,
+		&event.Type,
+		&event.Payload,
+		&event.Status,
+		&event.Error,
+		&event.Attempts,
+		&event.ReceivedAt,
+		&event.ProcessedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return event, err
+}
+
+func (r *webhookEventRepository) Update(ctx context.Context, event *entity.WebhookEvent) error {
+	event.ProcessedAt = time.Now()
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+        UPDATE webhook_events
+        SET status = $1, error = $2, attempts = $3, processed_at = $4
+        WHERE id = $5
+    `, event.Status, event.Error, event.Attempts, event.ProcessedAt, event.ID)
+
+	return err
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type webhookSubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookSubscriptionRepository(db *sql.DB) repository.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{db: db}
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	query := `
+        INSERT INTO webhook_subscriptions (merchant_id, url, secret, event_types, active, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id
+    `
+
+	subscription.CreatedAt = time.Now()
+	if subscription.EventTypes == nil {
+		subscription.EventTypes = []string{}
+	}
+
+	return conn(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		subscription.MerchantID,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Active,
+		subscription.CreatedAt,
+	).Scan(&subscription.ID)
+}
+
+func (r *webhookSubscriptionRepository) List(ctx context.Context, merchantID int64) ([]*entity.WebhookSubscription, error) {
+	query := `
+        SELECT id, merchant_id, url, secret, event_types, active, created_at
+        FROM webhook_subscriptions
+        WHERE $1 = 0 OR merchant_id = $1
+        ORDER BY id
+    `
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entity.WebhookSubscription
+	for rows.Next() {
+		subscription := &entity.WebhookSubscription{}
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.MerchantID,
+			&subscription.URL,
+			&subscription.Secret,
+			pq.Array(&subscription.EventTypes),
+			&subscription.Active,
+			&subscription.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
@@ -0,0 +1,185 @@
+// Package jwt verifies bearer tokens signed by an identity provider, with
+// keys fetched from its JWKS endpoint or configured statically.
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/pkg/log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Config struct {
+	// JWKSURLs are fetched on start and every RefreshInterval, and again
+	// when a token carries an unknown kid
+	JWKSURLs        []string
+	RefreshInterval time.Duration
+
+	// StaticJWKS is a JWK Set JSON, for keys that don't come from a JWKS
+	// endpoint. It may hold "oct" keys for HMAC algorithms.
+	StaticJWKS string
+
+	// Algorithms accepted in the alg header, e.g. RS256 or ES256
+	Algorithms []string
+	Issuer     string
+	Audience   string
+	Leeway     time.Duration
+
+	// MerchantClaim names the claim holding the merchant ID, as a number or
+	// a numeric string
+	MerchantClaim string
+}
+
+type Verifier struct {
+	keys   []keyfunc.Keyfunc
+	parser *jwt.Parser
+	config Config
+}
+
+// NewVerifier returns a Verifier for the keys of config. JWKS endpoints are
+// refreshed in the background until ctx is done.
+func NewVerifier(ctx context.Context, config Config) (*Verifier, error) {
+	var keys []keyfunc.Keyfunc
+
+	if config.StaticJWKS != "" {
+		static, err := keyfunc.NewJWKSetJSON(json.RawMessage(config.StaticJWKS))
+		if err != nil {
+			return nil, fmt.Errorf("invalid static JWKS: %w", err)
+		}
+		keys = append(keys, static)
+	}
+
+	if len(config.JWKSURLs) > 0 {
+		remote, err := keyfunc.NewDefaultOverrideCtx(ctx, config.JWKSURLs, keyfunc.Override{
+			RefreshInterval: config.RefreshInterval,
+			// Tokens with unknown kids fail right away instead of waiting
+			// for the rate limited refresh, so forged ones can't pile up
+			RateLimitWaitMax: time.Millisecond,
+			RefreshErrorHandlerFunc: func(url string) func(ctx context.Context, err error) {
+				return func(ctx context.Context, err error) {
+					log.Logger.Errorf("failed to refresh JWKS from %s: %v", url, err)
+				}
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up JWKS: %w", err)
+		}
+		keys = append(keys, remote)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no JWKS URL or static JWKS configured")
+	}
+	if len(config.Algorithms) == 0 {
+		return nil, errors.New("no JWT algorithms configured")
+	}
+	if config.MerchantClaim == "" {
+		config.MerchantClaim = "merchant_id"
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(config.Algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &Verifier{
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+		config: config,
+	}, nil
+}
+
+// Verify checks the signature and registered claims of token and returns
+// the principal of its subject. Tokens without a merchant are rejected.
+func (v *Verifier) Verify(ctx context.Context, token string) (auth.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyFor(ctx)); err != nil {
+		return auth.Principal{}, err
+	}
+
+	merchantID, err := v.merchantID(claims)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	subject, _ := claims.GetSubject()
+
+	return auth.Principal{
+		Subject:    subject,
+		MerchantID: merchantID,
+		Method:     auth.MethodJWT,
+		Scopes:     scopes(claims),
+	}, nil
+}
+
+// keyFor looks the key up in the static keys first and then in the JWKS
+// endpoints.
+func (v *Verifier) keyFor(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		var errs []error
+		for _, keys := range v.keys {
+			key, err := keys.KeyfuncCtx(ctx)(token)
+			if err == nil {
+				return key, nil
+			}
+			errs = append(errs, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+}
+
+func (v *Verifier) merchantID(claims jwt.MapClaims) (int64, error) {
+	var (
+		id  int64
+		err error
+	)
+
+	switch value := claims[v.config.MerchantClaim].(type) {
+	case float64:
+		id = int64(value)
+		if float64(id) != value {
+			err = fmt.Errorf("%s is not an integer", v.config.MerchantClaim)
+		}
+	case string:
+		id, err = strconv.ParseInt(value, 10, 64)
+	default:
+		err = fmt.Errorf("missing %s claim", v.config.MerchantClaim)
+	}
+
+	if err == nil && id <= 0 {
+		err = fmt.Errorf("invalid %s claim", v.config.MerchantClaim)
+	}
+	return id, err
+}
+
+// scopes reads the OAuth scope claim, a space separated string, or the scp
+// array some providers send instead.
+func scopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+
+	values, _ := claims["scp"].([]interface{})
+	scopes := make([]string, 0, len(values))
+	for _, value := range values {
+		if scope, ok := value.(string); ok {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
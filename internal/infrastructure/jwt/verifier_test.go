@@ -0,0 +1,159 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func staticJWKS() string {
+	return fmt.Sprintf(`{"keys":[{"kty":"oct","kid":"static","alg":"HS256","k":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(hmacSecret))
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":         "user-1",
+		"iss":         "https://auth.example.com",
+		"aud":         "payments",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"merchant_id": "7",
+		"scope":       "payments:read payments:write",
+	}
+}
+
+func newStaticVerifier(t *testing.T) *Verifier {
+	verifier, err := NewVerifier(context.Background(), Config{
+		StaticJWKS: staticJWKS(),
+		Algorithms: []string{"HS256", "RS256"},
+		Issuer:     "https://auth.example.com",
+		Audience:   "payments",
+	})
+	assert.NoError(t, err)
+	return verifier
+}
+
+func TestVerifyStaticKey(t *testing.T) {
+	verifier := newStaticVerifier(t)
+
+	principal, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, "static", hmacSecret, validClaims()))
+	assert.NoError(t, err)
+	assert.Equal(t, auth.Principal{
+		Subject:    "user-1",
+		MerchantID: 7,
+		Method:     auth.MethodJWT,
+		Scopes:     []string{"payments:read", "payments:write"},
+	}, principal)
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	verifier := newStaticVerifier(t)
+
+	claims := func(change func(jwt.MapClaims)) jwt.MapClaims {
+		c := validClaims()
+		change(c)
+		return c
+	}
+
+	cases := map[string]string{
+		"wrong secret":   sign(t, jwt.SigningMethodHS256, "static", []byte("another secret of the same size!"), validClaims()),
+		"unknown kid":    sign(t, jwt.SigningMethodHS256, "other", hmacSecret, validClaims()),
+		"disallowed alg": sign(t, jwt.SigningMethodHS512, "static", hmacSecret, validClaims()),
+		"expired":        sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+		"no expiration":  sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { delete(c, "exp") })),
+		"wrong issuer":   sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" })),
+		"wrong audience": sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { c["aud"] = "billing" })),
+		"no merchant":    sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { delete(c, "merchant_id") })),
+		"bad merchant":   sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { c["merchant_id"] = "abc" })),
+		"zero merchant":  sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims(func(c jwt.MapClaims) { c["merchant_id"] = 0 })),
+		"garbage":        "not.a.token",
+	}
+
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifyJWKSURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "remote",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier, err := NewVerifier(ctx, Config{
+		JWKSURLs:      []string{server.URL},
+		StaticJWKS:    staticJWKS(),
+		Algorithms:    []string{"RS256", "HS256"},
+		MerchantClaim: "tenant",
+	})
+	assert.NoError(t, err)
+
+	claims := jwt.MapClaims{
+		"sub":    "service-a",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"tenant": 42,
+		"scp":    []string{"payments:read"},
+	}
+
+	principal, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "remote", key, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), principal.MerchantID)
+	assert.Equal(t, []string{"payments:read"}, principal.Scopes)
+
+	// static keys keep working next to the JWKS endpoint
+	_, err = verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, "static", hmacSecret, claims))
+	assert.NoError(t, err)
+}
+
+func TestNewVerifierInvalid(t *testing.T) {
+	_, err := NewVerifier(context.Background(), Config{Algorithms: []string{"RS256"}})
+	assert.Error(t, err)
+
+	_, err = NewVerifier(context.Background(), Config{StaticJWKS: "{", Algorithms: []string{"RS256"}})
+	assert.Error(t, err)
+
+	_, err = NewVerifier(context.Background(), Config{StaticJWKS: staticJWKS()})
+	assert.Error(t, err)
+}
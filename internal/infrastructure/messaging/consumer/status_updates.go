@@ -0,0 +1,50 @@
+// Package consumer adapts Kafka messages to the use cases they drive.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	appErr "go-payments-api/pkg/errors"
+)
+
+// HeaderProvider names the provider that sent a status update.
+const HeaderProvider = "provider"
+
+// StatusUpdates applies the provider notifications published to the status
+// updates topic. Messages without a provider header are attributed to the
+// default provider.
+type StatusUpdates struct {
+	useCase         usecase.ApplyStatusUpdate
+	defaultProvider string
+}
+
+func NewStatusUpdates(useCase usecase.ApplyStatusUpdate, defaultProvider string) *StatusUpdates {
+	return &StatusUpdates{
+		useCase:         useCase,
+		defaultProvider: defaultProvider,
+	}
+}
+
+// Handle is a kafka.Handler. Malformed notifications are permanent failures
+// while any other error is retried.
+func (s *StatusUpdates) Handle(ctx context.Context, message kafka.Message) error {
+	provider := message.Headers[HeaderProvider]
+	if provider == "" {
+		provider = s.defaultProvider
+	}
+
+	_, err := s.useCase.Execute(ctx, dto.StatusUpdateInput{
+		Provider: provider,
+		Body:     message.Value,
+	})
+
+	var badFormat appErr.BadFormat
+	if errors.As(err, &badFormat) {
+		return kafka.Permanent(err)
+	}
+
+	return err
+}
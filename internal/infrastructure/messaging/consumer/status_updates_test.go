@@ -0,0 +1,49 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/pkg/base"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStatusUpdatesHandle(t *testing.T) {
+	cases := map[string]struct {
+		headers   map[string]string
+		provider  string
+		err       error
+		permanent bool
+	}{
+		"provider header":  {headers: map[string]string{HeaderProvider: "acme"}, provider: "acme"},
+		"default provider": {provider: "simulator"},
+		"transient error":  {provider: "simulator", err: errors.New("database unavailable")},
+		"malformed":        {provider: "simulator", err: appErr.NewBadFormat("status update must be a notification with an id"), permanent: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			body := []byte(`{"id":"evt_1","type":"payment.status","reference":"ref-1","status":"CAPTURED"}`)
+			useCase := base.NewMockUseCase[dto.StatusUpdateInput, *dto.WebhookOutput](ctrl)
+			useCase.EXPECT().Execute(gomock.Any(), dto.StatusUpdateInput{Provider: tc.provider, Body: body}).
+				Return(&dto.WebhookOutput{}, tc.err)
+
+			err := NewStatusUpdates(useCase, "simulator").Handle(context.Background(), kafka.Message{
+				Headers: tc.headers,
+				Value:   body,
+			})
+
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.permanent, kafka.IsPermanent(err))
+		})
+	}
+}
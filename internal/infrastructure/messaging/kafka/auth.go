@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+type AuthConfig struct {
+	// SASLMechanism is empty for no SASL, or plain, scram-sha-256 or
+	// scram-sha-512
+	SASLMechanism string
+	Username      string
+	Password      string
+
+	TLS bool
+	// CAFile is a PEM bundle trusted on top of the system roots
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
+// Auth is how producers, consumers and topic provisioning connect to the
+// brokers. The zero value connects over plaintext without SASL.
+type Auth struct {
+	SASL sasl.Mechanism
+	TLS  *tls.Config
+}
+
+func NewAuth(config AuthConfig) (Auth, error) {
+	var auth Auth
+
+	switch mechanism := strings.ToLower(config.SASLMechanism); mechanism {
+	case "":
+	case "plain":
+		auth.SASL = plain.Mechanism{Username: config.Username, Password: config.Password}
+	case "scram-sha-256", "scram-sha-512":
+		algorithm := scram.SHA256
+		if mechanism == "scram-sha-512" {
+			algorithm = scram.SHA512
+		}
+		scramMechanism, err := scram.Mechanism(algorithm, config.Username, config.Password)
+		if err != nil {
+			return Auth{}, fmt.Errorf("failed to set up %s: %w", mechanism, err)
+		}
+		auth.SASL = scramMechanism
+	default:
+		return Auth{}, fmt.Errorf("unknown SASL mechanism %q", config.SASLMechanism)
+	}
+
+	if !config.TLS {
+		return auth, nil
+	}
+
+	auth.TLS = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return Auth{}, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return Auth{}, fmt.Errorf("no certificates found in %s", config.CAFile)
+		}
+		auth.TLS.RootCAs = roots
+	}
+
+	return auth, nil
+}
+
+func (a Auth) transport() *kafka.Transport {
+	return &kafka.Transport{SASL: a.SASL, TLS: a.TLS}
+}
+
+func (a Auth) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		SASLMechanism: a.SASL,
+		TLS:           a.TLS,
+	}
+}
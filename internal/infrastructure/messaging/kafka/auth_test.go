@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuth(t *testing.T) {
+	auth, err := NewAuth(AuthConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, auth.SASL)
+	assert.Nil(t, auth.TLS)
+
+	auth, err = NewAuth(AuthConfig{SASLMechanism: "PLAIN", Username: "user", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, plain.Mechanism{Username: "user", Password: "secret"}, auth.SASL)
+
+	for _, mechanism := range []string{"scram-sha-256", "scram-sha-512"} {
+		auth, err = NewAuth(AuthConfig{SASLMechanism: mechanism, Username: "user", Password: "secret", TLS: true})
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToUpper(mechanism), auth.SASL.Name())
+		assert.NotNil(t, auth.TLS)
+	}
+
+	_, err = NewAuth(AuthConfig{SASLMechanism: "gssapi"})
+	assert.Error(t, err)
+}
+
+func TestNewAuthCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0o600))
+
+	auth, err := NewAuth(AuthConfig{TLS: true, CAFile: caFile})
+	assert.NoError(t, err)
+	assert.NotNil(t, auth.TLS.RootCAs)
+
+	invalid := filepath.Join(dir, "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+
+	_, err = NewAuth(AuthConfig{TLS: true, CAFile: invalid})
+	assert.Error(t, err)
+
+	_, err = NewAuth(AuthConfig{TLS: true, CAFile: filepath.Join(dir, "missing.pem")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package kafka
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// avroConverter turns a decoded JSON value into the native Go value goavro
+// encodes for a schema: numbers get their Avro width, RFC 3339 strings
+// become times for timestamp types, base64 strings become bytes, and union
+// values are wrapped in their branch name.
+type avroConverter struct {
+	named     map[string]map[string]interface{}
+	fullNames map[string]string
+}
+
+func newAvroConverter(schema interface{}) *avroConverter {
+	converter := &avroConverter{
+		named:     map[string]map[string]interface{}{},
+		fullNames: map[string]string{},
+	}
+	converter.collect(schema, "")
+	return converter
+}
+
+func (c *avroConverter) collect(schema interface{}, namespace string) {
+	switch schema := schema.(type) {
+	case []interface{}:
+		for _, branch := range schema {
+			c.collect(branch, namespace)
+		}
+	case map[string]interface{}:
+		if name, ok := schema["name"].(string); ok {
+			fullName := avroFullName(schema, namespace)
+			c.named[fullName] = schema
+			c.named[name] = schema
+			c.fullNames[name] = fullName
+			c.fullNames[fullName] = fullName
+			if i := strings.LastIndex(fullName, "."); i >= 0 {
+				namespace = fullName[:i]
+			}
+		}
+		for _, key := range []string{"type", "items", "values"} {
+			c.collect(schema[key], namespace)
+		}
+		if fields, ok := schema["fields"].([]interface{}); ok {
+			for _, field := range fields {
+				if field, ok := field.(map[string]interface{}); ok {
+					c.collect(field["type"], namespace)
+				}
+			}
+		}
+	}
+}
+
+func (c *avroConverter) convert(schema interface{}, value interface{}) (interface{}, error) {
+	switch schema := schema.(type) {
+	case string:
+		if definition, ok := c.named[schema]; ok {
+			return c.convert(definition, value)
+		}
+		return convertAvroPrimitive(schema, "", value)
+	case []interface{}:
+		return c.convertUnion(schema, value)
+	case map[string]interface{}:
+		return c.convertComplex(schema, value)
+	}
+	return nil, fmt.Errorf("unsupported avro schema %v", schema)
+}
+
+func (c *avroConverter) convertComplex(schema map[string]interface{}, value interface{}) (interface{}, error) {
+	kind, ok := schema["type"].(string)
+	if !ok {
+		return c.convert(schema["type"], value)
+	}
+
+	switch kind {
+	case "record":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not a %s record", value, schema["name"])
+		}
+		record := map[string]interface{}{}
+		fields, _ := schema["fields"].([]interface{})
+		for _, field := range fields {
+			field, _ := field.(map[string]interface{})
+			name, _ := field["name"].(string)
+			// Missing fields fall back to the schema default when encoding
+			if object[name] == nil {
+				continue
+			}
+			converted, err := c.convert(field["type"], object[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			record[name] = converted
+		}
+		return record, nil
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not an array", value)
+		}
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			converted, err := c.convert(schema["items"], item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return items, nil
+	case "map":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not a map", value)
+		}
+		values := make(map[string]interface{}, len(object))
+		for key, item := range object {
+			converted, err := c.convert(schema["values"], item)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = converted
+		}
+		return values, nil
+	case "enum":
+		symbol, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a %s symbol", value, schema["name"])
+		}
+		return symbol, nil
+	case "fixed":
+		return convertAvroPrimitive("bytes", "", value)
+	}
+
+	logicalType, _ := schema["logicalType"].(string)
+	return convertAvroPrimitive(kind, logicalType, value)
+}
+
+// convertUnion picks the first branch value converts to.
+func (c *avroConverter) convertUnion(branches []interface{}, value interface{}) (interface{}, error) {
+	for _, branch := range branches {
+		name := c.branchName(branch)
+		if name == "null" {
+			if value == nil {
+				return nil, nil
+			}
+			continue
+		}
+
+		converted, err := c.convert(branch, value)
+		if err == nil {
+			return map[string]interface{}{name: converted}, nil
+		}
+	}
+	return nil, fmt.Errorf("%v matches no branch of union %v", value, branches)
+}
+
+// branchName is the name goavro identifies a union branch by.
+func (c *avroConverter) branchName(branch interface{}) string {
+	switch branch := branch.(type) {
+	case string:
+		if fullName, ok := c.fullNames[branch]; ok {
+			return fullName
+		}
+		return branch
+	case map[string]interface{}:
+		if name, ok := branch["name"].(string); ok {
+			return c.fullNames[name]
+		}
+		kind, _ := branch["type"].(string)
+		if logicalType, ok := branch["logicalType"].(string); ok {
+			return kind + "." + logicalType
+		}
+		return kind
+	}
+	return ""
+}
+
+func convertAvroPrimitive(kind, logicalType string, value interface{}) (interface{}, error) {
+	if strings.HasPrefix(logicalType, "timestamp-") || logicalType == "date" {
+		if text, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, text)
+		}
+	}
+
+	switch kind {
+	case "null":
+		if value != nil {
+			return nil, fmt.Errorf("%v is not null", value)
+		}
+		return nil, nil
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case "int", "long":
+		if number, ok := value.(json.Number); ok {
+			n, err := number.Int64()
+			if err != nil {
+				return nil, err
+			}
+			if kind == "int" {
+				return int32(n), nil
+			}
+			return n, nil
+		}
+	case "float", "double":
+		if number, ok := value.(json.Number); ok {
+			n, err := number.Float64()
+			if err != nil {
+				return nil, err
+			}
+			if kind == "float" {
+				return float32(n), nil
+			}
+			return n, nil
+		}
+	case "string":
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+	case "bytes":
+		// encoding/json writes []byte as base64
+		if text, ok := value.(string); ok {
+			return base64.StdEncoding.DecodeString(text)
+		}
+	}
+
+	return nil, fmt.Errorf("%v is not a valid %s", value, kind)
+}
+
+func avroFullName(schema map[string]interface{}, namespace string) string {
+	name, _ := schema["name"].(string)
+	if strings.Contains(name, ".") {
+		return name
+	}
+	if ns, ok := schema["namespace"].(string); ok {
+		namespace = ns
+	}
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
@@ -0,0 +1,296 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"go-payments-api/pkg/log"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/redact"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const traceParentHeader = "traceparent"
+
+// Message is a record consumed from a topic.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Time      time.Time
+}
+
+// Handler processes one message. Returned errors are retried unless they
+// are Permanent.
+type Handler func(ctx context.Context, message Message) error
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as one retrying won't fix, sending the message
+// straight to the dead letter topic.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// DeadLetter is published to the dead letter topic for messages the handler
+// couldn't process.
+type DeadLetter struct {
+	Topic     string            `json:"topic"`
+	Partition int               `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Key       string            `json:"key"`
+	Value     []byte            `json:"value"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Error     string            `json:"error"`
+	Attempts  int               `json:"attempts"`
+	FailedAt  time.Time         `json:"failed_at"`
+}
+
+type ConsumerConfig struct {
+	Brokers      []string
+	GroupID      string
+	Topic        string
+	DLQTopic     string
+	MaxRetries   int
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	Auth         Auth
+}
+
+// reader is the part of *kafka.Reader the consumer relies on.
+type reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+// Consumer reads a topic as part of a consumer group and hands every
+// message to a Handler. Offsets are committed only once the handler
+// succeeds or the message is parked in the dead letter topic, so a message
+// is never lost, although it may be handled more than once.
+type Consumer struct {
+	reader  reader
+	dlq     Publisher
+	handler Handler
+	config  ConsumerConfig
+}
+
+func NewConsumer(config ConsumerConfig, handler Handler, dlq Publisher) *Consumer {
+	return &Consumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     config.Brokers,
+			GroupID:     config.GroupID,
+			Topic:       config.Topic,
+			StartOffset: kafka.FirstOffset,
+			Dialer:      config.Auth.dialer(),
+		}),
+		dlq:     dlq,
+		handler: handler,
+		config:  config,
+	}
+}
+
+// Run consumes until ctx is canceled and then leaves the group. The message
+// being handled when ctx is canceled is finished first; if it is still
+// waiting for a retry it is left uncommitted to be consumed again.
+func (c *Consumer) Run(ctx context.Context) {
+	defer func() {
+		if err := c.reader.Close(); err != nil {
+			log.Logger.Errorf("failed to close consumer of %s: %v", c.config.Topic, err)
+		}
+	}()
+
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Logger.Errorf("failed to fetch message from %s: %v", c.config.Topic, err)
+			if !c.wait(ctx, c.config.RetryBackoff) {
+				return
+			}
+			continue
+		}
+
+		if !c.consume(ctx, message) {
+			return
+		}
+	}
+}
+
+// consume handles message and commits it, reporting false when ctx was
+// canceled before the message could be settled.
+func (c *Consumer) consume(ctx context.Context, message kafka.Message) bool {
+	// Handling and committing outlive ctx so shutting down doesn't abort
+	// a message halfway, and join the producer's trace when it sent one
+	handleCtx := metrics.WithTraceParent(context.WithoutCancel(ctx), headerValue(message, traceParentHeader))
+	handleCtx, span := metrics.StartSpan(handleCtx, "KafkaConsumer.Consume")
+	defer span.End()
+
+	metrics.AddSpanAttributes(handleCtx,
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination.name", message.Topic),
+		attribute.String("messaging.consumer.group.name", c.config.GroupID),
+		attribute.Int("messaging.kafka.partition", message.Partition),
+		attribute.Int64("messaging.kafka.offset", message.Offset),
+		attribute.String("messaging.kafka.message.key", string(message.Key)),
+	)
+
+	attempts, err := c.handle(ctx, handleCtx, message)
+	if err != nil {
+		if ctx.Err() != nil && !IsPermanent(err) && attempts <= c.config.MaxRetries {
+			metrics.AddSpanEvent(handleCtx, "consumer.interrupted")
+			return false
+		}
+
+		span.SetStatus(codes.Error, redact.String(err.Error()))
+		if !c.deadLetter(ctx, handleCtx, message, err, attempts) {
+			return false
+		}
+	}
+
+	if err := c.reader.CommitMessages(handleCtx, message); err != nil {
+		// The message will be consumed again, which handlers tolerate
+		log.Logger.WithContext(handleCtx).Errorf("failed to commit offset %d of %s[%d]: %v", message.Offset, message.Topic, message.Partition, err)
+	}
+
+	return true
+}
+
+// handle runs the handler until it succeeds, fails permanently or runs out
+// of retries, returning the number of attempts made and the last error.
+func (c *Consumer) handle(ctx, handleCtx context.Context, message kafka.Message) (int, error) {
+	consumed := toMessage(message)
+
+	var err error
+	attempts := 0
+	for {
+		attempts++
+		if err = c.handler(handleCtx, consumed); err == nil {
+			return attempts, nil
+		}
+
+		metrics.AddSpanEvent(handleCtx, "consumer.handler.failed",
+			attribute.Int("attempt", attempts),
+			attribute.String("error", err.Error()),
+		)
+
+		if IsPermanent(err) || attempts > c.config.MaxRetries {
+			return attempts, err
+		}
+
+		log.Logger.WithContext(handleCtx).Errorf("failed to handle offset %d of %s[%d] (attempt %d), retrying: %v",
+			message.Offset, message.Topic, message.Partition, attempts, err)
+
+		if !c.wait(ctx, c.backoff(attempts)) {
+			return attempts, err
+		}
+	}
+}
+
+// deadLetter parks message in the dead letter topic, retrying until the
+// publish succeeds or ctx is canceled.
+func (c *Consumer) deadLetter(ctx, handleCtx context.Context, message kafka.Message, cause error, attempts int) bool {
+	letter := DeadLetter{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       string(message.Key),
+		Value:     message.Value,
+		Headers:   toMessage(message).Headers,
+		Error:     cause.Error(),
+		Attempts:  attempts,
+		FailedAt:  time.Now(),
+	}
+
+	log.Logger.WithContext(handleCtx).Errorf("sending offset %d of %s[%d] to %s after %d attempts: %v",
+		message.Offset, message.Topic, message.Partition, c.config.DLQTopic, attempts, cause)
+
+	for retry := 1; ; retry++ {
+		err := c.dlq.Publish(handleCtx, c.config.DLQTopic, letter.Key, letter)
+		if err == nil {
+			metrics.AddSpanEvent(handleCtx, "consumer.dead_lettered", attribute.String("messaging.dlq", c.config.DLQTopic))
+			return true
+		}
+
+		log.Logger.WithContext(handleCtx).Errorf("failed to publish dead letter to %s: %v", c.config.DLQTopic, err)
+		if !c.wait(ctx, c.backoff(retry)) {
+			return false
+		}
+	}
+}
+
+// backoff doubles the wait after every failed attempt, up to MaxBackoff.
+func (c *Consumer) backoff(attempts int) time.Duration {
+	wait := c.config.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= c.config.MaxBackoff {
+			return c.config.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// wait sleeps for d, reporting false if ctx is canceled first.
+func (c *Consumer) wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+func headerValue(message kafka.Message, key string) string {
+	for _, header := range message.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func toMessage(message kafka.Message) Message {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+
+	return Message{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       message.Key,
+		Value:     message.Value,
+		Headers:   headers,
+		Time:      message.Time,
+	}
+}
@@ -0,0 +1,205 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-payments-api/test"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testConsumerConfig = ConsumerConfig{
+	GroupID:      "go-payments-api",
+	Topic:        "payment.status.updates",
+	DLQTopic:     "payment.status.updates.dlq",
+	MaxRetries:   2,
+	RetryBackoff: time.Millisecond,
+	MaxBackoff:   5 * time.Millisecond,
+}
+
+// fakeReader serves messages once and then blocks until ctx is canceled.
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []int64
+	closed    bool
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		message := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return message, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, messages ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, message := range messages {
+		r.committed = append(r.committed, message.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func newTestConsumer(ctrl *gomock.Controller, handler Handler, messages ...kafka.Message) (*Consumer, *fakeReader, *MockPublisher) {
+	reader := &fakeReader{messages: messages}
+	dlq := NewMockPublisher(ctrl)
+
+	return &Consumer{
+		reader:  reader,
+		dlq:     dlq,
+		handler: handler,
+		config:  testConsumerConfig,
+	}, reader, dlq
+}
+
+// runUntil runs the consumer until done reports true.
+func runUntil(t *testing.T, consumer *Consumer, done func() bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		consumer.Run(ctx)
+		close(stopped)
+	}()
+
+	assert.Eventually(t, done, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("consumer didn't stop after context cancellation")
+	}
+}
+
+func (r *fakeReader) committedOffsets() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.committed...)
+}
+
+func TestConsumerCommitsHandledMessages(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	var handled []string
+	consumer, reader, _ := newTestConsumer(ctrl, func(_ context.Context, message Message) error {
+		handled = append(handled, string(message.Value))
+		assert.Equal(t, "simulator", message.Headers["provider"])
+		return nil
+	},
+		kafka.Message{Topic: "payment.status.updates", Offset: 1, Value: []byte("a"), Headers: []kafka.Header{{Key: "provider", Value: []byte("simulator")}}},
+		kafka.Message{Topic: "payment.status.updates", Offset: 2, Value: []byte("b"), Headers: []kafka.Header{{Key: "provider", Value: []byte("simulator")}}},
+	)
+
+	runUntil(t, consumer, func() bool { return len(reader.committedOffsets()) == 2 })
+
+	assert.Equal(t, []string{"a", "b"}, handled)
+	assert.Equal(t, []int64{1, 2}, reader.committedOffsets())
+	assert.True(t, reader.closed)
+}
+
+func TestConsumerRetriesFailedHandler(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	attempts := 0
+	consumer, reader, _ := newTestConsumer(ctrl, func(context.Context, Message) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}, kafka.Message{Offset: 1})
+
+	runUntil(t, consumer, func() bool { return len(reader.committedOffsets()) == 1 })
+
+	assert.Equal(t, 3, attempts)
+}
+
+func TestConsumerDeadLettersExhaustedMessages(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	attempts := 0
+	consumer, reader, dlq := newTestConsumer(ctrl, func(context.Context, Message) error {
+		attempts++
+		return errors.New("database unavailable")
+	}, kafka.Message{Topic: "payment.status.updates", Partition: 1, Offset: 7, Key: []byte("ref-1"), Value: []byte("{}")})
+
+	dlq.EXPECT().Publish(gomock.Any(), "payment.status.updates.dlq", "ref-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, message interface{}, _ ...Header) error {
+			letter := message.(DeadLetter)
+			assert.Equal(t, int64(7), letter.Offset)
+			assert.Equal(t, 1, letter.Partition)
+			assert.Equal(t, []byte("{}"), letter.Value)
+			assert.Equal(t, "database unavailable", letter.Error)
+			assert.Equal(t, 3, letter.Attempts)
+			return nil
+		})
+
+	runUntil(t, consumer, func() bool { return len(reader.committedOffsets()) == 1 })
+
+	assert.Equal(t, 3, attempts)
+}
+
+func TestConsumerDeadLettersPermanentFailures(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	attempts := 0
+	consumer, reader, dlq := newTestConsumer(ctrl, func(context.Context, Message) error {
+		attempts++
+		return Permanent(errors.New("malformed message"))
+	}, kafka.Message{Offset: 1, Value: []byte("not json")})
+
+	gomock.InOrder(
+		dlq.EXPECT().Publish(gomock.Any(), testConsumerConfig.DLQTopic, "", gomock.Any()).Return(errors.New("broker down")),
+		dlq.EXPECT().Publish(gomock.Any(), testConsumerConfig.DLQTopic, "", gomock.Any()).Return(nil),
+	)
+
+	runUntil(t, consumer, func() bool { return len(reader.committedOffsets()) == 1 })
+
+	assert.Equal(t, 1, attempts)
+}
+
+func TestConsumerLeavesInterruptedRetriesUncommitted(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	failed := make(chan struct{}, 1)
+	consumer, reader, _ := newTestConsumer(ctrl, func(context.Context, Message) error {
+		select {
+		case failed <- struct{}{}:
+		default:
+		}
+		return errors.New("database unavailable")
+	}, kafka.Message{Offset: 1})
+	consumer.config.RetryBackoff = time.Hour
+	consumer.config.MaxBackoff = time.Hour
+
+	runUntil(t, consumer, func() bool { return len(failed) == 1 })
+
+	assert.Empty(t, reader.committedOffsets())
+}
+
+func TestConsumerBackoff(t *testing.T) {
+	consumer := &Consumer{config: testConsumerConfig}
+
+	assert.Equal(t, time.Millisecond, consumer.backoff(1))
+	assert.Equal(t, 4*time.Millisecond, consumer.backoff(3))
+	assert.Equal(t, 5*time.Millisecond, consumer.backoff(10))
+}
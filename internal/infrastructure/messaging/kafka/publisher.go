@@ -2,112 +2,253 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/metrics"
+	"strings"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Publisher interface {
-	Publish(ctx context.Context, topic string, key string, message interface{}) error
+	Publish(ctx context.Context, topic string, key string, message interface{}, headers ...Header) error
 	Close() error
 }
 
+type Header struct {
+	Key   string
+	Value string
+}
+
+type TopicConfig struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+}
+
+type ProducerConfig struct {
+	Brokers []string
+
+	// RequiredAcks is none, leader or all
+	RequiredAcks string
+	// Compression is none, gzip, snappy, lz4 or zstd
+	Compression string
+	// Balancer picks the partition of a message: hash (FNV-1a of the key),
+	// murmur2 (as the Java client), crc32 (as librdkafka) or least_bytes,
+	// which ignores the key and so breaks the per-key ordering of events
+	Balancer     string
+	BatchSize    int
+	BatchBytes   int64
+	BatchTimeout time.Duration
+
+	// Topics are created on startup if they don't exist yet
+	Topics []TopicConfig
+	Auth   Auth
+}
+
 type publisher struct {
-	writer  *kafka.Writer
-	brokers []string
+	writer     *kafka.Writer
+	brokers    []string
+	transport  *kafka.Transport
+	serializer Serializer
+	logger     log.Logger
 }
 
-func NewPublisher(brokers []string) Publisher {
-	log.Printf("🔧 Initializing Kafka publisher with brokers: %v", brokers)
+func NewPublisher(config ProducerConfig, serializer Serializer, logger log.Logger) (Publisher, error) {
+	logger.Infof("initializing kafka publisher with brokers %v", config.Brokers)
+
+	acks, err := requiredAcks(config.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := compression(config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	partitioner, err := balancer(config.Balancer)
+	if err != nil {
+		return nil, err
+	}
 
+	transport := config.Auth.transport()
 	writer := &kafka.Writer{
-		Addr:     kafka.TCP(brokers...),
-		Balancer: &kafka.LeastBytes{},
-		Async:    false,
+		Addr:         kafka.TCP(config.Brokers...),
+		Balancer:     partitioner,
+		RequiredAcks: acks,
+		Compression:  codec,
+		BatchSize:    config.BatchSize,
+		BatchBytes:   config.BatchBytes,
+		BatchTimeout: config.BatchTimeout,
+		Transport:    transport,
 	}
 
 	pub := &publisher{
-		writer:  writer,
-		brokers: brokers,
+		writer:     writer,
+		brokers:    config.Brokers,
+		transport:  transport,
+		serializer: serializer,
+		logger:     logger,
 	}
 
-	if err := pub.createTopicIfNotExists("payment.events"); err != nil {
-		log.Printf("⚠️  Warning: Failed to create topic: %v", err)
+	if err := pub.createTopics(config.Topics); err != nil {
+		logger.Warnf("failed to create topics: %v", err)
 	}
 
-	return pub
+	return pub, nil
 }
 
-func (p *publisher) createTopicIfNotExists(topic string) error {
-	conn, err := kafka.Dial("tcp", p.brokers[0])
-	if err != nil {
-		return fmt.Errorf("failed to dial leader: %w", err)
+func requiredAcks(name string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(name) {
+	case "none":
+		return kafka.RequireNone, nil
+	case "leader":
+		return kafka.RequireOne, nil
+	case "all", "":
+		return kafka.RequireAll, nil
 	}
-	defer conn.Close()
+	return 0, fmt.Errorf("unknown required acks %q", name)
+}
 
-	controller, err := conn.Controller()
-	if err != nil {
-		return fmt.Errorf("failed to get controller: %w", err)
+func compression(name string) (kafka.Compression, error) {
+	switch strings.ToLower(name) {
+	case "none", "":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
 	}
+	return 0, fmt.Errorf("unknown compression codec %q", name)
+}
 
-	controllerConn, err := kafka.Dial("tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
-	if err != nil {
-		return fmt.Errorf("failed to dial controller: %w", err)
+func balancer(name string) (kafka.Balancer, error) {
+	switch strings.ToLower(name) {
+	case "hash", "":
+		return &kafka.Hash{}, nil
+	case "murmur2":
+		return kafka.Murmur2Balancer{}, nil
+	case "crc32":
+		return kafka.CRC32Balancer{}, nil
+	case "least_bytes":
+		return &kafka.LeastBytes{}, nil
 	}
-	defer controllerConn.Close()
+	return nil, fmt.Errorf("unknown balancer %q", name)
+}
 
-	topicConfigs := []kafka.TopicConfig{
-		{
-			Topic:             topic,
-			NumPartitions:     3,
-			ReplicationFactor: 1,
-		},
+// createTopics creates the missing topics. Existing topics are left as they
+// are, even if their partitions or replication differ.
+func (p *publisher) createTopics(topics []TopicConfig) error {
+	if len(topics) == 0 {
+		return nil
 	}
 
-	err = controllerConn.CreateTopics(topicConfigs...)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	request := &kafka.CreateTopicsRequest{}
+	for _, topic := range topics {
+		request.Topics = append(request.Topics, kafka.TopicConfig{
+			Topic:             topic.Name,
+			NumPartitions:     topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+		})
+	}
+
+	client := &kafka.Client{Addr: kafka.TCP(p.brokers...), Transport: p.transport}
+	response, err := client.CreateTopics(ctx, request)
 	if err != nil {
-		// Ignorar erro se o tópico já existe
-		if err.Error() == "Topic with this name already exists" {
-			log.Printf("✅ Topic %s already exists", topic)
-			return nil
+		return fmt.Errorf("failed to create topics: %w", err)
+	}
+
+	var failures []error
+	for _, topic := range topics {
+		switch err := response.Errors[topic.Name]; {
+		case err == nil:
+			p.logger.Infof("topic %s created", topic.Name)
+		case errors.Is(err, kafka.TopicAlreadyExists):
+			p.logger.Infof("topic %s already exists", topic.Name)
+		default:
+			failures = append(failures, fmt.Errorf("%s: %w", topic.Name, err))
 		}
-		return fmt.Errorf("failed to create topic: %w", err)
 	}
 
-	log.Printf("✅ Topic %s created successfully", topic)
-	return nil
+	return errors.Join(failures...)
 }
 
-func (p *publisher) Publish(ctx context.Context, topic string, key string, message interface{}) error {
-	log.Printf("📨 Attempting to publish message - Topic: %s, Key: %s", topic, key)
+func (p *publisher) Publish(ctx context.Context, topic string, key string, message interface{}, headers ...Header) error {
+	logger := p.logger.WithContext(ctx).Tag("topic", topic).Tag("key", key)
 
-	data, err := json.Marshal(message)
+	data, err := p.serializer.Serialize(ctx, topic, message)
 	if err != nil {
-		log.Printf("❌ Failed to marshal message: %v", err)
-		return fmt.Errorf("failed to marshal message: %w", err)
+		logger.Errorf("failed to serialize message: %v", err)
+		return fmt.Errorf("failed to serialize message: %w", err)
 	}
 
-	log.Printf("📦 Message marshaled - Size: %d bytes", len(data))
-
 	msg := kafka.Message{
 		Topic: topic,
 		Key:   []byte(key),
 		Value: data,
 	}
+	for _, header := range headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: header.Key, Value: []byte(header.Value)})
+	}
 
+	start := time.Now()
 	err = p.writer.WriteMessages(ctx, msg)
+	recordPublish(ctx, topic, start, err)
 	if err != nil {
-		log.Printf("❌ Failed to write message to Kafka: %v", err)
+		logger.Errorf("failed to write message to kafka: %v", err)
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	log.Printf("✅ Message published successfully - Topic: %s, Key: %s", topic, key)
+	logger.Debugf("message published (%d bytes)", len(data))
 	return nil
 }
 
+// recordPublish records the duration and the outcome of a write.
+func recordPublish(ctx context.Context, topic string, start time.Time, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.operation.name", "publish"),
+		attribute.String("messaging.destination.name", topic),
+	}
+	if err != nil {
+		attrs = append(attrs, attribute.String("error.type", publishErrorType(err)))
+	}
+
+	metrics.RecordDuration(ctx, metrics.MessagingPublishDuration, start, attrs...)
+	metrics.AddCounter(ctx, metrics.MessagingSentMessages, 1, attrs...)
+}
+
+// publishErrorType is the name of the Kafka error code of err, such as
+// Request Timed Out, or _OTHER for errors of the client or the network.
+func publishErrorType(err error) string {
+	// Writes of a single message fail with the error of that message
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, writeErr := range writeErrs {
+			if writeErr != nil {
+				err = writeErr
+				break
+			}
+		}
+	}
+
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr.Title()
+	}
+	return "_OTHER"
+}
+
 func (p *publisher) Close() error {
-	log.Printf("🔒 Closing Kafka publisher")
+	p.logger.Infof("closing kafka publisher")
 	return p.writer.Close()
 }
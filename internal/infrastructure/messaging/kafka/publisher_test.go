@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	log "go-payments-api/pkg/log/implement"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPublisher(t *testing.T) {
+	pub, err := NewPublisher(ProducerConfig{
+		Brokers:      []string{"localhost:9092"},
+		RequiredAcks: "leader",
+		Compression:  "zstd",
+		Balancer:     "murmur2",
+		BatchSize:    50,
+		BatchBytes:   512,
+		BatchTimeout: 5 * time.Millisecond,
+	}, nil, log.Discard())
+	assert.NoError(t, err)
+
+	writer := pub.(*publisher).writer
+	assert.Equal(t, kafka.RequireOne, writer.RequiredAcks)
+	assert.Equal(t, kafka.Zstd, writer.Compression)
+	assert.IsType(t, kafka.Murmur2Balancer{}, writer.Balancer)
+	assert.Equal(t, 50, writer.BatchSize)
+	assert.Equal(t, int64(512), writer.BatchBytes)
+	assert.Equal(t, 5*time.Millisecond, writer.BatchTimeout)
+	assert.False(t, writer.Async)
+}
+
+func TestNewPublisherDefaults(t *testing.T) {
+	pub, err := NewPublisher(ProducerConfig{Brokers: []string{"localhost:9092"}}, nil, log.Discard())
+	assert.NoError(t, err)
+
+	writer := pub.(*publisher).writer
+	assert.Equal(t, kafka.RequireAll, writer.RequiredAcks)
+	assert.Equal(t, kafka.Compression(0), writer.Compression)
+	assert.IsType(t, &kafka.Hash{}, writer.Balancer)
+}
+
+func TestNewPublisherInvalid(t *testing.T) {
+	cases := map[string]ProducerConfig{
+		"acks":        {RequiredAcks: "some"},
+		"compression": {Compression: "brotli"},
+		"balancer":    {Balancer: "random"},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewPublisher(config, nil, log.Discard())
+			assert.Error(t, err)
+		})
+	}
+}
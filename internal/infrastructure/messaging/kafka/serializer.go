@@ -0,0 +1,224 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-payments-api/pkg/schemaregistry"
+	"sync"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatAvro     Format = "avro"
+	FormatProtobuf Format = "protobuf"
+
+	// magicByte starts every message in the Confluent wire format, followed
+	// by the big-endian schema ID and the encoded message
+	magicByte = 0
+)
+
+var ErrInvalidFrame = errors.New("message is not in the schema registry wire format")
+
+// Schema describes the messages of a topic in each supported format.
+type Schema struct {
+	JSON     string
+	Avro     string
+	Protobuf string
+
+	// Message is the generated type of the Protobuf schema's message
+	Message proto.Message
+}
+
+// Serializer encodes the messages published to a topic.
+type Serializer interface {
+	Serialize(ctx context.Context, topic string, message interface{}) ([]byte, error)
+}
+
+// NewSerializer returns a Serializer writing format in the Confluent wire
+// format. Each topic's schema is registered under the "<topic>-value"
+// subject the first time the topic is published to; topics without a
+// schema can't be published to. Messages are converted to the schema
+// through their JSON encoding, so the schemas' field names must match the
+// messages' json tags.
+func NewSerializer(format Format, registry schemaregistry.Registry, schemas map[string]Schema) (Serializer, error) {
+	switch format {
+	case FormatJSON, FormatAvro, FormatProtobuf:
+	default:
+		return nil, fmt.Errorf("unknown serialization format %q", format)
+	}
+
+	return &serializer{
+		format:     format,
+		registry:   registry,
+		schemas:    schemas,
+		registered: map[string]*registeredSchema{},
+	}, nil
+}
+
+type serializer struct {
+	format   Format
+	registry schemaregistry.Registry
+	schemas  map[string]Schema
+
+	mu         sync.Mutex
+	registered map[string]*registeredSchema
+}
+
+type registeredSchema struct {
+	id      int
+	codec   *goavro.Codec
+	avro    interface{}
+	message protoreflect.MessageType
+	indexes []byte
+}
+
+func (s *serializer) Serialize(ctx context.Context, topic string, message interface{}) ([]byte, error) {
+	schema, err := s.schemaFor(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.format {
+	case FormatAvro:
+		encoded, err = encodeAvro(schema.codec, schema.avro, encoded)
+	case FormatProtobuf:
+		encoded, err = encodeProtobuf(schema.message, encoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s message for %s: %w", s.format, topic, err)
+	}
+
+	framed := make([]byte, 5, 5+len(schema.indexes)+len(encoded))
+	framed[0] = magicByte
+	binary.BigEndian.PutUint32(framed[1:], uint32(schema.id))
+	framed = append(framed, schema.indexes...)
+	return append(framed, encoded...), nil
+}
+
+// schemaFor registers the topic's schema once and caches its ID.
+func (s *serializer) schemaFor(ctx context.Context, topic string) (*registeredSchema, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if registered, ok := s.registered[topic]; ok {
+		return registered, nil
+	}
+
+	schema, ok := s.schemas[topic]
+	if !ok {
+		return nil, fmt.Errorf("no schema for topic %s", topic)
+	}
+
+	registered := &registeredSchema{}
+	var schemaType schemaregistry.SchemaType
+	var source string
+
+	switch s.format {
+	case FormatJSON:
+		schemaType, source = schemaregistry.JSON, schema.JSON
+	case FormatAvro:
+		codec, err := goavro.NewCodec(schema.Avro)
+		if err != nil {
+			return nil, fmt.Errorf("invalid avro schema for %s: %w", topic, err)
+		}
+		if err := json.Unmarshal([]byte(schema.Avro), &registered.avro); err != nil {
+			return nil, fmt.Errorf("invalid avro schema for %s: %w", topic, err)
+		}
+		schemaType, source, registered.codec = schemaregistry.Avro, schema.Avro, codec
+	case FormatProtobuf:
+		if schema.Message == nil {
+			return nil, fmt.Errorf("no protobuf message for topic %s", topic)
+		}
+		schemaType, source = schemaregistry.Protobuf, schema.Protobuf
+		registered.message = schema.Message.ProtoReflect().Type()
+		registered.indexes = messageIndexes(schema.Message.ProtoReflect().Descriptor())
+	}
+
+	if source == "" {
+		return nil, fmt.Errorf("no %s schema for topic %s", s.format, topic)
+	}
+
+	id, err := s.registry.Register(ctx, topic+"-value", schemaType, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register schema for %s: %w", topic, err)
+	}
+	registered.id = id
+
+	s.registered[topic] = registered
+	return registered, nil
+}
+
+// Unframe splits a wire format message into its schema ID and the rest of
+// the message. Protobuf messages still start with their message indexes.
+func Unframe(data []byte) (int, []byte, error) {
+	if len(data) < 5 || data[0] != magicByte {
+		return 0, nil, ErrInvalidFrame
+	}
+	return int(binary.BigEndian.Uint32(data[1:5])), data[5:], nil
+}
+
+func encodeAvro(codec *goavro.Codec, schema interface{}, encoded []byte) ([]byte, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	native, err := newAvroConverter(schema).convert(schema, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.BinaryFromNative(nil, native)
+}
+
+func encodeProtobuf(messageType protoreflect.MessageType, encoded []byte) ([]byte, error) {
+	message := messageType.New().Interface()
+	if err := protojson.Unmarshal(encoded, message); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
+
+// messageIndexes encodes the path of message within its .proto file as
+// zig-zag varints: the count, then the index at each nesting level. The
+// common case of the file's first message is shortened to a single 0.
+func messageIndexes(message protoreflect.MessageDescriptor) []byte {
+	var path []int
+	var descriptor protoreflect.Descriptor = message
+	for {
+		path = append([]int{descriptor.Index()}, path...)
+		parent, ok := descriptor.Parent().(protoreflect.MessageDescriptor)
+		if !ok {
+			break
+		}
+		descriptor = parent
+	}
+
+	if len(path) == 1 && path[0] == 0 {
+		return []byte{0}
+	}
+
+	indexes := binary.AppendVarint(nil, int64(len(path)))
+	for _, index := range path {
+		indexes = binary.AppendVarint(indexes, int64(index))
+	}
+	return indexes
+}
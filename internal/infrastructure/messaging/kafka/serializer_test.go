@@ -0,0 +1,144 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-payments-api/pkg/schemaregistry"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type testMessage struct {
+	ID       int64     `json:"id"`
+	Reason   *string   `json:"reason"`
+	Payload  []byte    `json:"payload"`
+	Tags     []string  `json:"tags"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+var testSchema = Schema{
+	JSON: `{"type":"object","required":["id"],"properties":{"id":{"type":"integer"}}}`,
+	Avro: `{"type":"record","name":"TestMessage","namespace":"test","fields":[
+		{"name":"id","type":"long"},
+		{"name":"reason","type":["null","string"],"default":null},
+		{"name":"payload","type":"bytes"},
+		{"name":"tags","type":{"type":"array","items":"string"}},
+		{"name":"failed_at","type":{"type":"long","logicalType":"timestamp-micros"}},
+		{"name":"attempts","type":"int","default":1}
+	]}`,
+	// Int64Value is the third message of google/protobuf/wrappers.proto
+	Protobuf: `syntax = "proto3";
+		package google.protobuf;
+		message DoubleValue { double value = 1; }
+		message FloatValue { float value = 1; }
+		message Int64Value { int64 value = 1; }`,
+	Message: &wrapperspb.Int64Value{},
+}
+
+func newTestSerializer(t *testing.T, format Format) Serializer {
+	serializer, err := NewSerializer(format, schemaregistry.NewInMemory(), map[string]Schema{"test": testSchema})
+	assert.NoError(t, err)
+	return serializer
+}
+
+func TestSerializerJSON(t *testing.T) {
+	message := testMessage{ID: 1, Tags: []string{"a"}}
+
+	data, err := newTestSerializer(t, FormatJSON).Serialize(context.Background(), "test", message)
+	assert.NoError(t, err)
+
+	id, payload, err := Unframe(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	expected, _ := json.Marshal(message)
+	assert.JSONEq(t, string(expected), string(payload))
+}
+
+func TestSerializerAvro(t *testing.T) {
+	reason := "card declined"
+	failedAt := time.Date(2024, 11, 13, 10, 30, 0, 0, time.UTC)
+
+	serializer := newTestSerializer(t, FormatAvro)
+	data, err := serializer.Serialize(context.Background(), "test", testMessage{
+		ID:       1,
+		Reason:   &reason,
+		Payload:  []byte{0xde, 0xad},
+		Tags:     []string{"a", "b"},
+		FailedAt: failedAt,
+	})
+	assert.NoError(t, err)
+
+	id, payload, err := Unframe(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	codec, err := goavro.NewCodec(testSchema.Avro)
+	assert.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(payload)
+	assert.NoError(t, err)
+
+	record := native.(map[string]interface{})
+	assert.Equal(t, int64(1), record["id"])
+	assert.Equal(t, map[string]interface{}{"string": reason}, record["reason"])
+	assert.Equal(t, []byte{0xde, 0xad}, record["payload"])
+	assert.Equal(t, []interface{}{"a", "b"}, record["tags"])
+	assert.True(t, failedAt.Equal(record["failed_at"].(time.Time)))
+	assert.Equal(t, int32(1), record["attempts"])
+
+	data, err = serializer.Serialize(context.Background(), "test", testMessage{ID: 2, Payload: []byte{}, Tags: []string{}, FailedAt: failedAt})
+	assert.NoError(t, err)
+
+	_, payload, _ = Unframe(data)
+	native, _, err = codec.NativeFromBinary(payload)
+	assert.NoError(t, err)
+	assert.Nil(t, native.(map[string]interface{})["reason"])
+}
+
+func TestSerializerProtobuf(t *testing.T) {
+	data, err := newTestSerializer(t, FormatProtobuf).Serialize(context.Background(), "test", 42)
+	assert.NoError(t, err)
+
+	id, payload, err := Unframe(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	// Message indexes [2] as zig-zag varints: the count, then the index
+	assert.Equal(t, []byte{0x02, 0x04}, payload[:2])
+
+	var message wrapperspb.Int64Value
+	assert.NoError(t, proto.Unmarshal(payload[2:], &message))
+	assert.Equal(t, int64(42), message.Value)
+}
+
+func TestSerializerErrors(t *testing.T) {
+	_, err := NewSerializer("xml", schemaregistry.NewInMemory(), nil)
+	assert.Error(t, err)
+
+	_, err = newTestSerializer(t, FormatJSON).Serialize(context.Background(), "unknown", testMessage{})
+	assert.Error(t, err)
+
+	registry := schemaregistry.NewInMemory()
+	_, err = registry.Register(context.Background(), "test-value", schemaregistry.Avro,
+		`{"type":"record","name":"TestMessage","namespace":"test","fields":[{"name":"id","type":"string"}]}`)
+	assert.NoError(t, err)
+
+	serializer, err := NewSerializer(FormatAvro, registry, map[string]Schema{"test": testSchema})
+	assert.NoError(t, err)
+	_, err = serializer.Serialize(context.Background(), "test", testMessage{ID: 1})
+	assert.ErrorIs(t, err, schemaregistry.ErrIncompatible)
+
+	_, err = newTestSerializer(t, FormatAvro).Serialize(context.Background(), "test", map[string]string{"id": "1"})
+	assert.Error(t, err)
+}
+
+func TestUnframeInvalid(t *testing.T) {
+	_, _, err := Unframe([]byte(`{"id":1}`))
+	assert.ErrorIs(t, err, ErrInvalidFrame)
+}
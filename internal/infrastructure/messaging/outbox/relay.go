@@ -0,0 +1,241 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/pkg/cloudevents"
+	"go-payments-api/pkg/log"
+	"go-payments-api/pkg/metrics"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// ContentType is the content-type header of the published messages,
+	// matching the format the publisher serializes them in. The header
+	// stored with the message is kept when empty
+	ContentType string
+}
+
+// Relay drains the outbox table into Kafka. Messages sharing a topic and key
+// are published in the order they were enqueued, and failed messages are
+// retried with exponential backoff until they get through. Once published,
+// events are also scheduled for delivery to merchant webhook subscriptions.
+type Relay struct {
+	repository repository.OutboxRepository
+	transactor repository.Transactor
+	publisher  kafka.Publisher
+	deliveries repository.WebhookDeliveryRepository
+	config     Config
+
+	lag       metric.Float64Gauge
+	pending   metric.Int64Gauge
+	published metric.Int64Counter
+	failed    metric.Int64Counter
+}
+
+func NewRelay(
+	repository repository.OutboxRepository,
+	transactor repository.Transactor,
+	publisher kafka.Publisher,
+	deliveries repository.WebhookDeliveryRepository,
+	config Config,
+) *Relay {
+	meter := otel.Meter("go-payments-api/outbox")
+
+	lag, _ := meter.Float64Gauge("outbox.lag",
+		metric.WithUnit("s"),
+		metric.WithDescription("Age of the oldest message waiting in the outbox"))
+	pending, _ := meter.Int64Gauge("outbox.pending",
+		metric.WithDescription("Messages waiting in the outbox"))
+	published, _ := meter.Int64Counter("outbox.published",
+		metric.WithDescription("Outbox messages published to Kafka"))
+	failed, _ := meter.Int64Counter("outbox.failed",
+		metric.WithDescription("Outbox publish attempts that failed"))
+
+	return &Relay{
+		repository: repository,
+		transactor: transactor,
+		publisher:  publisher,
+		deliveries: deliveries,
+		config:     config,
+		lag:        lag,
+		pending:    pending,
+		published:  published,
+		failed:     failed,
+	}
+}
+
+// Run polls the outbox until ctx is canceled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+		r.recordStats(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.RelayBatch(ctx)
+		if err != nil {
+			log.Logger.Errorf("failed to relay outbox batch: %v", err)
+			return
+		}
+
+		if n < r.config.BatchSize {
+			return
+		}
+	}
+}
+
+// RelayBatch publishes one batch of pending messages and returns how many
+// were fetched.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	ctx, span := metrics.StartSpan(ctx, "OutboxRelay.RelayBatch")
+	defer span.End()
+
+	var fetched int
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		messages, err := r.repository.FetchPending(ctx, r.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		fetched = len(messages)
+
+		for _, message := range messages {
+			if err := r.relay(ctx, message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	metrics.AddSpanAttributes(ctx, attribute.Int("outbox.batch.size", fetched))
+
+	return fetched, err
+}
+
+func (r *Relay) relay(ctx context.Context, message *entity.OutboxMessage) error {
+	attrs := metric.WithAttributes(attribute.String("topic", message.Topic))
+
+	err := r.publisher.Publish(ctx, message.Topic, message.Key, json.RawMessage(message.Payload), r.headers(message)...)
+	if err == nil {
+		r.published.Add(ctx, 1, attrs)
+		if err := r.scheduleWebhooks(ctx, message); err != nil {
+			return err
+		}
+		return r.repository.MarkPublished(ctx, message.ID)
+	}
+
+	r.failed.Add(ctx, 1, attrs)
+	metrics.AddSpanEvent(ctx, "outbox.publish.failed",
+		attribute.Int64("outbox.message.id", message.ID),
+		attribute.String("error", err.Error()),
+	)
+
+	message.Attempts++
+	message.LastError = err.Error()
+	message.NextAttemptAt = time.Now().Add(r.backoff(message.Attempts))
+
+	log.Logger.WithContext(ctx).Errorf("failed to publish outbox message %d (attempt %d), retrying at %s: %v",
+		message.ID, message.Attempts, message.NextAttemptAt.Format(time.RFC3339), err)
+
+	return r.repository.MarkFailed(ctx, message)
+}
+
+// scheduleWebhooks fans a published event out to the webhook subscriptions
+// of the merchant of the message interested in its type. It runs in the same transaction as
+// MarkPublished so every event is scheduled exactly once.
+func (r *Relay) scheduleWebhooks(ctx context.Context, message *entity.OutboxMessage) error {
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(message.Payload, &event); err != nil || event.Type == "" {
+		return nil
+	}
+
+	scheduled, err := r.deliveries.Schedule(ctx, message.MerchantID, event.Type, message.Payload)
+	if err != nil {
+		return err
+	}
+
+	metrics.AddSpanEvent(ctx, "outbox.webhooks.scheduled",
+		attribute.Int64("outbox.message.id", message.ID),
+		attribute.Int64("webhook.deliveries", scheduled),
+	)
+
+	return nil
+}
+
+// headers returns the message headers in a stable order, with the content
+// type of the serialized message.
+func (r *Relay) headers(message *entity.OutboxMessage) []kafka.Header {
+	values := make(map[string]string, len(message.Headers)+1)
+	for key, value := range message.Headers {
+		values[key] = value
+	}
+	if r.config.ContentType != "" {
+		values[cloudevents.HeaderContentType] = r.config.ContentType
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := make([]kafka.Header, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, kafka.Header{Key: key, Value: values[key]})
+	}
+	return headers
+}
+
+// backoff doubles the wait after every failed attempt, up to MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	wait := r.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= r.config.MaxBackoff {
+			return r.config.MaxBackoff
+		}
+	}
+	return wait
+}
+
+func (r *Relay) recordStats(ctx context.Context) {
+	pending, oldest, err := r.repository.Stats(ctx)
+	if err != nil {
+		log.Logger.Errorf("failed to read outbox stats: %v", err)
+		return
+	}
+
+	lag := 0.0
+	if pending > 0 {
+		lag = time.Since(oldest).Seconds()
+	}
+
+	r.pending.Record(ctx, pending)
+	r.lag.Record(ctx, lag)
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testConfig = Config{
+	PollInterval: time.Millisecond,
+	BatchSize:    10,
+	BaseBackoff:  time.Second,
+	MaxBackoff:   10 * time.Second,
+	ContentType:  "application/cloudevents+avro",
+}
+
+// contentType is the header the relay adds to every message.
+var contentType = kafka.Header{Key: "content-type", Value: "application/cloudevents+avro"}
+
+func newTestRelay(ctrl *gomock.Controller) (*Relay, *repository.MockOutboxRepository, *kafka.MockPublisher, *repository.MockWebhookDeliveryRepository) {
+	outbox := repository.NewMockOutboxRepository(ctrl)
+	publisher := kafka.NewMockPublisher(ctrl)
+	deliveries := repository.NewMockWebhookDeliveryRepository(ctrl)
+
+	transactor := repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return NewRelay(outbox, transactor, publisher, deliveries, testConfig), outbox, publisher, deliveries
+}
+
+func TestRelayBatch(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	relay, outbox, publisher, _ := newTestRelay(ctrl)
+
+	outbox.EXPECT().FetchPending(gomock.Any(), 10).Return([]*entity.OutboxMessage{
+		{ID: 1, Topic: "payment.events", Key: "1", Payload: []byte(`{"id":1}`)},
+		{ID: 2, Topic: "payment.events", Key: "2", Payload: []byte(`{"id":2}`)},
+	}, nil)
+
+	gomock.InOrder(
+		publisher.EXPECT().Publish(gomock.Any(), "payment.events", "1", json.RawMessage(`{"id":1}`), contentType).Return(nil),
+		outbox.EXPECT().MarkPublished(gomock.Any(), int64(1)).Return(nil),
+		publisher.EXPECT().Publish(gomock.Any(), "payment.events", "2", json.RawMessage(`{"id":2}`), contentType).Return(nil),
+		outbox.EXPECT().MarkPublished(gomock.Any(), int64(2)).Return(nil),
+	)
+
+	n, err := relay.RelayBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestRelayBatchPublishFailure(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	relay, outbox, publisher, _ := newTestRelay(ctrl)
+
+	outbox.EXPECT().FetchPending(gomock.Any(), 10).Return([]*entity.OutboxMessage{
+		{ID: 1, Topic: "payment.events", Key: "1", Payload: []byte(`{}`), Attempts: 2},
+	}, nil)
+	publisher.EXPECT().Publish(gomock.Any(), "payment.events", "1", gomock.Any(), contentType).Return(errors.New("broker down"))
+	outbox.EXPECT().MarkFailed(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *entity.OutboxMessage) error {
+		assert.Equal(t, 3, message.Attempts)
+		assert.Equal(t, "broker down", message.LastError)
+		assert.WithinDuration(t, time.Now().Add(4*time.Second), message.NextAttemptAt, time.Second)
+		return nil
+	})
+
+	n, err := relay.RelayBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestRelayBatchSchedulesWebhooks(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	relay, outbox, publisher, deliveries := newTestRelay(ctrl)
+
+	payload := []byte(`{"specversion":"1.0","type":"payment.completed","data":{"id":1}}`)
+	outbox.EXPECT().FetchPending(gomock.Any(), 10).Return([]*entity.OutboxMessage{
+		{ID: 1, Topic: "payment.events", Key: "1", MerchantID: 7, Payload: payload, Headers: map[string]string{
+			"traceparent":  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"content-type": "application/cloudevents+json",
+		}},
+	}, nil)
+
+	gomock.InOrder(
+		publisher.EXPECT().Publish(gomock.Any(), "payment.events", "1", json.RawMessage(payload),
+			contentType,
+			kafka.Header{Key: "traceparent", Value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		).Return(nil),
+		deliveries.EXPECT().Schedule(gomock.Any(), int64(7), "payment.completed", payload).Return(int64(2), nil),
+		outbox.EXPECT().MarkPublished(gomock.Any(), int64(1)).Return(nil),
+	)
+
+	n, err := relay.RelayBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := &Relay{config: testConfig}
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(50))
+}
+
+func TestRelayRunStopsWithContext(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	relay, outbox, _, _ := newTestRelay(ctrl)
+
+	outbox.EXPECT().FetchPending(gomock.Any(), 10).Return(nil, nil).AnyTimes()
+	outbox.EXPECT().Stats(gomock.Any()).Return(int64(0), time.Now(), nil).AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay didn't stop after context cancellation")
+	}
+}
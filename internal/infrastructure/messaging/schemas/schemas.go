@@ -0,0 +1,43 @@
+// Package schemas holds the schemas of the messages the API publishes to
+// Kafka, in every format kafka.Serializer supports. Keep the three in sync
+// and run make proto after editing a .proto file.
+package schemas
+
+import (
+	_ "embed"
+
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+)
+
+var (
+	//go:embed payment_events.schema.json
+	paymentEventsJSON string
+	//go:embed payment_events.avsc
+	paymentEventsAvro string
+	//go:embed payment_events.proto
+	paymentEventsProto string
+
+	//go:embed dead_letter.schema.json
+	deadLetterJSON string
+	//go:embed dead_letter.avsc
+	deadLetterAvro string
+	//go:embed dead_letter.proto
+	deadLetterProto string
+)
+
+// PaymentEvents describes the CloudEvents published to payment.events.
+var PaymentEvents = kafka.Schema{
+	JSON:     paymentEventsJSON,
+	Avro:     paymentEventsAvro,
+	Protobuf: paymentEventsProto,
+	Message:  &PaymentEvent{},
+}
+
+// DeadLetters describes the kafka.DeadLetter published to dead letter
+// topics.
+var DeadLetters = kafka.Schema{
+	JSON:     deadLetterJSON,
+	Avro:     deadLetterAvro,
+	Protobuf: deadLetterProto,
+	Message:  &DeadLetter{},
+}
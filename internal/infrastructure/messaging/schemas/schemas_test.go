@@ -0,0 +1,140 @@
+package schemas
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/internal/infrastructure/messaging/kafka"
+	"go-payments-api/pkg/cloudevents"
+	"go-payments-api/pkg/schemaregistry"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	createdAt = time.Date(2024, 11, 13, 10, 30, 0, 0, time.UTC)
+	amounts   = map[string]int64{"payment": 10050, "refund": 5000}
+)
+
+// events returns the payment and refund events as the outbox relay
+// publishes them.
+func events(t *testing.T) map[string]json.RawMessage {
+	payment, err := cloudevents.New(context.Background(), "/go-payments-api", "payment.completed", "payments/1", "", dto.PaymentEvent{
+		ID:        1,
+		Amount:    money.Money{Value: amounts["payment"], Currency: "BRL"},
+		Method:    "PIX",
+		Status:    "COMPLETED",
+		CreatedAt: createdAt,
+	})
+	assert.NoError(t, err)
+
+	refund, err := cloudevents.New(context.Background(), "/go-payments-api", "refund.created", "refunds/2", "", dto.RefundEvent{
+		ID:        2,
+		PaymentID: 1,
+		Amount:    money.Money{Value: amounts["refund"], Currency: "BRL"},
+		Status:    "PENDING",
+		Reason:    "customer request",
+		CreatedAt: createdAt,
+	})
+	assert.NoError(t, err)
+
+	encoded := map[string]json.RawMessage{}
+	for name, event := range map[string]*cloudevents.Event{"payment": payment, "refund": refund} {
+		encoded[name], err = json.Marshal(event)
+		assert.NoError(t, err)
+	}
+	return encoded
+}
+
+func serialize(t *testing.T, format kafka.Format, schema kafka.Schema, message interface{}) []byte {
+	serializer, err := kafka.NewSerializer(format, schemaregistry.NewInMemory(), map[string]kafka.Schema{"topic": schema})
+	assert.NoError(t, err)
+
+	data, err := serializer.Serialize(context.Background(), "topic", message)
+	assert.NoError(t, err)
+
+	_, payload, err := kafka.Unframe(data)
+	assert.NoError(t, err)
+	return payload
+}
+
+func TestPaymentEventsAvro(t *testing.T) {
+	codec, err := goavro.NewCodec(PaymentEvents.Avro)
+	assert.NoError(t, err)
+
+	for name, event := range events(t) {
+		t.Run(name, func(t *testing.T) {
+			native, _, err := codec.NativeFromBinary(serialize(t, kafka.FormatAvro, PaymentEvents, event))
+			assert.NoError(t, err)
+
+			data := native.(map[string]interface{})["data"].(map[string]interface{})
+			assert.Equal(t, map[string]interface{}{"value": amounts[name], "currency": "BRL"}, data["amount"])
+			assert.True(t, createdAt.Equal(data["created_at"].(time.Time)))
+			if name == "refund" {
+				assert.Equal(t, int64(1), data["payment_id"])
+				assert.Equal(t, "customer request", data["reason"])
+			}
+		})
+	}
+}
+
+func TestPaymentEventsProtobuf(t *testing.T) {
+	for name, event := range events(t) {
+		t.Run(name, func(t *testing.T) {
+			payload := serialize(t, kafka.FormatProtobuf, PaymentEvents, event)
+			assert.Equal(t, byte(0), payload[0])
+
+			var decoded PaymentEvent
+			assert.NoError(t, proto.Unmarshal(payload[1:], &decoded))
+			assert.Equal(t, "1.0", decoded.Specversion)
+			assert.Equal(t, amounts[name], decoded.Data.Amount.Value)
+			assert.Equal(t, "BRL", decoded.Data.Amount.Currency)
+			assert.True(t, createdAt.Equal(decoded.Data.CreatedAt.AsTime()))
+			if name == "refund" {
+				assert.Equal(t, int64(1), decoded.Data.PaymentId)
+				assert.Equal(t, "refund.created", decoded.Type)
+			}
+		})
+	}
+}
+
+func TestPaymentEventsJSON(t *testing.T) {
+	for name, event := range events(t) {
+		t.Run(name, func(t *testing.T) {
+			assert.JSONEq(t, string(event), string(serialize(t, kafka.FormatJSON, PaymentEvents, event)))
+		})
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	letter := kafka.DeadLetter{
+		Topic:     "payment.status.updates",
+		Partition: 2,
+		Offset:    42,
+		Key:       "ref-1",
+		Value:     []byte(`{"reference":"ref-1"`),
+		Headers:   map[string]string{"provider": "simulator"},
+		Error:     "malformed status update",
+		Attempts:  1,
+		FailedAt:  createdAt,
+	}
+
+	codec, err := goavro.NewCodec(DeadLetters.Avro)
+	assert.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(serialize(t, kafka.FormatAvro, DeadLetters, letter))
+	assert.NoError(t, err)
+	assert.Equal(t, letter.Value, native.(map[string]interface{})["value"])
+	assert.Equal(t, map[string]interface{}{"provider": "simulator"}, native.(map[string]interface{})["headers"])
+
+	var decoded DeadLetter
+	assert.NoError(t, proto.Unmarshal(serialize(t, kafka.FormatProtobuf, DeadLetters, letter)[1:], &decoded))
+	assert.Equal(t, letter.Value, decoded.Value)
+	assert.Equal(t, int64(42), decoded.Offset)
+	assert.Equal(t, letter.Headers, decoded.Headers)
+}
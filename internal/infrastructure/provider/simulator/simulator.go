@@ -0,0 +1,195 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is returned for the calls picked to fail by Config.ErrorRate.
+var ErrUnavailable = errors.New("simulated provider unavailable")
+
+type Config struct {
+	// Latency is how long every call takes
+	Latency time.Duration
+
+	// DeclineRate is the share of authorizations declined, from 0 to 1
+	DeclineRate float64
+
+	// ErrorRate is the share of calls failing with ErrUnavailable, from 0 to 1
+	ErrorRate float64
+}
+
+type operation struct {
+	method   string
+	status   provider.Status
+	amount   money.Money
+	refunded money.Money
+}
+
+// Simulator is an in-memory provider.Provider used to run the API without
+// a real acquirer. CARD payments are authorized and wait for capture, PIX
+// payments stay pending until the payer pays them. Operations are lost when
+// the process stops.
+type Simulator struct {
+	config Config
+	random func() float64
+
+	mu         sync.Mutex
+	operations map[string]*operation
+}
+
+func New(config Config) *Simulator {
+	return &Simulator{
+		config:     config,
+		random:     rand.Float64,
+		operations: make(map[string]*operation),
+	}
+}
+
+func (s *Simulator) Authorize(ctx context.Context, payment *entity.Payment) (*provider.Result, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+
+	op := &operation{
+		method:   payment.Method,
+		status:   provider.StatusAuthorized,
+		amount:   payment.Amount,
+		refunded: money.Money{Currency: payment.Amount.Currency},
+	}
+
+	message := ""
+	switch {
+	case s.random() < s.config.DeclineRate:
+		op.status = provider.StatusDeclined
+		message = "simulated decline"
+	case payment.Method == entity.MethodPix:
+		op.status = provider.StatusPending
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reference := fmt.Sprintf("sim_%016x", rand.Uint64())
+	s.operations[reference] = op
+
+	return &provider.Result{Reference: reference, Status: op.status, Message: message}, nil
+}
+
+func (s *Simulator) Capture(ctx context.Context, reference string, amount money.Money) (*provider.Result, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.update(reference, func(op *operation) error {
+		if op.status != provider.StatusAuthorized {
+			return fmt.Errorf("can't capture a %s operation", op.status)
+		}
+
+		if cmp, err := amount.Cmp(op.amount); err != nil || cmp > 0 {
+			return fmt.Errorf("can't capture %s out of %s", amount, op.amount)
+		}
+
+		op.status = provider.StatusCaptured
+		return nil
+	})
+}
+
+func (s *Simulator) Void(ctx context.Context, reference string) (*provider.Result, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.update(reference, func(op *operation) error {
+		if op.status != provider.StatusAuthorized && op.status != provider.StatusPending {
+			return fmt.Errorf("can't void a %s operation", op.status)
+		}
+
+		op.status = provider.StatusVoided
+		return nil
+	})
+}
+
+// Refund accepts refunds of captured operations and of pending PIX ones,
+// as those may have been paid without the simulator knowing.
+func (s *Simulator) Refund(ctx context.Context, reference string, amount money.Money) (*provider.Result, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.update(reference, func(op *operation) error {
+		switch op.status {
+		case provider.StatusCaptured, provider.StatusRefunded:
+		case provider.StatusPending:
+			if op.method != entity.MethodPix {
+				return fmt.Errorf("can't refund a %s operation", op.status)
+			}
+		default:
+			return fmt.Errorf("can't refund a %s operation", op.status)
+		}
+
+		refunded, err := op.refunded.Add(amount)
+		if err != nil {
+			return err
+		}
+
+		if cmp, err := refunded.Cmp(op.amount); err != nil || cmp > 0 {
+			return fmt.Errorf("can't refund %s out of %s", refunded, op.amount)
+		}
+
+		op.refunded = refunded
+		op.status = provider.StatusRefunded
+		return nil
+	})
+}
+
+func (s *Simulator) GetStatus(ctx context.Context, reference string) (*provider.Result, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.update(reference, func(*operation) error { return nil })
+}
+
+// call waits for the configured latency and fails at the configured rate.
+func (s *Simulator) call(ctx context.Context) error {
+	if s.config.Latency > 0 {
+		timer := time.NewTimer(s.config.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if s.random() < s.config.ErrorRate {
+		return ErrUnavailable
+	}
+
+	return nil
+}
+
+func (s *Simulator) update(reference string, fn func(op *operation) error) (*provider.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[reference]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", provider.ErrUnknownReference, reference)
+	}
+
+	if err := fn(op); err != nil {
+		return nil, err
+	}
+
+	return &provider.Result{Reference: reference, Status: op.status}, nil
+}
@@ -0,0 +1,94 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/gateway/provider"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPayment(method string) *entity.Payment {
+	return &entity.Payment{
+		ID:     1,
+		Amount: money.Money{Value: 10000, Currency: "BRL"},
+		Method: method,
+	}
+}
+
+func TestSimulatorCardLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := New(Config{})
+
+	result, err := s.Authorize(ctx, newPayment(entity.MethodCard))
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusAuthorized, result.Status)
+	assert.NotEmpty(t, result.Reference)
+
+	result, err = s.Capture(ctx, result.Reference, money.Money{Value: 10000, Currency: "BRL"})
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusCaptured, result.Status)
+
+	_, err = s.Void(ctx, result.Reference)
+	assert.Error(t, err)
+
+	result, err = s.Refund(ctx, result.Reference, money.Money{Value: 4000, Currency: "BRL"})
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusRefunded, result.Status)
+
+	_, err = s.Refund(ctx, result.Reference, money.Money{Value: 6001, Currency: "BRL"})
+	assert.Error(t, err)
+
+	result, err = s.GetStatus(ctx, result.Reference)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusRefunded, result.Status)
+}
+
+func TestSimulatorPixPending(t *testing.T) {
+	ctx := context.Background()
+	s := New(Config{})
+
+	result, err := s.Authorize(ctx, newPayment(entity.MethodPix))
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusPending, result.Status)
+
+	_, err = s.Capture(ctx, result.Reference, money.Money{Value: 10000, Currency: "BRL"})
+	assert.Error(t, err)
+
+	result, err = s.Void(ctx, result.Reference)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusVoided, result.Status)
+}
+
+func TestSimulatorFailureRates(t *testing.T) {
+	ctx := context.Background()
+
+	declining := New(Config{DeclineRate: 0.5})
+	declining.random = func() float64 { return 0.25 }
+
+	result, err := declining.Authorize(ctx, newPayment(entity.MethodCard))
+	assert.NoError(t, err)
+	assert.Equal(t, provider.StatusDeclined, result.Status)
+
+	failing := New(Config{ErrorRate: 1})
+
+	_, err = failing.Authorize(ctx, newPayment(entity.MethodCard))
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestSimulatorLatencyHonorsContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err := New(Config{Latency: time.Minute}).Authorize(ctx, newPayment(entity.MethodCard))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSimulatorUnknownReference(t *testing.T) {
+	_, err := New(Config{}).GetStatus(context.Background(), "sim_missing")
+	assert.ErrorIs(t, err, provider.ErrUnknownReference)
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	paymentsv1 "go-payments-api/api/proto/payments/v1"
+	"go-payments-api/internal/application/auth"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/base"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptors(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	apiKeys := repository.NewMockApiKeyRepository(ctrl)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_valid")).
+		Return(&entity.ApiKey{MerchantID: 7, Prefix: "pk_valid"}, nil).Times(2)
+	apiKeys.EXPECT().FindByHash(gomock.Any(), auth.HashApiKey("pk_unknown")).Return(nil, nil)
+
+	merchantOf := func(ctx context.Context, _ dto.GetPaymentInput) (*dto.GetPaymentOutput, error) {
+		return &dto.GetPaymentOutput{ID: auth.MerchantID(ctx), Status: string(entity.StatusFailed)}, nil
+	}
+	getPayment := base.NewMockUseCase[dto.GetPaymentInput, *dto.GetPaymentOutput](ctrl)
+	getPayment.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(merchantOf).Times(2)
+
+	authenticator := auth.NewAuthenticator(apiKeys, nil)
+	client := serve(t, NewPaymentService(nil, getPayment, nil, PaymentServiceConfig{WatchInterval: time.Millisecond}),
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(authenticator)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(authenticator)),
+	)
+
+	_, err := client.GetPayment(context.Background(), &paymentsv1.GetPaymentRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "pk_unknown")
+	_, err = client.GetPayment(ctx, &paymentsv1.GetPaymentRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "pk_valid")
+	payment, err := client.GetPayment(ctx, &paymentsv1.GetPaymentRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), payment.Id)
+
+	stream, err := client.WatchPayment(ctx, &paymentsv1.WatchPaymentRequest{Id: 1})
+	assert.NoError(t, err)
+	payment, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), payment.Id)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	paymentsv1 "go-payments-api/api/proto/payments/v1"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/constants"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/metrics"
+
+	"github.com/gin-gonic/gin/binding"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const idempotencyKeyMaxLength = 255
+
+type PaymentServiceConfig struct {
+	// WatchInterval is how often WatchPayment polls the payment status
+	WatchInterval time.Duration
+}
+
+// PaymentService serves payments.v1.PaymentService with the same use cases
+// as the /payments HTTP handlers. Errors are returned as they come and
+// turned into statuses by the interceptors of api.GrpcServer.
+type PaymentService struct {
+	paymentsv1.UnimplementedPaymentServiceServer
+
+	createPayment usecase.CreatePayment
+	getPayment    usecase.GetPayment
+	listPayments  usecase.ListPayments
+	config        PaymentServiceConfig
+}
+
+func NewPaymentService(
+	createPayment usecase.CreatePayment,
+	getPayment usecase.GetPayment,
+	listPayments usecase.ListPayments,
+	config PaymentServiceConfig,
+) *PaymentService {
+	return &PaymentService{
+		createPayment: createPayment,
+		getPayment:    getPayment,
+		listPayments:  listPayments,
+		config:        config,
+	}
+}
+
+func (s *PaymentService) CreatePayment(ctx context.Context, request *paymentsv1.CreatePaymentRequest) (*paymentsv1.CreatePaymentResponse, error) {
+	ctx, span := metrics.StartSpan(ctx, "PaymentService.CreatePayment")
+	defer span.End()
+
+	input := dto.CreatePaymentInput{
+		Amount:         toMoney(request.GetAmount()),
+		Method:         request.GetMethod(),
+		IdempotencyKey: request.GetIdempotencyKey(),
+	}
+	if err := validate(ctx, input); err != nil {
+		return nil, err
+	}
+	if len(input.IdempotencyKey) > idempotencyKeyMaxLength {
+		return nil, appErr.NewBadFormat("idempotency_key is too long")
+	}
+
+	output, err := s.createPayment.Execute(ctx, input)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "usecase.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.Int64("payment.created.id", output.ID))
+	response := &paymentsv1.CreatePaymentResponse{
+		Payment: &paymentsv1.Payment{
+			Id:        output.ID,
+			Amount:    fromMoney(output.Amount),
+			Method:    output.Method,
+			Status:    output.Status,
+			CreatedAt: timestamppb.New(output.CreatedAt),
+			UpdatedAt: timestamppb.New(output.CreatedAt),
+		},
+		Replayed: output.Replayed,
+	}
+	if output.Pix != nil {
+		qrCode, err := base64.StdEncoding.DecodeString(output.Pix.QRCode)
+		if err != nil {
+			return nil, appErr.NewInternalServer("invalid PIX QR code")
+		}
+		response.Pix = &paymentsv1.PixCharge{
+			Txid:      output.Pix.TxID,
+			Payload:   output.Pix.Payload,
+			QrCode:    qrCode,
+			ExpiresAt: timestamppb.New(output.Pix.ExpiresAt),
+		}
+	}
+
+	return response, nil
+}
+
+func (s *PaymentService) GetPayment(ctx context.Context, request *paymentsv1.GetPaymentRequest) (*paymentsv1.Payment, error) {
+	ctx, span := metrics.StartSpan(ctx, "PaymentService.GetPayment")
+	defer span.End()
+
+	input := dto.GetPaymentInput{ID: request.GetId()}
+	if err := validate(ctx, input); err != nil {
+		return nil, err
+	}
+
+	output, err := s.getPayment.Execute(ctx, input)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "usecase.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	return toPayment(*output), nil
+}
+
+func (s *PaymentService) ListPayments(ctx context.Context, request *paymentsv1.ListPaymentsRequest) (*paymentsv1.ListPaymentsResponse, error) {
+	ctx, span := metrics.StartSpan(ctx, "PaymentService.ListPayments")
+	defer span.End()
+
+	input := dto.ListPaymentsInput{
+		Status:      request.GetStatus(),
+		Method:      request.GetMethod(),
+		Currency:    request.GetCurrency(),
+		MinAmount:   request.GetMinAmount(),
+		MaxAmount:   request.GetMaxAmount(),
+		CreatedFrom: toTime(request.GetCreatedFrom()),
+		CreatedTo:   toTime(request.GetCreatedTo()),
+		Cursor:      request.GetCursor(),
+	}
+	if request.GetLimit() != 0 {
+		input.Limit = constants.PageLength(strconv.Itoa(int(request.GetLimit())))
+	}
+	if err := validate(ctx, input); err != nil {
+		return nil, err
+	}
+
+	output, err := s.listPayments.Execute(ctx, input)
+	if err != nil {
+		metrics.AddSpanEvent(ctx, "usecase.failed", attribute.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &paymentsv1.ListPaymentsResponse{
+		Payments:   make([]*paymentsv1.Payment, 0, len(output.Data)),
+		NextCursor: output.NextCursor,
+		HasMore:    output.HasMore,
+	}
+	for _, payment := range output.Data {
+		response.Payments = append(response.Payments, toPayment(payment))
+	}
+
+	return response, nil
+}
+
+// WatchPayment polls the payment every WatchInterval and sends it whenever
+// its status changes, starting with its current state.
+func (s *PaymentService) WatchPayment(request *paymentsv1.WatchPaymentRequest, stream paymentsv1.PaymentService_WatchPaymentServer) error {
+	ctx := stream.Context()
+
+	input := dto.GetPaymentInput{ID: request.GetId()}
+	if err := validate(ctx, input); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.config.WatchInterval)
+	defer ticker.Stop()
+
+	var status string
+	for {
+		output, err := s.getPayment.Execute(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		if output.Status != status {
+			status = output.Status
+			if err := stream.Send(toPayment(*output)); err != nil {
+				return err
+			}
+		}
+		if entity.PaymentStatus(status).IsTerminal() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// validate checks input against the same binding tags the HTTP handlers
+// bind with.
+func validate(ctx context.Context, input any) error {
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		metrics.AddSpanEvent(ctx, "validation.failed", attribute.String("error", err.Error()))
+		return appErr.NewBadFormat(err.Error())
+	}
+	return nil
+}
+
+func toPayment(payment dto.GetPaymentOutput) *paymentsv1.Payment {
+	return &paymentsv1.Payment{
+		Id:        payment.ID,
+		Amount:    fromMoney(payment.Amount),
+		Method:    payment.Method,
+		Status:    payment.Status,
+		CreatedAt: timestamppb.New(payment.CreatedAt),
+		UpdatedAt: timestamppb.New(payment.UpdatedAt),
+	}
+}
+
+func toMoney(amount *paymentsv1.Money) money.Money {
+	return money.Money{Value: amount.GetValue(), Currency: money.Currency(amount.GetCurrency())}
+}
+
+func fromMoney(amount money.Money) *paymentsv1.Money {
+	return &paymentsv1.Money{Value: amount.Value, Currency: string(amount.Currency)}
+}
+
+// toTime keeps unset timestamps as the zero time the use cases ignore,
+// instead of the Unix epoch AsTime returns for nil.
+func toTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
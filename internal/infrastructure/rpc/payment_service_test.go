@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	paymentsv1 "go-payments-api/api/proto/payments/v1"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/domain/money"
+	"go-payments-api/pkg/api"
+	"go-payments-api/pkg/base"
+	"go-payments-api/pkg/constants"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serve starts service on an in-memory listener behind the interceptors of
+// api.GrpcServer and returns a client for it.
+func serve(t *testing.T, service *PaymentService, opts ...grpc.ServerOption) paymentsv1.PaymentServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := api.NewGrpcServer[*grpc.Server]("bufconn", nil, opts...)
+	paymentsv1.RegisterPaymentServiceServer(server.GetRouter(), service)
+	go func() {
+		_ = server.GetRouter().Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown(context.Background())
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return paymentsv1.NewPaymentServiceClient(conn)
+}
+
+func TestPaymentServiceCreatePayment(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	createPayment := base.NewMockUseCase[dto.CreatePaymentInput, *dto.CreatePaymentOutput](ctrl)
+	createPayment.EXPECT().
+		Execute(gomock.Any(), dto.CreatePaymentInput{
+			Amount:         money.Money{Value: 10050, Currency: "BRL"},
+			Method:         "PIX",
+			IdempotencyKey: "key-1",
+		}).
+		Return(&dto.CreatePaymentOutput{
+			ID:        1,
+			Amount:    money.Money{Value: 10050, Currency: "BRL"},
+			Method:    "PIX",
+			Status:    "CREATED",
+			CreatedAt: createdAt,
+			Pix: &dto.PixChargeOutput{
+				TxID:      "PAY1",
+				Payload:   "000201",
+				QRCode:    "iVBORw0KGgo=",
+				ExpiresAt: createdAt.Add(30 * time.Minute),
+			},
+			Replayed: true,
+		}, nil)
+
+	client := serve(t, NewPaymentService(createPayment, nil, nil, PaymentServiceConfig{}))
+
+	response, err := client.CreatePayment(context.Background(), &paymentsv1.CreatePaymentRequest{
+		Amount:         &paymentsv1.Money{Value: 10050, Currency: "BRL"},
+		Method:         "PIX",
+		IdempotencyKey: "key-1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), response.Payment.Id)
+	assert.Equal(t, "BRL", response.Payment.Amount.Currency)
+	assert.Equal(t, createdAt, response.Payment.CreatedAt.AsTime())
+	assert.Equal(t, "PAY1", response.Pix.Txid)
+	assert.Equal(t, []byte("\x89PNG\r\n\x1a\n"), response.Pix.QrCode)
+	assert.True(t, response.Replayed)
+
+	_, err = client.CreatePayment(context.Background(), &paymentsv1.CreatePaymentRequest{Method: "BOLETO"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPaymentServiceGetPayment(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	getPayment := base.NewMockUseCase[dto.GetPaymentInput, *dto.GetPaymentOutput](ctrl)
+	getPayment.EXPECT().
+		Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+		Return(&dto.GetPaymentOutput{ID: 1, Method: "PIX", Status: "COMPLETED"}, nil)
+	getPayment.EXPECT().
+		Execute(gomock.Any(), dto.GetPaymentInput{ID: 2}).
+		Return(nil, appErr.NewNotFound("payment 2 not found"))
+
+	client := serve(t, NewPaymentService(nil, getPayment, nil, PaymentServiceConfig{}))
+
+	payment, err := client.GetPayment(context.Background(), &paymentsv1.GetPaymentRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "COMPLETED", payment.Status)
+
+	_, err = client.GetPayment(context.Background(), &paymentsv1.GetPaymentRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "payment 2 not found", status.Convert(err).Message())
+
+	_, err = client.GetPayment(context.Background(), &paymentsv1.GetPaymentRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPaymentServiceListPayments(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	listPayments := base.NewMockUseCase[dto.ListPaymentsInput, *dto.ListPaymentsOutput](ctrl)
+	listPayments.EXPECT().
+		Execute(gomock.Any(), dto.ListPaymentsInput{Status: "CREATED", Limit: constants.FIFTY}).
+		Return(&dto.ListPaymentsOutput{
+			Data:       []dto.GetPaymentOutput{{ID: 2}, {ID: 1}},
+			NextCursor: "next",
+			HasMore:    true,
+		}, nil)
+
+	client := serve(t, NewPaymentService(nil, nil, listPayments, PaymentServiceConfig{}))
+
+	response, err := client.ListPayments(context.Background(), &paymentsv1.ListPaymentsRequest{Status: "CREATED", Limit: 50})
+	assert.NoError(t, err)
+	assert.Len(t, response.Payments, 2)
+	assert.Equal(t, int64(2), response.Payments[0].Id)
+	assert.Equal(t, "next", response.NextCursor)
+	assert.True(t, response.HasMore)
+
+	_, err = client.ListPayments(context.Background(), &paymentsv1.ListPaymentsRequest{Limit: 20})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPaymentServiceWatchPayment(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	getPayment := base.NewMockUseCase[dto.GetPaymentInput, *dto.GetPaymentOutput](ctrl)
+	gomock.InOrder(
+		getPayment.EXPECT().Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+			Return(&dto.GetPaymentOutput{ID: 1, Status: "CREATED"}, nil),
+		getPayment.EXPECT().Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+			Return(&dto.GetPaymentOutput{ID: 1, Status: "CREATED"}, nil),
+		getPayment.EXPECT().Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+			Return(&dto.GetPaymentOutput{ID: 1, Status: "PROCESSING"}, nil),
+		getPayment.EXPECT().Execute(gomock.Any(), dto.GetPaymentInput{ID: 1}).
+			Return(&dto.GetPaymentOutput{ID: 1, Status: "FAILED"}, nil),
+	)
+
+	client := serve(t, NewPaymentService(nil, getPayment, nil, PaymentServiceConfig{WatchInterval: time.Millisecond}))
+
+	stream, err := client.WatchPayment(context.Background(), &paymentsv1.WatchPaymentRequest{Id: 1})
+	assert.NoError(t, err)
+
+	var statuses []string
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		statuses = append(statuses, payment.Status)
+	}
+
+	assert.Equal(t, []string{"CREATED", "PROCESSING", "FAILED"}, statuses)
+}
+
+func TestPaymentServiceWatchPaymentNotFound(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	getPayment := base.NewMockUseCase[dto.GetPaymentInput, *dto.GetPaymentOutput](ctrl)
+	getPayment.EXPECT().
+		Execute(gomock.Any(), dto.GetPaymentInput{ID: 3}).
+		Return(nil, appErr.NewNotFound("payment 3 not found"))
+
+	client := serve(t, NewPaymentService(nil, getPayment, nil, PaymentServiceConfig{WatchInterval: time.Millisecond}))
+
+	stream, err := client.WatchPayment(context.Background(), &paymentsv1.WatchPaymentRequest{Id: 3})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,70 @@
+package sweeper
+
+import (
+	"context"
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/internal/application/usecase"
+	"go-payments-api/pkg/log"
+	"time"
+)
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// PixExpiry periodically expires the PIX payments whose charges lapsed
+// without being paid.
+type PixExpiry struct {
+	useCase usecase.ExpirePixCharges
+	config  Config
+	now     func() time.Time
+}
+
+func NewPixExpiry(useCase usecase.ExpirePixCharges, config Config) *PixExpiry {
+	return &PixExpiry{
+		useCase: useCase,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// Run sweeps expired charges until ctx is canceled.
+func (s *PixExpiry) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep expires charges in batches until a batch comes back short.
+func (s *PixExpiry) Sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		output, err := s.useCase.Execute(ctx, dto.ExpirePixChargesInput{
+			Now:   s.now(),
+			Limit: s.config.BatchSize,
+		})
+		if err != nil {
+			log.Logger.Errorf("failed to sweep expired pix charges: %v", err)
+			return
+		}
+
+		if output.Expired > 0 {
+			log.Logger.Infof("expired %d pix payments", output.Expired)
+		}
+
+		// Charges that failed to expire come back on every query, so a full
+		// batch without progress must not loop
+		if output.Found < s.config.BatchSize || output.Expired == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/dto"
+	"go-payments-api/pkg/base"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPixExpirySweep(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	input := dto.ExpirePixChargesInput{Now: now, Limit: 2}
+
+	useCase := base.NewMockUseCase[dto.ExpirePixChargesInput, *dto.ExpirePixChargesOutput](ctrl)
+	gomock.InOrder(
+		useCase.EXPECT().Execute(gomock.Any(), input).Return(&dto.ExpirePixChargesOutput{Found: 2, Expired: 2}, nil),
+		useCase.EXPECT().Execute(gomock.Any(), input).Return(&dto.ExpirePixChargesOutput{Found: 1, Expired: 1}, nil),
+	)
+
+	s := NewPixExpiry(useCase, Config{Interval: time.Minute, BatchSize: 2})
+	s.now = func() time.Time { return now }
+
+	s.Sweep(context.Background())
+}
+
+func TestPixExpirySweepStops(t *testing.T) {
+	cases := map[string]func(call *gomock.Call){
+		"no progress": func(call *gomock.Call) {
+			call.Return(&dto.ExpirePixChargesOutput{Found: 2, Expired: 0}, nil)
+		},
+		"error": func(call *gomock.Call) {
+			call.Return(nil, errors.New("connection refused"))
+		},
+	}
+
+	for name, answer := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := test.Setup(t, nil)
+
+			useCase := base.NewMockUseCase[dto.ExpirePixChargesInput, *dto.ExpirePixChargesOutput](ctrl)
+			answer(useCase.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1))
+
+			NewPixExpiry(useCase, Config{Interval: time.Minute, BatchSize: 2}).Sweep(context.Background())
+
+			assert.True(t, ctrl.Satisfied())
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Package webhook delivers payment events to the HTTP endpoints merchants
+// subscribed with.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/http"
+	"go-payments-api/pkg/log"
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/signature"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	HeaderEvent    = "X-Webhook-Event"
+	HeaderDelivery = "X-Webhook-Delivery"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+}
+
+// Dispatcher sends due webhook deliveries to their subscriptions. Each
+// request is signed with the subscription secret, failed attempts are
+// retried with exponential backoff and jitter, and deliveries that run out
+// of attempts are dead-lettered until someone redelivers them.
+type Dispatcher struct {
+	deliveries repository.WebhookDeliveryRepository
+	client     http.Wrapper
+	config     Config
+	now        func() time.Time
+	random     func(n int64) int64
+
+	attempts metric.Int64Counter
+}
+
+func NewDispatcher(
+	deliveries repository.WebhookDeliveryRepository,
+	client http.Wrapper,
+	config Config,
+) *Dispatcher {
+	meter := otel.Meter("go-payments-api/webhook")
+
+	attempts, _ := meter.Int64Counter("webhook.delivery.attempts",
+		metric.WithDescription("Merchant webhook delivery attempts by outcome"))
+
+	return &Dispatcher{
+		deliveries: deliveries,
+		client:     client,
+		config:     config,
+		now:        time.Now,
+		random:     rand.Int63n,
+		attempts:   attempts,
+	}
+}
+
+// Run polls for due deliveries until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := d.DispatchBatch(ctx)
+		if err != nil {
+			log.Logger.Errorf("failed to dispatch webhook batch: %v", err)
+			return
+		}
+
+		if n < d.config.BatchSize {
+			return
+		}
+	}
+}
+
+// DispatchBatch attempts one batch of due deliveries and returns how many
+// were claimed. The deliveries are claimed before any request is sent, so no
+// database transaction waits on merchant endpoints, and each outcome is
+// stored on its own as soon as it's known.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	ctx, span := metrics.StartSpan(ctx, "WebhookDispatcher.DispatchBatch")
+	defer span.End()
+
+	now := d.now()
+	deliveries, err := d.deliveries.ClaimDue(ctx, now, now.Add(d.claim()), d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.AddSpanAttributes(ctx, attribute.Int("webhook.batch.size", len(deliveries)))
+
+	for _, delivery := range deliveries {
+		d.deliver(ctx, delivery)
+
+		if err := d.deliveries.Update(ctx, delivery); err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// claim is how long claimed deliveries are kept from other dispatchers. A
+// batch is sent one delivery at a time, so it covers a batch where every
+// endpoint times out.
+func (d *Dispatcher) claim() time.Duration {
+	return time.Duration(d.config.BatchSize+1) * d.config.Timeout
+}
+
+// deliver makes one attempt and records its outcome on delivery.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	status, err := d.send(ctx, delivery)
+	if err == nil {
+		delivery.Delivered(status)
+		d.record(ctx, delivery)
+		return
+	}
+
+	delivery.Failed(err, status, d.now().Add(d.backoff(delivery.Attempts+1)), d.config.MaxAttempts)
+	d.record(ctx, delivery)
+
+	metrics.AddSpanEvent(ctx, "webhook.delivery.failed",
+		attribute.Int64("webhook.delivery.id", delivery.ID),
+		attribute.String("error", err.Error()),
+	)
+
+	if delivery.Status == entity.WebhookDeliveryDead {
+		log.Logger.WithContext(ctx).Errorf("webhook delivery %d to subscription %d dead-lettered after %d attempts: %v",
+			delivery.ID, delivery.SubscriptionID, delivery.Attempts, err)
+		return
+	}
+
+	log.Logger.WithContext(ctx).Errorf("webhook delivery %d to subscription %d failed (attempt %d), retrying at %s: %v",
+		delivery.ID, delivery.SubscriptionID, delivery.Attempts, delivery.NextAttemptAt.Format(time.RFC3339), err)
+}
+
+// send posts the signed payload and returns the response status. Any answer
+// outside 2xx is a failure.
+func (d *Dispatcher) send(ctx context.Context, delivery *entity.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	now := d.now()
+	response, err := d.client.Post(ctx, delivery.Subscription.URL, http.Request{
+		Headers: map[string]string{
+			"Content-Type":            "application/json",
+			HeaderEvent:               delivery.EventType,
+			HeaderDelivery:            strconv.FormatInt(delivery.ID, 10),
+			signature.HeaderTimestamp: strconv.FormatInt(now.Unix(), 10),
+			signature.HeaderSignature: signature.Sign(delivery.Subscription.Secret, now, delivery.Payload),
+		},
+		Body: delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint responded with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (d *Dispatcher) record(ctx context.Context, delivery *entity.WebhookDelivery) {
+	d.attempts.Add(ctx, 1, metric.WithAttributes(attribute.String("status", string(delivery.Status))))
+}
+
+// backoff doubles the wait after every failed attempt, up to MaxBackoff, and
+// picks a random point in its upper half so failing endpoints aren't retried
+// in lockstep.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.config.BaseBackoff
+	for i := 1; i < attempts && wait < d.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.config.MaxBackoff {
+		wait = d.config.MaxBackoff
+	}
+
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+
+	return half + time.Duration(d.random(int64(half)+1))
+}
@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-payments-api/internal/application/gateway/repository"
+	"go-payments-api/internal/domain/entity"
+	"go-payments-api/pkg/http"
+	"go-payments-api/pkg/signature"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testConfig = Config{
+	PollInterval: time.Millisecond,
+	BatchSize:    10,
+	MaxAttempts:  3,
+	BaseBackoff:  time.Second,
+	MaxBackoff:   10 * time.Second,
+	Timeout:      time.Second,
+}
+
+func newTestDispatcher(ctrl *gomock.Controller) (*Dispatcher, *repository.MockWebhookDeliveryRepository) {
+	deliveries := repository.NewMockWebhookDeliveryRepository(ctrl)
+
+	dispatcher := NewDispatcher(deliveries, http.NewWrapper(), testConfig)
+	dispatcher.random = func(n int64) int64 { return 0 }
+
+	return dispatcher, deliveries
+}
+
+func testDelivery(url string, attempts int) *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             7,
+		SubscriptionID: 3,
+		EventType:      "payment.completed",
+		Payload:        []byte(`{"id":1,"event_type":"payment.completed"}`),
+		Status:         entity.WebhookDeliveryPending,
+		Attempts:       attempts,
+		Subscription:   &entity.WebhookSubscription{ID: 3, URL: url, Secret: "whsec_test"},
+	}
+}
+
+func TestDispatchBatchDelivers(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	dispatcher, deliveries := newTestDispatcher(ctrl)
+
+	receiver := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "payment.completed", r.Header.Get(HeaderEvent))
+		assert.Equal(t, "7", r.Header.Get(HeaderDelivery))
+		assert.NoError(t, signature.Verify("whsec_test",
+			r.Header.Get(signature.HeaderTimestamp),
+			r.Header.Get(signature.HeaderSignature),
+			body, time.Now(), time.Minute))
+
+		w.WriteHeader(nethttp.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	deliveries.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		DoAndReturn(func(_ context.Context, now, until time.Time, _ int) ([]*entity.WebhookDelivery, error) {
+			assert.Equal(t, 11*time.Second, until.Sub(now))
+			return []*entity.WebhookDelivery{testDelivery(receiver.URL, 0)}, nil
+		})
+	deliveries.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery) error {
+		assert.Equal(t, entity.WebhookDeliveryDelivered, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, nethttp.StatusNoContent, delivery.ResponseStatus)
+		return nil
+	})
+
+	n, err := dispatcher.DispatchBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestDispatchBatchRetries(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	dispatcher, deliveries := newTestDispatcher(ctrl)
+
+	receiver := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	deliveries.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		Return([]*entity.WebhookDelivery{testDelivery(receiver.URL, 1)}, nil)
+	deliveries.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery) error {
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, nethttp.StatusServiceUnavailable, delivery.ResponseStatus)
+		assert.Equal(t, "endpoint responded with status 503", delivery.LastError)
+		assert.WithinDuration(t, time.Now().Add(time.Second), delivery.NextAttemptAt, time.Second)
+		return nil
+	})
+
+	_, err := dispatcher.DispatchBatch(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestDispatchBatchDeadLetters(t *testing.T) {
+	ctrl := test.Setup(t, nil)
+	dispatcher, deliveries := newTestDispatcher(ctrl)
+
+	receiver := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusInternalServerError)
+	}))
+	receiver.Close()
+
+	deliveries.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		Return([]*entity.WebhookDelivery{testDelivery(receiver.URL, 2)}, nil)
+	deliveries.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *entity.WebhookDelivery) error {
+		assert.Equal(t, entity.WebhookDeliveryDead, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Zero(t, delivery.ResponseStatus)
+		assert.NotEmpty(t, delivery.LastError)
+		return nil
+	})
+
+	_, err := dispatcher.DispatchBatch(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	dispatcher := &Dispatcher{config: testConfig}
+
+	dispatcher.random = func(n int64) int64 { return 0 }
+	assert.Equal(t, 500*time.Millisecond, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(3))
+	assert.Equal(t, 5*time.Second, dispatcher.backoff(50))
+
+	dispatcher.random = func(n int64) int64 { return n - 1 }
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 4*time.Second, dispatcher.backoff(3))
+	assert.Equal(t, 10*time.Second, dispatcher.backoff(50))
+}
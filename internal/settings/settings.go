@@ -1,6 +1,11 @@
 package settings
 
 import (
+	"fmt"
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/ratelimit"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -8,17 +13,98 @@ import (
 
 type (
 	Specification struct {
-		Environment string `envconfig:"ENVIRONMENT" default:"dev"`
-		HttpServer  HttpServerSpecification
-		Database    DatabaseSpecification
-		Kafka       KafkaSpecification
-		Metrics     MetricsSpecification
+		Environment     string `envconfig:"ENVIRONMENT" default:"dev"`
+		Log             LogSpecification
+		Redaction       RedactionSpecification
+		HttpServer      HttpServerSpecification
+		GrpcServer      GrpcServerSpecification
+		Auth            AuthSpecification
+		RateLimit       RateLimitSpecification
+		Cors            CorsSpecification
+		Database        DatabaseSpecification
+		Kafka           KafkaSpecification
+		Outbox          OutboxSpecification
+		Provider        ProviderSpecification
+		ProviderWebhook ProviderWebhookSpecification
+		MerchantWebhook MerchantWebhookSpecification
+		Pix             PixSpecification
+		Metrics         MetricsSpecification
 	}
 
+	// LogSpecification configures the application logger. Level is debug,
+	// info, warn, error or fatal, and can be changed while running through
+	// the admin API. Format is text or json, one object per line with the
+	// trace_id and span_id of the request.
+	LogSpecification struct {
+		Level    string      `envconfig:"LOG_LEVEL" default:"info"`
+		Format   string      `envconfig:"LOG_FORMAT" default:"text"`
+		Sampling LogSampling `envconfig:"LOG_SAMPLING"`
+	}
+
+	// RedactionSpecification configures how sensitive data is masked in
+	// logs, traces and responses. HashKey keys the hashes of documents,
+	// emails and PIX keys, so the same value hashes the same on every
+	// instance; a random key is used when it's empty.
+	RedactionSpecification struct {
+		HashKey string `envconfig:"REDACTION_HASH_KEY"`
+	}
+
+	// HttpServerSpecification configures the HTTP server. TrustedProxies are
+	// the IPs or CIDRs of the load balancers in front of it, whose
+	// X-Forwarded-For header tells the client IP used by the rate limits. No
+	// proxy is trusted by default.
 	HttpServerSpecification struct {
-		Port         string        `envconfig:"HTTP_SERVER_PORT" default:":8080"`
-		ReadTimeout  time.Duration `envconfig:"HTTP_SERVER_READ_TIMEOUT" default:"15s"`
-		WriteTimeout time.Duration `envconfig:"HTTP_SERVER_WRITE_TIMEOUT" default:"15s"`
+		Port           string        `envconfig:"HTTP_SERVER_PORT" default:":8080"`
+		ReadTimeout    time.Duration `envconfig:"HTTP_SERVER_READ_TIMEOUT" default:"15s"`
+		WriteTimeout   time.Duration `envconfig:"HTTP_SERVER_WRITE_TIMEOUT" default:"15s"`
+		TrustedProxies []string      `envconfig:"HTTP_SERVER_TRUSTED_PROXIES"`
+	}
+
+	GrpcServerSpecification struct {
+		Port          string        `envconfig:"GRPC_SERVER_PORT" default:":9090"`
+		WatchInterval time.Duration `envconfig:"GRPC_WATCH_POLL_INTERVAL" default:"1s"`
+	}
+
+	// AuthSpecification configures the bearer tokens accepted next to API
+	// keys. Bearer tokens are rejected when neither JWKSURLs nor StaticJWKS
+	// is set.
+	AuthSpecification struct {
+		JWKSURLs            []string      `envconfig:"AUTH_JWT_JWKS_URLS"`
+		JWKSRefreshInterval time.Duration `envconfig:"AUTH_JWT_JWKS_REFRESH_INTERVAL" default:"1h"`
+		StaticJWKS          string        `envconfig:"AUTH_JWT_STATIC_JWKS"`
+		Algorithms          []string      `envconfig:"AUTH_JWT_ALGORITHMS" default:"RS256,ES256"`
+		Issuer              string        `envconfig:"AUTH_JWT_ISSUER"`
+		Audience            string        `envconfig:"AUTH_JWT_AUDIENCE"`
+		Leeway              time.Duration `envconfig:"AUTH_JWT_LEEWAY" default:"30s"`
+		MerchantClaim       string        `envconfig:"AUTH_JWT_MERCHANT_CLAIM" default:"merchant_id"`
+	}
+
+	// RateLimitSpecification sets the rules of each route group of the HTTP
+	// API. A request must be allowed by every rule of its group, so a short
+	// period limits bursts while a long one works as a quota. Groups set
+	// empty aren't limited.
+	RateLimitSpecification struct {
+		Public        RateLimitRules `envconfig:"RATE_LIMIT_PUBLIC" default:"100/1s:200:ip"`
+		Authenticated RateLimitRules `envconfig:"RATE_LIMIT_AUTHENTICATED" default:"50/1s:100:api_key"`
+		Admin         RateLimitRules `envconfig:"RATE_LIMIT_ADMIN" default:"10/1s:20:api_key"`
+		// PreAuth limits by IP the requests of the authenticated and admin
+		// groups before their credentials are checked, so requests failing
+		// authentication are limited too
+		PreAuth RateLimitRules `envconfig:"RATE_LIMIT_PRE_AUTH" default:"100/1s:200:ip"`
+	}
+
+	// CorsSpecification is the CORS policy browsers get, such as the one of
+	// the checkout widget. Origins are exact or patterns with * for a host
+	// label or port, e.g. https://*.example.com, and no origin is allowed by
+	// default. The trace ID header of the responses is always exposed.
+	CorsSpecification struct {
+		AllowedOrigins   []string      `envconfig:"CORS_ALLOWED_ORIGINS"`
+		AllowedMethods   []string      `envconfig:"CORS_ALLOWED_METHODS" default:"GET,POST,PATCH,DELETE"`
+		AllowedHeaders   []string      `envconfig:"CORS_ALLOWED_HEADERS" default:"Content-Type,Authorization,X-API-KEY,Idempotency-Key"`
+		ExposedHeaders   []string      `envconfig:"CORS_EXPOSED_HEADERS" default:"X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,Retry-After"`
+		AllowCredentials bool          `envconfig:"CORS_ALLOW_CREDENTIALS" default:"false"`
+		MaxAge           time.Duration `envconfig:"CORS_MAX_AGE" default:"10m"`
+		Routes           CorsRoutes    `envconfig:"CORS_ROUTES"`
 	}
 
 	DatabaseSpecification struct {
@@ -30,19 +116,263 @@ type (
 	}
 
 	KafkaSpecification struct {
-		Brokers []string `envconfig:"KAFKA_BROKERS" default:"kafka:9092"`
+		Brokers       []string      `envconfig:"KAFKA_BROKERS" default:"kafka:9092"`
+		ConsumerGroup string        `envconfig:"KAFKA_CONSUMER_GROUP" default:"go-payments-api"`
+		MaxRetries    int           `envconfig:"KAFKA_CONSUMER_MAX_RETRIES" default:"3"`
+		RetryBackoff  time.Duration `envconfig:"KAFKA_CONSUMER_RETRY_BACKOFF" default:"500ms"`
+		MaxBackoff    time.Duration `envconfig:"KAFKA_CONSUMER_MAX_BACKOFF" default:"30s"`
+
+		StatusUpdatesTopic    string `envconfig:"KAFKA_STATUS_UPDATES_TOPIC" default:"payment.status.updates"`
+		StatusUpdatesDLQTopic string `envconfig:"KAFKA_STATUS_UPDATES_DLQ_TOPIC" default:"payment.status.updates.dlq"`
+
+		// StatusUpdatesProvider is the provider of status updates published
+		// without a provider header
+		StatusUpdatesProvider string `envconfig:"KAFKA_STATUS_UPDATES_PROVIDER" default:"simulator"`
+
+		// Producer tuning; see kafka.ProducerConfig for the accepted values
+		RequiredAcks string        `envconfig:"KAFKA_PRODUCER_ACKS" default:"all"`
+		Compression  string        `envconfig:"KAFKA_PRODUCER_COMPRESSION" default:"none"`
+		Balancer     string        `envconfig:"KAFKA_PRODUCER_BALANCER" default:"hash"`
+		BatchSize    int           `envconfig:"KAFKA_PRODUCER_BATCH_SIZE" default:"100"`
+		BatchBytes   int64         `envconfig:"KAFKA_PRODUCER_BATCH_BYTES" default:"1048576"`
+		BatchTimeout time.Duration `envconfig:"KAFKA_PRODUCER_BATCH_TIMEOUT" default:"10ms"`
+
+		Topics KafkaTopics `envconfig:"KAFKA_TOPICS" default:"payment.events:3:1,payment.status.updates:3:1,payment.status.updates.dlq:1:1"`
+
+		// SASLMechanism is plain, scram-sha-256 or scram-sha-512, or empty to
+		// connect without SASL
+		SASLMechanism         string `envconfig:"KAFKA_SASL_MECHANISM"`
+		SASLUsername          string `envconfig:"KAFKA_SASL_USERNAME"`
+		SASLPassword          string `envconfig:"KAFKA_SASL_PASSWORD"`
+		TLSEnabled            bool   `envconfig:"KAFKA_TLS_ENABLED" default:"false"`
+		TLSCAFile             string `envconfig:"KAFKA_TLS_CA_FILE"`
+		TLSInsecureSkipVerify bool   `envconfig:"KAFKA_TLS_INSECURE_SKIP_VERIFY" default:"false"`
+
+		// Serializer is the format messages are published in: json, avro or
+		// protobuf, framed with the ID of their schema in the registry
+		Serializer string `envconfig:"KAFKA_SERIALIZER" default:"json"`
+
+		// Schemas are registered in memory when SchemaRegistryURL is empty,
+		// so IDs are only meaningful to this process
+		SchemaRegistryURL      string `envconfig:"KAFKA_SCHEMA_REGISTRY_URL"`
+		SchemaRegistryUsername string `envconfig:"KAFKA_SCHEMA_REGISTRY_USERNAME"`
+		SchemaRegistryPassword string `envconfig:"KAFKA_SCHEMA_REGISTRY_PASSWORD"`
+	}
+
+	OutboxSpecification struct {
+		PollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+		BatchSize    int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+		BaseBackoff  time.Duration `envconfig:"OUTBOX_BASE_BACKOFF" default:"1s"`
+		MaxBackoff   time.Duration `envconfig:"OUTBOX_MAX_BACKOFF" default:"5m"`
+	}
+
+	ProviderSpecification struct {
+		SimulatorLatency     time.Duration `envconfig:"PROVIDER_SIMULATOR_LATENCY" default:"200ms"`
+		SimulatorDeclineRate float64       `envconfig:"PROVIDER_SIMULATOR_DECLINE_RATE" default:"0"`
+		SimulatorErrorRate   float64       `envconfig:"PROVIDER_SIMULATOR_ERROR_RATE" default:"0"`
 	}
 
+	PixSpecification struct {
+		Key            string        `envconfig:"PIX_KEY"`
+		MerchantName   string        `envconfig:"PIX_MERCHANT_NAME" default:"Go Payments"`
+		MerchantCity   string        `envconfig:"PIX_MERCHANT_CITY" default:"Sao Paulo"`
+		Expiration     time.Duration `envconfig:"PIX_EXPIRATION" default:"30m"`
+		QRCodeSize     int           `envconfig:"PIX_QR_CODE_SIZE" default:"256"`
+		SweepInterval  time.Duration `envconfig:"PIX_SWEEP_INTERVAL" default:"1m"`
+		SweepBatchSize int           `envconfig:"PIX_SWEEP_BATCH_SIZE" default:"100"`
+	}
+
+	ProviderWebhookSpecification struct {
+		// Secrets maps each provider to its signing secret, e.g.
+		// "simulator:secret,acme:other"
+		Secrets   map[string]string `envconfig:"PROVIDER_WEBHOOK_SECRETS"`
+		Tolerance time.Duration     `envconfig:"PROVIDER_WEBHOOK_TOLERANCE" default:"5m"`
+	}
+
+	// MerchantWebhookSpecification configures the deliveries to the merchants.
+	// AllowPrivateNetworks lets subscriptions and deliveries reach loopback
+	// and private addresses, for local setups only.
+	MerchantWebhookSpecification struct {
+		PollInterval         time.Duration `envconfig:"MERCHANT_WEBHOOK_POLL_INTERVAL" default:"1s"`
+		BatchSize            int           `envconfig:"MERCHANT_WEBHOOK_BATCH_SIZE" default:"50"`
+		MaxAttempts          int           `envconfig:"MERCHANT_WEBHOOK_MAX_ATTEMPTS" default:"8"`
+		BaseBackoff          time.Duration `envconfig:"MERCHANT_WEBHOOK_BASE_BACKOFF" default:"30s"`
+		MaxBackoff           time.Duration `envconfig:"MERCHANT_WEBHOOK_MAX_BACKOFF" default:"1h"`
+		Timeout              time.Duration `envconfig:"MERCHANT_WEBHOOK_TIMEOUT" default:"10s"`
+		AllowPrivateNetworks bool          `envconfig:"MERCHANT_WEBHOOK_ALLOW_PRIVATE_NETWORKS" default:"false"`
+	}
+
+	// MetricsSpecification configures the telemetry. TracesExporter is otlp,
+	// jaeger (OTLP to the Jaeger collector), stdout or none; MetricsExporter
+	// lists otlp, prometheus (scraped on /metrics of Port) or none. The OTLP
+	// exporters send to Url over Protocol, grpc or http/protobuf. SampleRatio
+	// is the share of new traces sampled, traces from callers keep their
+	// decision, and Propagators lists tracecontext, baggage, b3 or b3multi.
 	MetricsSpecification struct {
-		Name            string `envconfig:"OTEL_SERVICE_NAME" default:"go-payments-api"`
-		Url             string `envconfig:"OTEL_EXPORTER_JAEGER_ENDPOINT" default:"http://localhost:4317"`
-		Token           string `envconfig:"SPLUNK_ACCESS_TOKEN"`
-		Resource        string `envconfig:"OTEL_RESOURCE_ATTRIBUTES" default:"service.name=go-payments-api"`
-		TracesExporter  string `envconfig:"OTEL_TRACES_EXPORTER" default:"jaeger"`
-		MetricsExporter string `envconfig:"OTEL_METRICS_EXPORTER" default:""`
+		Name            string   `envconfig:"OTEL_SERVICE_NAME" default:"go-payments-api"`
+		Url             string   `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"http://localhost:4317"`
+		Protocol        string   `envconfig:"OTEL_EXPORTER_OTLP_PROTOCOL" default:"grpc"`
+		Token           string   `envconfig:"SPLUNK_ACCESS_TOKEN"`
+		Resource        string   `envconfig:"OTEL_RESOURCE_ATTRIBUTES" default:"service.name=go-payments-api"`
+		TracesExporter  string   `envconfig:"OTEL_TRACES_EXPORTER" default:"jaeger"`
+		SampleRatio     float64  `envconfig:"OTEL_TRACES_SAMPLER_ARG" default:"1"`
+		Propagators     []string `envconfig:"OTEL_PROPAGATORS" default:"tracecontext,baggage"`
+		MetricsExporter []string `envconfig:"OTEL_METRICS_EXPORTER" default:"prometheus"`
+		// Port serves the scrapes apart from the API, so it can be left
+		// out of the public ingress
+		Port string `envconfig:"METRICS_PORT" default:":9464"`
 	}
 )
 
+// KafkaTopic is an entry of the topics manifest.
+type KafkaTopic struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+}
+
+// KafkaTopics is the manifest of the topics created on startup, written as
+// comma-separated "name:partitions:replication-factor" entries.
+type KafkaTopics []KafkaTopic
+
+func (t *KafkaTopics) Decode(value string) error {
+	*t = nil
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid topic %q, want name:partitions:replication-factor", entry)
+		}
+
+		partitions, err := strconv.Atoi(parts[1])
+		if err != nil || partitions < 1 {
+			return fmt.Errorf("invalid partitions in topic %q", entry)
+		}
+		replicationFactor, err := strconv.Atoi(parts[2])
+		if err != nil || replicationFactor < 1 {
+			return fmt.Errorf("invalid replication factor in topic %q", entry)
+		}
+
+		*t = append(*t, KafkaTopic{Name: parts[0], Partitions: partitions, ReplicationFactor: replicationFactor})
+	}
+	return nil
+}
+
+// rateLimitKeys are what requests can be limited by: the client IP, the API
+// key or JWT subject, or the merchant.
+var rateLimitKeys = map[string]bool{"ip": true, "api_key": true, "merchant": true}
+
+// RateLimitRules are written as comma-separated "requests/period:burst:key"
+// rules, e.g. "50/1s:100:api_key,10000/24h:10000:merchant", with key being
+// ip, api_key or merchant.
+type RateLimitRules []ratelimit.Rule
+
+func (r *RateLimitRules) Decode(value string) error {
+	*r = nil
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid rate limit %q, want requests/period:burst:key", entry)
+		}
+
+		limit, err := ratelimit.ParseLimit(parts[0])
+		if err != nil {
+			return err
+		}
+		limit.Burst, err = strconv.Atoi(parts[1])
+		if err != nil || limit.Burst < 1 {
+			return fmt.Errorf("invalid burst in rate limit %q", entry)
+		}
+		if !rateLimitKeys[parts[2]] {
+			return fmt.Errorf("invalid key in rate limit %q, want ip, api_key or merchant", entry)
+		}
+
+		*r = append(*r, ratelimit.Rule{Limit: limit, By: parts[2]})
+	}
+	return nil
+}
+
+// LogSampling is written as comma-separated "level:first:thereafter[:tick]"
+// entries, e.g. "debug:100:10:1s", writing per tick the first lines of the
+// level from each log call and then one of every thereafter. Ticks default
+// to one second and fatal lines can't be sampled.
+type LogSampling map[log.Level]log.Sampling
+
+func (s *LogSampling) Decode(value string) error {
+	*s = LogSampling{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 && len(parts) != 4 {
+			return fmt.Errorf("invalid log sampling %q, want level:first:thereafter[:tick]", entry)
+		}
+
+		level, err := log.LevelFromString(parts[0])
+		if err != nil {
+			return err
+		}
+		if level == log.LevelFatal {
+			return fmt.Errorf("invalid log sampling %q, fatal lines can't be sampled", entry)
+		}
+
+		var sampling log.Sampling
+		if sampling.First, err = strconv.Atoi(parts[1]); err != nil || sampling.First < 0 {
+			return fmt.Errorf("invalid first in log sampling %q", entry)
+		}
+		if sampling.Thereafter, err = strconv.Atoi(parts[2]); err != nil || sampling.Thereafter < 0 {
+			return fmt.Errorf("invalid thereafter in log sampling %q", entry)
+		}
+		if len(parts) == 4 {
+			if sampling.Tick, err = time.ParseDuration(parts[3]); err != nil || sampling.Tick <= 0 {
+				return fmt.Errorf("invalid tick in log sampling %q", entry)
+			}
+		}
+
+		(*s)[level] = sampling
+	}
+	return nil
+}
+
+// CorsRoutes replaces the allowed origins of the routes under path
+// prefixes, written as semicolon-separated "prefix=origin,origin" entries,
+// e.g. "/v1/payments/admin=https://admin.example.com".
+type CorsRoutes map[string][]string
+
+func (r *CorsRoutes) Decode(value string) error {
+	*r = CorsRoutes{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, origins, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("invalid CORS route %q, want /prefix=origin,origin", entry)
+		}
+
+		for _, origin := range strings.Split(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				(*r)[prefix] = append((*r)[prefix], origin)
+			}
+		}
+	}
+	return nil
+}
+
 var Settings Specification
 
 func Init() {
@@ -58,3 +388,7 @@ func (s *Specification) IsProduction() bool {
 func (s *Specification) IsLocal() bool {
 	return s.Environment == "local"
 }
+
+func (s *Specification) IsDevelopment() bool {
+	return s.Environment == "dev" || s.IsLocal()
+}
@@ -1 +1,79 @@
 package settings
+
+import (
+	"testing"
+	"time"
+
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaTopicsDecode(t *testing.T) {
+	var topics KafkaTopics
+
+	assert.NoError(t, topics.Decode("payment.events:3:1, payment.status.updates.dlq:1:3,"))
+	assert.Equal(t, KafkaTopics{
+		{Name: "payment.events", Partitions: 3, ReplicationFactor: 1},
+		{Name: "payment.status.updates.dlq", Partitions: 1, ReplicationFactor: 3},
+	}, topics)
+
+	assert.NoError(t, topics.Decode(""))
+	assert.Empty(t, topics)
+
+	for _, invalid := range []string{"payment.events", "payment.events:3", "payment.events:0:1", "payment.events:3:x"} {
+		assert.Error(t, topics.Decode(invalid), invalid)
+	}
+}
+
+func TestRateLimitRulesDecode(t *testing.T) {
+	var rules RateLimitRules
+
+	assert.NoError(t, rules.Decode("50/1s:100:api_key, 10000/24h:10000:merchant"))
+	assert.Equal(t, RateLimitRules{
+		{Limit: ratelimit.Limit{Requests: 50, Period: time.Second, Burst: 100}, By: "api_key"},
+		{Limit: ratelimit.Limit{Requests: 10000, Period: 24 * time.Hour, Burst: 10000}, By: "merchant"},
+	}, rules)
+
+	assert.NoError(t, rules.Decode(""))
+	assert.Empty(t, rules)
+
+	for _, invalid := range []string{"50/1s", "50/1s:100", "50:100:ip", "50/1s:0:ip", "50/1s:100:user"} {
+		assert.Error(t, rules.Decode(invalid), invalid)
+	}
+}
+
+func TestLogSamplingDecode(t *testing.T) {
+	var sampling LogSampling
+
+	assert.NoError(t, sampling.Decode("debug:100:10:5s, info:1000:0"))
+	assert.Equal(t, LogSampling{
+		log.LevelDebug: {First: 100, Thereafter: 10, Tick: 5 * time.Second},
+		log.LevelInfo:  {First: 1000},
+	}, sampling)
+
+	assert.NoError(t, sampling.Decode(""))
+	assert.Empty(t, sampling)
+
+	for _, invalid := range []string{"debug:100", "trace:1:1", "fatal:1:1", "info:-1:1", "info:1:x", "info:1:1:0s"} {
+		assert.Error(t, sampling.Decode(invalid), invalid)
+	}
+}
+
+func TestCorsRoutesDecode(t *testing.T) {
+	var routes CorsRoutes
+
+	assert.NoError(t, routes.Decode("/v1/payments/admin=https://admin.example.com, https://ops.example.com; /v1/payments/payments=*"))
+	assert.Equal(t, CorsRoutes{
+		"/v1/payments/admin":    {"https://admin.example.com", "https://ops.example.com"},
+		"/v1/payments/payments": {"*"},
+	}, routes)
+
+	assert.NoError(t, routes.Decode(""))
+	assert.Empty(t, routes)
+
+	for _, invalid := range []string{"https://admin.example.com", "v1/payments=*"} {
+		assert.Error(t, routes.Decode(invalid), invalid)
+	}
+}
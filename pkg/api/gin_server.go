@@ -5,23 +5,88 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+
+	log "go-payments-api/pkg/log/implement"
+	"go-payments-api/pkg/redact"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 type GinServer[T any] struct {
 	router T
 	server *http.Server
+	logger log.Logger
+}
+
+type GinConfig struct {
+	// Service names the server in the traces
+	Service string
+	// TrustedProxies are the IPs or CIDRs of the proxies whose
+	// X-Forwarded-For and X-Real-IP headers tell the client IP. None is
+	// trusted when empty, so the client IP is the peer address and can't be
+	// forged by clients
+	TrustedProxies []string
+	// UntracedRoutes are left out of the traces, such as the ones called by
+	// probes
+	UntracedRoutes []string
+	// Logger logs the start and the stop of the server, discarded when nil
+	Logger log.Logger
 }
 
-func NewGinServer[T *gin.Engine](httpServer *http.Server) *GinServer[T] {
-	router := gin.Default()
+// NewGinServer returns a gin server traced with OpenTelemetry. Request
+// metrics are left to the middlewares of the routes, so otelgin only traces.
+func NewGinServer[T *gin.Engine](httpServer *http.Server, config GinConfig) (*GinServer[T], error) {
+	untraced := make(map[string]bool, len(config.UntracedRoutes))
+	for _, route := range config.UntracedRoutes {
+		untraced[route] = true
+	}
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	router.Use(
+		gin.LoggerWithFormatter(logFormatter),
+		gin.Recovery(),
+		otelgin.Middleware(config.Service,
+			otelgin.WithMeterProvider(noop.NewMeterProvider()),
+			otelgin.WithGinFilter(func(ctx *gin.Context) bool { return !untraced[ctx.FullPath()] }),
+		),
+	)
 	httpServer.Handler = router
 
+	logger := config.Logger
+	if logger == nil {
+		logger = log.Discard()
+	}
+
 	return &GinServer[T]{
 		router: router,
 		server: httpServer,
+		logger: logger,
+	}, nil
+}
+
+// logFormatter writes the request lines as gin does, with the sensitive data
+// of paths and query strings, such as emails in filters, redacted.
+func logFormatter(param gin.LogFormatterParams) string {
+	path := param.Path
+	if unescaped, err := url.QueryUnescape(path); err == nil {
+		path = unescaped
 	}
+
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		redact.String(path),
+		redact.String(param.ErrorMessage),
+	)
 }
 
 func (s *GinServer[T]) GetRouter() T {
@@ -29,12 +94,12 @@ func (s *GinServer[T]) GetRouter() T {
 }
 
 func (s *GinServer[T]) Start() error {
-	fmt.Printf("Starting web server on port %s", s.server.Addr)
+	s.logger.Infof("starting web server on port %s", s.server.Addr)
 	return s.server.ListenAndServe()
 }
 
 func (s *GinServer[T]) Shutdown(ctx context.Context) error {
-	fmt.Printf("Stopping web server")
+	s.logger.Infof("stopping web server")
 	return s.server.Shutdown(ctx)
 }
 
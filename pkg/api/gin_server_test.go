@@ -1,35 +1,110 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	log "go-payments-api/pkg/log/implement"
 	"go-payments-api/test"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var testServerPort = "3008"
 
 func TestNewGinServer(t *testing.T) {
 	httpServer := &http.Server{}
-	ginServer := NewGinServer(httpServer)
+	ginServer, _ := NewGinServer(httpServer, GinConfig{Service: "go-payments-api"})
 
 	assert.Equal(t, ginServer.server, httpServer)
 	assert.Equal(t, ginServer.router, httpServer.Handler)
 	assert.Len(t, ginServer.router.Routes(), 0)
 }
 
+func TestNewGinServerTraces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ginServer, _ := NewGinServer(&http.Server{}, GinConfig{Service: "go-payments-api", UntracedRoutes: []string{"/health"}})
+	router := ginServer.GetRouter()
+	router.GET("/payments/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	router.GET("/health", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/payments/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Name(), "/payments/:id")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestNewGinServerClientIP(t *testing.T) {
+	cases := map[string]struct {
+		proxies []string
+		want    string
+	}{
+		"no trusted proxy":    {want: "10.0.0.1"},
+		"trusted proxy":       {proxies: []string{"10.0.0.0/8"}, want: "203.0.113.7"},
+		"other trusted proxy": {proxies: []string{"192.168.0.1"}, want: "10.0.0.1"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ginServer, err := NewGinServer(&http.Server{}, GinConfig{TrustedProxies: tc.proxies})
+			assert.NoError(t, err)
+
+			var clientIP string
+			router := ginServer.GetRouter()
+			router.GET("/ip", func(ctx *gin.Context) { clientIP = ctx.ClientIP() })
+
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			req.RemoteAddr = "10.0.0.1:4321"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.want, clientIP)
+		})
+	}
+
+	_, err := NewGinServer(&http.Server{}, GinConfig{TrustedProxies: []string{"not an ip"}})
+	assert.Error(t, err)
+}
+
+func TestLogFormatter(t *testing.T) {
+	line := logFormatter(gin.LogFormatterParams{
+		TimeStamp:  time.Date(2024, 11, 13, 10, 30, 0, 0, time.UTC),
+		StatusCode: http.StatusOK,
+		ClientIP:   "127.0.0.1",
+		Method:     http.MethodGet,
+		Path:       "/v1/payments/payments?email=payer%40example.com&status=COMPLETED",
+	})
+
+	assert.Contains(t, line, "[GIN] 2024/11/13 - 10:30:00 | 200 |")
+	assert.Contains(t, line, "status=COMPLETED")
+	assert.NotContains(t, line, "payer")
+}
+
 func TestGinServerRegisterRoutes(t *testing.T) {
 	httpServer := &http.Server{}
-	ginServer := NewGinServer(httpServer)
+	ginServer, _ := NewGinServer(httpServer, GinConfig{Service: "go-payments-api"})
 
 	assert.IsType(t, ginServer.GetRouter(), &gin.Engine{})
 }
@@ -40,7 +115,7 @@ func TestGinServerStartError(t *testing.T) {
 	httpServer := &http.Server{
 		Addr: ":error",
 	}
-	ginServer := NewGinServer(httpServer)
+	ginServer, _ := NewGinServer(httpServer, GinConfig{Service: "go-payments-api"})
 
 	err := ginServer.Start()
 	assert.Error(t, err)
@@ -48,7 +123,7 @@ func TestGinServerStartError(t *testing.T) {
 
 func TestGinServerStartAndShutdown(t *testing.T) {
 	httpServer := &http.Server{Addr: ":" + testServerPort}
-	ginServer := NewGinServer(httpServer)
+	ginServer, _ := NewGinServer(httpServer, GinConfig{Service: "go-payments-api"})
 
 	ginServer.GetRouter().GET("/test", func(c *gin.Context) {
 		c.Data(http.StatusOK, "text/plain", []byte("up"))
@@ -87,11 +162,16 @@ func TestGinServerStartAndShutdown(t *testing.T) {
 }
 
 func TestGinServerShutdown(t *testing.T) {
+	var output bytes.Buffer
 	httpServer := &http.Server{Addr: ":" + testServerPort}
-	ginServer := NewGinServer(httpServer)
+	ginServer, _ := NewGinServer(httpServer, GinConfig{
+		Service: "go-payments-api",
+		Logger:  log.NewLogrus(log.Output(&output)),
+	})
 
 	err := ginServer.Shutdown(context.Background())
 	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "stopping web server")
 }
 
 func TestMockGin(t *testing.T) {
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/pkg/redact"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcError converts err into a gRPC status error with the code matching
+// its pkg/errors type, the way the JSON presenter picks HTTP statuses.
+// Errors that already carry a status are returned unchanged.
+func GrpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validation *appErr.Validation
+	if errors.As(err, &validation) {
+		return validationStatus(validation)
+	}
+
+	return status.Error(grpcCode(err), redact.String(err.Error()))
+}
+
+func grpcCode(err error) codes.Code {
+	var httpErr *appErr.Http
+	switch {
+	case errors.As(err, &httpErr):
+		if code, ok := httpCodes[httpErr.Code]; ok {
+			return code
+		}
+		return codes.Unknown
+	case errors.As(err, new(appErr.NotFound)):
+		return codes.NotFound
+	case errors.As(err, new(appErr.BadFormat)):
+		return codes.InvalidArgument
+	case errors.As(err, new(appErr.Conflict)), errors.As(err, new(appErr.Unprocessable)):
+		return codes.FailedPrecondition
+	case errors.As(err, new(appErr.Unauthorized)):
+		return codes.Unauthenticated
+	case errors.As(err, new(appErr.Forbidden)):
+		return codes.PermissionDenied
+	case errors.As(err, new(appErr.TooManyRequests)):
+		return codes.ResourceExhausted
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
+
+// httpCodes maps the statuses of appErr.Http errors; other statuses are
+// codes.Unknown.
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+}
+
+func validationStatus(validation *appErr.Validation) error {
+	st := status.New(codes.InvalidArgument, validation.Error())
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validation.Errors))
+	for _, message := range validation.Errors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       message.Field,
+			Description: message.Message,
+		})
+	}
+
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
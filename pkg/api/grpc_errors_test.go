@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	appErr "go-payments-api/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: appErr.NewNotFound("payment 1 not found"), want: codes.NotFound},
+		{err: appErr.NewBadFormat("invalid id"), want: codes.InvalidArgument},
+		{err: appErr.NewConflict("already refunded"), want: codes.FailedPrecondition},
+		{err: appErr.NewUnprocessable("invalid transition"), want: codes.FailedPrecondition},
+		{err: appErr.NewUnauthorized("missing credentials"), want: codes.Unauthenticated},
+		{err: appErr.NewForbidden("not allowed"), want: codes.PermissionDenied},
+		{err: appErr.NewTooManyRequests("rate limit exceeded"), want: codes.ResourceExhausted},
+		{err: appErr.NewInternalServer("boom"), want: codes.Internal},
+		{err: appErr.HttpBadRequest("invalid body"), want: codes.InvalidArgument},
+		{err: appErr.NewHttp(http.StatusTooManyRequests, "slow down"), want: codes.ResourceExhausted},
+		{err: appErr.NewHttp(http.StatusTeapot, "teapot"), want: codes.Unknown},
+		{err: fmt.Errorf("get payment: %w", appErr.NewNotFound("payment 1 not found")), want: codes.NotFound},
+		{err: context.Canceled, want: codes.Canceled},
+		{err: context.DeadlineExceeded, want: codes.DeadlineExceeded},
+		{err: errors.New("database down"), want: codes.Internal},
+		{err: status.Error(codes.Aborted, "aborted"), want: codes.Aborted},
+	}
+
+	for _, tc := range cases {
+		err := GrpcError(tc.err)
+		assert.Equal(t, tc.want, status.Code(err), tc.err.Error())
+	}
+
+	assert.NoError(t, GrpcError(nil))
+}
+
+func TestGrpcErrorValidation(t *testing.T) {
+	validation := appErr.NewValidation().(*appErr.Validation)
+	validation.AddError(appErr.NewValidationMessage("amount", "required", "amount is required"))
+
+	st := status.Convert(GrpcError(validation))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	details := st.Details()
+	assert.Len(t, details, 1)
+
+	badRequest := details[0].(*errdetails.BadRequest)
+	assert.Equal(t, "amount", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "amount is required", badRequest.FieldViolations[0].Description)
+}
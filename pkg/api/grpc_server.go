@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+
+	log "go-payments-api/pkg/log/implement"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcServer[T any] struct {
+	router T
+	server *grpc.Server
+	addr   string
+	logger log.Logger
+}
+
+// NewGrpcServer returns a gRPC server traced with OpenTelemetry that turns
+// pkg/errors errors into statuses and panics into Internal errors, logged to
+// logger with their stack. A nil logger discards the logs.
+func NewGrpcServer[T *grpc.Server](addr string, logger log.Logger, opts ...grpc.ServerOption) *GrpcServer[T] {
+	if logger == nil {
+		logger = log.Discard()
+	}
+
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryErrorInterceptor(logger)),
+		grpc.ChainStreamInterceptor(streamErrorInterceptor(logger)),
+	}, opts...)
+
+	server := grpc.NewServer(opts...)
+
+	return &GrpcServer[T]{
+		router: server,
+		server: server,
+		addr:   addr,
+		logger: logger,
+	}
+}
+
+func (s *GrpcServer[T]) GetRouter() T {
+	return s.router
+}
+
+func (s *GrpcServer[T]) Start() error {
+	s.logger.Infof("starting gRPC server on port %s", s.addr)
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.server.Serve(listener)
+}
+
+// Shutdown waits for pending RPCs until ctx is done and then cancels the
+// ones still running, such as open WatchPayment streams.
+func (s *GrpcServer[T]) Shutdown(ctx context.Context) error {
+	s.logger.Infof("stopping gRPC server")
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+func unaryErrorInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer recoverPanic(logger.WithContext(ctx), &err)
+
+		resp, err = handler(ctx, req)
+		return resp, GrpcError(err)
+	}
+}
+
+func streamErrorInterceptor(logger log.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		l := logger
+		if stream != nil {
+			l = logger.WithContext(stream.Context())
+		}
+		defer recoverPanic(l, &err)
+
+		return GrpcError(handler(srv, stream))
+	}
+}
+
+func recoverPanic(logger log.Logger, err *error) {
+	if r := recover(); r != nil {
+		logger.Errorf("panic serving gRPC request: %v\n%s", r, debug.Stack())
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-payments-api/test"
+
+	appErr "go-payments-api/pkg/errors"
+	log "go-payments-api/pkg/log/implement"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewGrpcServer(t *testing.T) {
+	grpcServer := NewGrpcServer[*grpc.Server](":9090", nil)
+
+	assert.Equal(t, grpcServer.server, grpcServer.GetRouter())
+	assert.Empty(t, grpcServer.GetRouter().GetServiceInfo())
+}
+
+func TestGrpcServerStartError(t *testing.T) {
+	test.Setup(t, nil)
+
+	grpcServer := NewGrpcServer[*grpc.Server](":error", nil)
+
+	err := grpcServer.Start()
+	assert.Error(t, err)
+}
+
+func TestGrpcServerStartAndShutdown(t *testing.T) {
+	grpcServer := NewGrpcServer[*grpc.Server]("localhost:0", nil)
+
+	started := make(chan error)
+	go func() {
+		started <- grpcServer.Start()
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	err := grpcServer.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, <-started)
+}
+
+func TestGrpcServerShutdownTimeout(t *testing.T) {
+	grpcServer := NewGrpcServer[*grpc.Server]("localhost:0", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// With nothing to drain GracefulStop may win the race
+	err := grpcServer.Shutdown(ctx)
+	if err != nil {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	_, err := unaryErrorInterceptor(log.Discard())(context.Background(), nil, nil, func(context.Context, interface{}) (interface{}, error) {
+		return nil, appErr.NewNotFound("payment 1 not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = unaryErrorInterceptor(log.Discard())(context.Background(), nil, nil, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	resp, err := unaryErrorInterceptor(log.Discard())(context.Background(), nil, nil, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestStreamErrorInterceptor(t *testing.T) {
+	err := streamErrorInterceptor(log.Discard())(nil, nil, nil, func(interface{}, grpc.ServerStream) error {
+		return appErr.NewForbidden("not allowed")
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	var output bytes.Buffer
+	err = streamErrorInterceptor(log.NewLogrus(log.Output(&output)))(nil, nil, nil, func(interface{}, grpc.ServerStream) error {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, output.String(), "panic serving gRPC request: boom")
+}
+
+// tracedStream is a stream whose context carries the trace with traceID.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+func newTracedStream(traceID byte) tracedStream {
+	if traceID == 0 {
+		return tracedStream{ctx: context.Background()}
+	}
+
+	return tracedStream{ctx: trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{15: traceID},
+		SpanID:  trace.SpanID{7: traceID},
+	}))}
+}
+
+func TestStreamErrorInterceptorConcurrentStreams(t *testing.T) {
+	var output bytes.Buffer
+	interceptor := streamErrorInterceptor(log.NewLogrus(log.WithFormat(log.FormatJSON), log.Output(&output)))
+
+	// Stream 0 is untraced and must not log the trace of another stream
+	var streams sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		streams.Add(1)
+		go func(i int) {
+			defer streams.Done()
+			err := interceptor(nil, newTracedStream(byte(i)), nil, func(interface{}, grpc.ServerStream) error {
+				panic(fmt.Sprintf("boom %d", i))
+			})
+			assert.Equal(t, codes.Internal, status.Code(err))
+		}(i)
+	}
+	streams.Wait()
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 8)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+		var i int
+		_, err := fmt.Sscanf(entry["message"].(string), "panic serving gRPC request: boom %d", &i)
+		assert.NoError(t, err)
+
+		if i == 0 {
+			assert.NotContains(t, entry, "trace_id")
+			continue
+		}
+		assert.Equal(t, trace.TraceID{15: byte(i)}.String(), entry["trace_id"])
+	}
+}
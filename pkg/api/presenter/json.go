@@ -1,14 +1,19 @@
 package presenter
 
 import (
+	"errors"
 	"net/http"
 
 	appErr "go-payments-api/pkg/errors"
 	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/redact"
 
 	"github.com/gin-gonic/gin"
 )
 
+// TraceIDHeader is the response header NewJson puts trace IDs in.
+const TraceIDHeader = "X-Trace-ID"
+
 type JsonConfig struct {
 	IncludeTraceIDInHeader bool
 	IncludeTraceIDInBody   bool
@@ -24,7 +29,7 @@ func NewJson() Json {
 		config: JsonConfig{
 			IncludeTraceIDInHeader: true,
 			IncludeTraceIDInBody:   false,
-			TraceIDHeaderName:      "X-Trace-ID",
+			TraceIDHeaderName:      TraceIDHeader,
 		},
 	}
 }
@@ -50,15 +55,38 @@ func (j Json) setTraceID(c *gin.Context, response gin.H) {
 
 func (j Json) Error(c *gin.Context, err error) {
 	code := http.StatusInternalServerError
-	response := gin.H{"error": err.Error()}
+	response := gin.H{"error": redact.String(err.Error())}
+
+	var validation *appErr.Validation
+	var httpErr *appErr.Http
+	switch {
+	case errors.As(err, &validation):
+		code = http.StatusBadRequest
+		response["messages"] = validation.Errors
+
+	case errors.As(err, &httpErr):
+		code = httpErr.Code
+
+	case errors.As(err, new(appErr.NotFound)):
+		code = http.StatusNotFound
 
-	switch e := err.(type) {
-	case *appErr.Validation:
+	case errors.As(err, new(appErr.BadFormat)):
 		code = http.StatusBadRequest
-		response["messages"] = e.Errors
 
-	case *appErr.Http:
-		code = e.Code
+	case errors.As(err, new(appErr.Conflict)):
+		code = http.StatusConflict
+
+	case errors.As(err, new(appErr.Unprocessable)):
+		code = http.StatusUnprocessableEntity
+
+	case errors.As(err, new(appErr.Unauthorized)):
+		code = http.StatusUnauthorized
+
+	case errors.As(err, new(appErr.Forbidden)):
+		code = http.StatusForbidden
+
+	case errors.As(err, new(appErr.TooManyRequests)):
+		code = http.StatusTooManyRequests
 	}
 
 	j.setTraceID(c, response)
@@ -0,0 +1,91 @@
+package presenter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go-payments-api/pkg/api"
+	appErr "go-payments-api/pkg/errors"
+	"go-payments-api/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonError(t *testing.T) {
+	test.Setup(t, nil)
+
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "generic error", err: errors.New("boom"), want: http.StatusInternalServerError},
+		{name: "http error", err: appErr.HttpBadRequest("bad"), want: http.StatusBadRequest},
+		{name: "validation error", err: appErr.NewValidation(), want: http.StatusBadRequest},
+		{name: "not found error", err: appErr.NewNotFound("missing"), want: http.StatusNotFound},
+		{name: "bad format error", err: appErr.NewBadFormat("invalid cursor"), want: http.StatusBadRequest},
+		{name: "conflict error", err: appErr.NewConflict("invalid transition"), want: http.StatusConflict},
+		{name: "unprocessable error", err: appErr.NewUnprocessable("key reused"), want: http.StatusUnprocessableEntity},
+		{name: "unauthorized error", err: appErr.NewUnauthorized("invalid signature"), want: http.StatusUnauthorized},
+		{name: "forbidden error", err: appErr.NewForbidden("not allowed"), want: http.StatusForbidden},
+		{name: "too many requests error", err: appErr.NewTooManyRequests("rate limit exceeded"), want: http.StatusTooManyRequests},
+		{name: "wrapped not found error", err: fmt.Errorf("failed to get payment: %w", appErr.NewNotFound("payment not found")), want: http.StatusNotFound},
+		{name: "wrapped conflict error", err: fmt.Errorf("failed to refund payment: %w", appErr.NewConflict("nothing left to refund")), want: http.StatusConflict},
+		{name: "wrapped http error", err: fmt.Errorf("provider: %w", appErr.HttpBadRequest("bad")), want: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _, recorder := api.MockGin()
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+			NewJson().Error(ctx, tc.err)
+
+			assert.Equal(t, tc.want, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tc.err.Error())
+		})
+	}
+}
+
+func TestJsonErrorWrappedValidation(t *testing.T) {
+	test.Setup(t, nil)
+
+	ctx, _, recorder := api.MockGin()
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	validation := &appErr.Validation{Errors: []appErr.ValidationMessage{
+		appErr.NewValidationMessage("amount", "required", "amount is required"),
+	}}
+	NewJson().Error(ctx, fmt.Errorf("invalid payment: %w", validation))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "amount is required")
+}
+
+func TestJsonErrorRedacts(t *testing.T) {
+	test.Setup(t, nil)
+
+	ctx, _, recorder := api.MockGin()
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	NewJson().Error(ctx, errors.New("pq: duplicate card 4111111111111111 for payer@example.com"))
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "4111111111111111")
+	assert.NotContains(t, recorder.Body.String(), "payer@example.com")
+	assert.Contains(t, recorder.Body.String(), "****1111")
+}
+
+func TestJsonPresent(t *testing.T) {
+	test.Setup(t, nil)
+
+	ctx, _, recorder := api.MockGin()
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	NewJson().Present(ctx, map[string]bool{"ok": true}, http.StatusOK)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"ok":true}`, recorder.Body.String())
+}
@@ -0,0 +1,175 @@
+// Package brcode builds PIX BR Code payloads, the EMV-MPM "copia e cola"
+// strings the Brazilian Central Bank specifies for PIX QR codes.
+package brcode
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	pixGUI          = "br.gov.bcb.pix"
+	brlCurrencyCode = "986"
+	countryCode     = "BR"
+
+	maxMerchantName = 25
+	maxMerchantCity = 15
+	maxTxID         = 25
+	maxValue        = 99
+)
+
+// Payload holds the fields of a PIX BR Code.
+type Payload struct {
+	// Key is the receiver PIX key
+	Key          string
+	MerchantName string
+	MerchantCity string
+
+	// Amount is the decimal amount in reais, e.g. "150.75". Empty lets the
+	// payer type it.
+	Amount string
+
+	// TxID identifies the charge, up to 25 letters and digits. Empty is
+	// encoded as *** as the spec requires.
+	TxID string
+
+	// OneTime marks the code as valid for a single payment
+	OneTime bool
+}
+
+// Encode returns the BR Code string, ending with its CRC16 checksum.
+// Merchant name and city lose their accents, as EMV lengths count bytes and
+// payer apps expect ASCII, and are trimmed to the spec limits.
+func (p Payload) Encode() (string, error) {
+	if p.Key == "" {
+		return "", fmt.Errorf("brcode: key is required")
+	}
+
+	txID := p.TxID
+	if txID == "" {
+		txID = "***"
+	} else if len(txID) > maxTxID || !isAlphanumeric(txID) {
+		return "", fmt.Errorf("brcode: txid must have up to %d letters and digits", maxTxID)
+	}
+
+	account, err := field("00", pixGUI)
+	if err != nil {
+		return "", err
+	}
+	key, err := field("01", p.Key)
+	if err != nil {
+		return "", err
+	}
+	additional, err := field("05", txID)
+	if err != nil {
+		return "", err
+	}
+
+	fields := []struct {
+		id    string
+		value string
+	}{
+		{"00", "01"},
+		{"01", ""},
+		{"26", account + key},
+		{"52", "0000"},
+		{"53", brlCurrencyCode},
+		{"54", p.Amount},
+		{"58", countryCode},
+		{"59", truncate(ascii(p.MerchantName), maxMerchantName)},
+		{"60", truncate(ascii(p.MerchantCity), maxMerchantCity)},
+		{"62", additional},
+	}
+	if p.OneTime {
+		fields[1].value = "12"
+	}
+
+	var sb strings.Builder
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+
+		encoded, err := field(f.id, f.value)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(encoded)
+	}
+
+	// The checksum covers the CRC field id and length too
+	sb.WriteString("6304")
+	sb.WriteString(fmt.Sprintf("%04X", CRC16(sb.String())))
+
+	return sb.String(), nil
+}
+
+// QRCode renders the payload as a size x size pixels PNG image.
+func QRCode(payload string, size int) ([]byte, error) {
+	return qrcode.Encode(payload, qrcode.Medium, size)
+}
+
+// CRC16 computes the CRC16-CCITT checksum (polynomial 0x1021, initial value
+// 0xFFFF) of s.
+func CRC16(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// field encodes an EMV TLV field: id, two digits length and value.
+func field(id, value string) (string, error) {
+	if len(value) > maxValue {
+		return "", fmt.Errorf("brcode: field %s is longer than %d bytes", id, maxValue)
+	}
+	return fmt.Sprintf("%s%02d%s", id, len(value), value), nil
+}
+
+// ascii strips the accents of s, so "São Paulo" becomes "Sao Paulo", and
+// drops the characters left outside ASCII.
+func ascii(s string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn))), s)
+	if err != nil {
+		stripped = s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, stripped)
+}
+
+// truncate cuts s to max bytes, which are also characters once s went
+// through ascii.
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
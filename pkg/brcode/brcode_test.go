@@ -0,0 +1,77 @@
+package brcode
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	// Example from the BR Code manual of the Central Bank
+	payload, err := Payload{
+		Key:          "123e4567-e12b-12d1-a456-426655440000",
+		MerchantName: "Fulano de Tal",
+		MerchantCity: "BRASILIA",
+	}.Encode()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", payload)
+}
+
+func TestEncodeCharge(t *testing.T) {
+	payload, err := Payload{
+		Key:          "payments@example.com",
+		MerchantName: "Go Payments Marketplace Ltda",
+		MerchantCity: "Sao Paulo",
+		Amount:       "150.75",
+		TxID:         "PAY0000000000000000000042",
+		OneTime:      true,
+	}.Encode()
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(payload, "000201010212"))
+	assert.Contains(t, payload, "5406150.75")
+	assert.Contains(t, payload, "5925Go Payments Marketplace ")
+	assert.Contains(t, payload, "62290525PAY0000000000000000000042")
+
+	body, crc := payload[:len(payload)-4], payload[len(payload)-4:]
+	assert.True(t, strings.HasSuffix(body, "6304"))
+	assert.Equal(t, fmt.Sprintf("%04X", CRC16(body)), crc)
+}
+
+func TestEncodeAccents(t *testing.T) {
+	payload, err := Payload{
+		Key:          "payments@example.com",
+		MerchantName: "Padaria Pão de Açúcar Ltda ME",
+		MerchantCity: "São José dos Campos",
+	}.Encode()
+
+	assert.NoError(t, err)
+	assert.Contains(t, payload, "5925Padaria Pao de Acucar Ltd")
+	assert.Contains(t, payload, "6015Sao Jose dos Ca")
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	_, err := Payload{}.Encode()
+	assert.Error(t, err)
+
+	_, err = Payload{Key: "key", TxID: "not-alphanumeric"}.Encode()
+	assert.Error(t, err)
+
+	_, err = Payload{Key: "key", TxID: strings.Repeat("A", 26)}.Encode()
+	assert.Error(t, err)
+}
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), CRC16("123456789"))
+}
+
+func TestQRCode(t *testing.T) {
+	png, err := QRCode("00020126580014br.gov.bcb.pix", 128)
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG")))
+}
@@ -15,6 +15,10 @@ type CacheItem struct {
 	Expiration int64
 }
 
+func (i CacheItem) expired(now int64) bool {
+	return now > i.Expiration
+}
+
 func NewLocalCache() *LocalCache {
 	return &LocalCache{
 		store: make(map[string]CacheItem),
@@ -33,23 +37,72 @@ func (c *LocalCache) Set(key string, value interface{}, duration time.Duration)
 
 func (c *LocalCache) Get(key string) (interface{}, bool) {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	item, found := c.store[key]
+	c.mu.RUnlock()
+
 	if !found {
 		return nil, false
 	}
 
-	if time.Now().UnixNano() > item.Expiration {
-		delete(c.store, key)
+	if item.expired(time.Now().UnixNano()) {
+		// The read lock can't be upgraded, so the item is checked again in
+		// case it was replaced in between
+		c.mu.Lock()
+		if current, ok := c.store[key]; ok && current.expired(time.Now().UnixNano()) {
+			delete(c.store, key)
+		}
+		c.mu.Unlock()
 		return nil, false
 	}
 
 	return item.Value, true
 }
 
+// Update replaces the value of key with the one fn returns, which expires
+// after the returned duration. fn gets the current value, with found false
+// when key is missing or expired, and runs with the cache locked, so
+// concurrent updates of a key never interleave.
+func (c *LocalCache) Update(key string, fn func(value interface{}, found bool) (interface{}, time.Duration)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	item, found := c.store[key]
+	if found && item.expired(now.UnixNano()) {
+		item, found = CacheItem{}, false
+	}
+
+	value, duration := fn(item.Value, found)
+	c.store[key] = CacheItem{
+		Value:      value,
+		Expiration: now.Add(duration).UnixNano(),
+	}
+}
+
 func (c *LocalCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	delete(c.store, key)
 }
+
+// DeleteExpired removes the expired items, which Get only removes when they
+// are read.
+func (c *LocalCache) DeleteExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now().UnixNano()
+	for key, item := range c.store {
+		if item.expired(now) {
+			delete(c.store, key)
+		}
+	}
+}
+
+// Len returns the number of items, expired ones included.
+func (c *LocalCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.store)
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalCacheGetExpired(t *testing.T) {
+	c := NewLocalCache()
+	c.Set("fresh", 1, time.Minute)
+	c.Set("stale", 2, -time.Second)
+
+	value, found := c.Get("fresh")
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	_, found = c.Get("stale")
+	assert.False(t, found)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLocalCacheUpdate(t *testing.T) {
+	c := NewLocalCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Update("counter", func(value interface{}, found bool) (interface{}, time.Duration) {
+				count, _ := value.(int)
+				return count + 1, time.Minute
+			})
+		}()
+	}
+	wg.Wait()
+
+	value, _ := c.Get("counter")
+	assert.Equal(t, 100, value)
+
+	c.Set("stale", 5, -time.Second)
+	c.Update("stale", func(value interface{}, found bool) (interface{}, time.Duration) {
+		assert.False(t, found)
+		assert.Nil(t, value)
+		return 1, time.Minute
+	})
+}
+
+func TestLocalCacheDeleteExpired(t *testing.T) {
+	c := NewLocalCache()
+	c.Set("fresh", 1, time.Minute)
+	c.Set("stale", 2, -time.Second)
+
+	c.DeleteExpired()
+
+	assert.Equal(t, 1, c.Len())
+}
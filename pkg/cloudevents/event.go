@@ -0,0 +1,92 @@
+// Package cloudevents builds CloudEvents 1.0 envelopes in structured mode,
+// where the whole event, attributes and data, is the message body.
+package cloudevents
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"go-payments-api/pkg/metrics"
+	"time"
+)
+
+const (
+	SpecVersion = "1.0"
+
+	HeaderContentType = "content-type"
+	HeaderTraceParent = "traceparent"
+)
+
+// Event is a CloudEvents 1.0 event. TraceParent is the distributed tracing
+// extension, linking the event to the trace that produced it.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	TraceParent     string          `json:"traceparent,omitempty"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// New returns an event of eventType about subject with a unique id and the
+// JSON of data, traced to the span in ctx.
+func New(ctx context.Context, source, eventType, subject, dataSchema string, data interface{}) (*Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      dataSchema,
+		TraceParent:     metrics.TraceParent(ctx),
+		Data:            payload,
+	}, nil
+}
+
+// ContentType is the content type of a structured mode event written in
+// format, the json, avro or protobuf event format, sent in the content-type
+// header of the message carrying it.
+func ContentType(format string) string {
+	return "application/cloudevents+" + format
+}
+
+// Headers returns the message headers announcing the trace of the event, for
+// consumers that don't read the body to trace it. The content-type header is
+// left to the publisher, which knows the format the event is written in.
+func (e *Event) Headers() map[string]string {
+	headers := map[string]string{}
+	if e.TraceParent != "" {
+		headers[HeaderTraceParent] = e.TraceParent
+	}
+	return headers
+}
+
+// newID returns a random UUID v4.
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
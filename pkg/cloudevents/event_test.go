@@ -0,0 +1,50 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"go-payments-api/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := metrics.WithTraceParent(context.Background(), traceParent)
+
+	event, err := New(ctx, "/payments", "payment.completed", "1", "urn:schema:v1", map[string]int{"id": 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, SpecVersion, event.SpecVersion)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), event.ID)
+	assert.Equal(t, traceParent, event.TraceParent)
+	assert.JSONEq(t, `{"id":1}`, string(event.Data))
+	assert.Equal(t, map[string]string{HeaderTraceParent: traceParent}, event.Headers())
+
+	body, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	var attributes map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &attributes))
+	assert.Equal(t, "1.0", attributes["specversion"])
+	assert.Equal(t, "payment.completed", attributes["type"])
+	assert.Equal(t, "urn:schema:v1", attributes["dataschema"])
+}
+
+func TestNewUniqueIDs(t *testing.T) {
+	first, _ := New(context.Background(), "/payments", "payment.created", "1", "", nil)
+	second, _ := New(context.Background(), "/payments", "payment.created", "1", "", nil)
+
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Empty(t, first.TraceParent)
+	assert.NotContains(t, first.Headers(), HeaderTraceParent)
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/cloudevents+json", ContentType("json"))
+	assert.Equal(t, "application/cloudevents+avro", ContentType("avro"))
+	assert.Equal(t, "application/cloudevents+protobuf", ContentType("protobuf"))
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "strconv"
+
 type PageLength string
 
 const (
@@ -7,3 +9,16 @@ const (
 	FIFTY   PageLength = "50"
 	TEN     PageLength = "10"
 )
+
+// Int returns the page length as an integer, falling back to TEN when the
+// value is not one of the known page lengths.
+func (p PageLength) Int() int {
+	switch p {
+	case HUNDRED, FIFTY, TEN:
+		n, _ := strconv.Atoi(string(p))
+		return n
+	}
+
+	n, _ := strconv.Atoi(string(TEN))
+	return n
+}
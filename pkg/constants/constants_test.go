@@ -0,0 +1,15 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageLengthInt(t *testing.T) {
+	assert.Equal(t, 100, HUNDRED.Int())
+	assert.Equal(t, 50, FIFTY.Int())
+	assert.Equal(t, 10, TEN.Int())
+	assert.Equal(t, 10, PageLength("").Int())
+	assert.Equal(t, 10, PageLength("1000").Int())
+}
@@ -0,0 +1,13 @@
+package errors
+
+type TooManyRequests struct {
+	description string
+}
+
+func NewTooManyRequests(description string) TooManyRequests {
+	return TooManyRequests{description: description}
+}
+
+func (e TooManyRequests) Error() string {
+	return e.description
+}
@@ -0,0 +1,13 @@
+package errors
+
+type Unauthorized struct {
+	description string
+}
+
+func NewUnauthorized(description string) Unauthorized {
+	return Unauthorized{description: description}
+}
+
+func (e Unauthorized) Error() string {
+	return e.description
+}
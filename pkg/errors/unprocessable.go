@@ -0,0 +1,13 @@
+package errors
+
+type Unprocessable struct {
+	description string
+}
+
+func NewUnprocessable(description string) Unprocessable {
+	return Unprocessable{description: description}
+}
+
+func (e Unprocessable) Error() string {
+	return e.description
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"go-payments-api/pkg/netguard"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type Wrapper interface {
@@ -44,6 +47,25 @@ func NewWrapper() *WrapperImpl {
 	}
 }
 
+// NewPublicWrapper returns a Wrapper that only connects to public addresses,
+// for URLs given by clients. Every connection, redirects included, is checked
+// after the host is resolved, and no proxy is used.
+func NewPublicWrapper() *WrapperImpl {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   netguard.Control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	wrapper := NewWrapper()
+	wrapper.Client.Transport = transport
+	return wrapper
+}
+
 func (d *WrapperImpl) Get(ctx context.Context, url string, request Request) (*Response, error) {
 	return d.Request(ctx, http.MethodGet, url, request)
 }
@@ -8,6 +8,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"go-payments-api/pkg/netguard"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,6 +67,18 @@ func TestWrapperRequestWithError(t *testing.T) {
 	assert.Nil(t, response)
 }
 
+func TestPublicWrapperRefusesLoopback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer ts.Close()
+
+	response, err := NewPublicWrapper().Post(context.Background(), ts.URL, Request{Body: []byte(`{}`)})
+
+	assert.ErrorIs(t, err, netguard.ErrNotPublic)
+	assert.Nil(t, response)
+}
+
 func TestWrapperGet(t *testing.T) {
 	mux := http2.NewServeMux()
 	mux.HandleFunc("/test", func(w http2.ResponseWriter, r *http2.Request) {
@@ -1,6 +1,9 @@
 package implement
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // Discard is a convenient function to create a logger that just does nothing,
 // discarding every message that it gets. Useful for testing.
@@ -12,13 +15,30 @@ var _ Logger = discard{}
 
 type discard struct{}
 
-func (discard) Errorf(string, ...interface{}) {}
+func (discard) Debugf(string, ...interface{}) {}
 func (discard) Infof(string, ...interface{})  {}
+func (discard) Warnf(string, ...interface{})  {}
+func (discard) Errorf(string, ...interface{}) {}
+func (discard) Fatalf(string, ...interface{}) {}
+
+func (discard) Level() Level {
+	return LevelInfo
+}
+
+func (discard) SetLevel(Level) {}
 
 func (d discard) Tag(string, interface{}) Logger {
 	return d
 }
 
+func (d discard) With(...interface{}) Logger {
+	return d
+}
+
+func (d discard) WithContext(context.Context) Logger {
+	return d
+}
+
 func (d discard) Output(io.Writer) Logger {
 	return d
 }
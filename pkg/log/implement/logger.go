@@ -3,22 +3,56 @@
 package implement
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
+// Level is the severity of a line. A logger writes the lines of its level
+// and of the levels before it, so LevelFatal is the quietest and LevelDebug
+// the most verbose.
 type Level uint32
 
 const (
-	LevelError Level = iota
+	LevelFatal Level = iota
+	LevelError
+	LevelWarn
 	LevelInfo
+	LevelDebug
 )
 
 var allLevels = []Level{
+	LevelFatal,
 	LevelError,
+	LevelWarn,
 	LevelInfo,
+	LevelDebug,
 }
 
+var levelNames = map[Level]string{
+	LevelFatal: "fatal",
+	LevelError: "error",
+	LevelWarn:  "warn",
+	LevelInfo:  "info",
+	LevelDebug: "debug",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", uint32(l))
+}
+
+// Format is how lines are written: text, bracketed for people, or json, one
+// object per line for log pipelines.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
 // Logger is the main interface of this package, it's the only interface
 // available to interact with every logger implementation, that's to keep the
 // usage easily integrated in code and tests.
@@ -27,23 +61,70 @@ type Logger interface {
 	// on every line as a prefix of the message in the form [name:value]
 	Tag(name string, value interface{}) Logger
 
-	// Errorf will log a message applying args to the format string in the same
-	// way fmt.Sprintf works but applying the [ERROR] prefix to the message
-	Errorf(format string, args ...interface{})
+	// With returns a new Logger tagged with fields, given as alternating
+	// names and values: With("payment_id", 1, "status", "COMPLETED")
+	With(fields ...interface{}) Logger
+
+	// WithContext returns a new Logger tagged with the trace_id and span_id
+	// of the span in ctx, so its lines can be correlated with the trace. It
+	// returns the same Logger when ctx has no span
+	WithContext(ctx context.Context) Logger
+
+	// Debugf will log a message applying args to the format string in the same
+	// way fmt.Sprintf works but applying the [DEBUG] prefix to the message
+	Debugf(format string, args ...interface{})
 
 	// Infof will log a message applying args to the format string in the same
 	// way fmt.Sprintf works but applying the [INFO] prefix to the message
 	Infof(format string, args ...interface{})
+
+	// Warnf will log a message applying args to the format string in the same
+	// way fmt.Sprintf works but applying the [WARNING] prefix to the message
+	Warnf(format string, args ...interface{})
+
+	// Errorf will log a message applying args to the format string in the same
+	// way fmt.Sprintf works but applying the [ERROR] prefix to the message
+	Errorf(format string, args ...interface{})
+
+	// Fatalf will log a message applying args to the format string in the same
+	// way fmt.Sprintf works but applying the [FATAL] prefix to the message,
+	// and then exit the process with status 1
+	Fatalf(format string, args ...interface{})
+
+	// Level returns the level of the logger
+	Level() Level
+
+	// SetLevel changes the level of the logger and of every logger derived
+	// from the same root, such as the ones returned by Tag, while running
+	SetLevel(level Level)
 }
 
 func LevelFromString(level string) (Level, error) {
-	switch strings.ToLower(level) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "fatal":
+		return LevelFatal, nil
 	case "error":
 		return LevelError, nil
+	case "warn", "warning":
+		return LevelWarn, nil
 	case "info":
 		return LevelInfo, nil
+	case "debug":
+		return LevelDebug, nil
 	}
 
 	var l Level
 	return l, fmt.Errorf("not valid log level: %q", level)
 }
+
+func FormatFromString(format string) (Format, error) {
+	switch Format(strings.ToLower(format)) {
+	case FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+
+	var f Format
+	return f, fmt.Errorf("not valid log format: %q", format)
+}
@@ -2,23 +2,36 @@ package implement
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 	"testing"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 func LoggerTestSuite(t *testing.T, newLogger func(...LoggerOption) Logger) {
-	for _, level := range allLevels {
-		t.Run("Infof", func(t *testing.T) {
-			var output bytes.Buffer
-			testInfof(t, newLogger(WithLevel(level), Output(&output)), &output, level)
-		})
+	methods := []struct {
+		name  string
+		level Level
+		want  string
+		logf  func(Logger) func(string, ...interface{})
+	}{
+		{"Debugf", LevelDebug, "DEBUG", func(l Logger) func(string, ...interface{}) { return l.Debugf }},
+		{"Infof", LevelInfo, "INFO", func(l Logger) func(string, ...interface{}) { return l.Infof }},
+		{"Warnf", LevelWarn, "WARN", func(l Logger) func(string, ...interface{}) { return l.Warnf }},
+		{"Errorf", LevelError, "ERROR", func(l Logger) func(string, ...interface{}) { return l.Errorf }},
+	}
 
-		t.Run("Errorf", func(t *testing.T) {
-			var output bytes.Buffer
-			testErrorf(t, newLogger(WithLevel(level), Output(&output)), &output)
-		})
+	for _, level := range allLevels {
+		for _, method := range methods {
+			t.Run(fmt.Sprintf("%s at %s", method.name, level), func(t *testing.T) {
+				var output bytes.Buffer
+				logger := newLogger(WithLevel(level), Output(&output))
+				testLogf(t, method.logf(logger), &output, level, method.level, method.want)
+			})
+		}
 	}
 
 	t.Run("Tag", func(t *testing.T) {
@@ -26,56 +39,53 @@ func LoggerTestSuite(t *testing.T, newLogger func(...LoggerOption) Logger) {
 		testTag(t, newLogger(WithLevel(LevelInfo), Output(&output)), &output)
 	})
 
+	t.Run("With", func(t *testing.T) {
+		var output bytes.Buffer
+		testWith(t, newLogger(WithLevel(LevelInfo), Output(&output)), &output)
+	})
+
 	t.Run("tags in lexicographic order", func(t *testing.T) {
 		var output bytes.Buffer
 		testTagsInLexicographicOrder(t, newLogger(WithLevel(LevelInfo), Output(&output)), &output)
 	})
+
+	t.Run("WithContext", func(t *testing.T) {
+		var output bytes.Buffer
+		testWithContext(t, newLogger(WithLevel(LevelInfo), Output(&output)), &output)
+	})
+
+	t.Run("SetLevel", func(t *testing.T) {
+		var output bytes.Buffer
+		testSetLevel(t, newLogger(WithLevel(LevelInfo), Output(&output)), &output)
+	})
 }
 
-func testInfof(t *testing.T, logger Logger, output *bytes.Buffer, level Level) {
+func testLogf(t *testing.T, logf func(string, ...interface{}), output *bytes.Buffer, loggerLevel, lineLevel Level, want string) {
 	format := "random message %d"
 	arg := 1234
 	message := fmt.Sprintf(format, arg)
 
-	logger.Infof(format, arg)
+	logf(format, arg)
 
 	line := output.String()
 
 	switch {
-	case LevelInfo > level:
+	case lineLevel > loggerLevel:
 		if line != "" {
-			t.Errorf("logger.Infof(%q, %v) = %q; want \"\"", format, arg, line)
+			t.Errorf("logger.%sf(%q, %v) = %q; want \"\"", lineLevel, format, arg, line)
 		}
 
 	default:
 		if !strings.HasSuffix(line, message+"\n") {
-			t.Errorf("logger.Infof(%q, %v) = %q; want %q", format, arg, line, message)
+			t.Errorf("logger.%sf(%q, %v) = %q; want %q", lineLevel, format, arg, line, message)
 		}
 
-		if !strings.Contains(line, "INFO") {
-			t.Errorf("logger.Infof(%q, %v) = doesn't contain INFO level", format, arg)
+		if !strings.Contains(line, want) {
+			t.Errorf("logger.%sf(%q, %v) = doesn't contain %s level", lineLevel, format, arg, want)
 		}
 	}
 }
 
-func testErrorf(t *testing.T, logger Logger, output *bytes.Buffer) {
-	format := "random message %d"
-	arg := 1234
-	message := fmt.Sprintf(format, arg)
-
-	logger.Errorf(format, arg)
-
-	line := output.String()
-
-	if !strings.HasSuffix(line, message+"\n") {
-		t.Errorf("logger.Errorf(%q, %v) = %q; want %q", format, arg, line, message)
-	}
-
-	if !strings.Contains(line, "ERROR") {
-		t.Errorf("logger.Errorf(%q, %v) = doesn't contain ERROR level", format, arg)
-	}
-}
-
 func testTag(t *testing.T, logger Logger, output *bytes.Buffer) {
 	tags := map[string]interface{}{
 		"aTagKey":           "string value",
@@ -143,3 +153,59 @@ func testTagsInLexicographicOrder(t *testing.T, logger Logger, output *bytes.Buf
 		index = curr
 	}
 }
+
+func testWithContext(t *testing.T, logger Logger, output *bytes.Buffer) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	logger.WithContext(ctx).Infof("traced")
+	logger.WithContext(context.Background()).Infof("untraced")
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logger.WithContext(ctx).Infof() wrote %d lines; want 2", len(lines))
+	}
+
+	for _, want := range []string{traceID.String(), spanID.String()} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("logger.WithContext(ctx).Infof() = %q; want to contain %s", lines[0], want)
+		}
+	}
+	if strings.Contains(lines[1], "trace_id") {
+		t.Errorf("logger.WithContext(context.Background()).Infof() = %q; want no trace_id", lines[1])
+	}
+}
+
+func testWith(t *testing.T, logger Logger, output *bytes.Buffer) {
+	logger.With("payment_id", 42, "status", "COMPLETED", "dangling").Infof("payment processed")
+
+	line := output.String()
+	for _, want := range []string{"payment_id:42", "status:COMPLETED", "!BADKEY:dangling"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("logger.With(...).Infof() = %q; want to contain %s", line, want)
+		}
+	}
+}
+
+func testSetLevel(t *testing.T, logger Logger, output *bytes.Buffer) {
+	derived := logger.Tag("component", "test")
+
+	derived.Debugf("hidden")
+	if output.Len() != 0 {
+		t.Errorf("logger.Debugf() at info = %q; want \"\"", output.String())
+	}
+
+	logger.SetLevel(LevelDebug)
+	if got := derived.Level(); got != LevelDebug {
+		t.Errorf("derived.Level() = %s; want %s", got, LevelDebug)
+	}
+
+	derived.Debugf("shown")
+	if !strings.Contains(output.String(), "shown") {
+		t.Errorf("derived.Debugf() after SetLevel(LevelDebug) = %q; want the line", output.String())
+	}
+}
@@ -2,6 +2,7 @@ package implement
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -10,20 +11,39 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"go-payments-api/pkg/metrics"
+	"go-payments-api/pkg/redact"
 	libruntime "go-payments-api/pkg/runtime"
 )
 
 type loggerConfig struct {
-	level  Level
-	output io.Writer
+	level    Level
+	format   Format
+	output   io.Writer
+	sampling map[Level]Sampling
+	redactor *redact.Redactor
 }
 
 var logLevelToLogrusLevel = map[Level]logrus.Level{
-	LevelInfo:  logrus.InfoLevel,
+	LevelFatal: logrus.FatalLevel,
 	LevelError: logrus.ErrorLevel,
+	LevelWarn:  logrus.WarnLevel,
+	LevelInfo:  logrus.InfoLevel,
+	LevelDebug: logrus.DebugLevel,
+}
+
+var logrusLevelToLogLevel = map[logrus.Level]Level{
+	logrus.PanicLevel: LevelFatal,
+	logrus.FatalLevel: LevelFatal,
+	logrus.ErrorLevel: LevelError,
+	logrus.WarnLevel:  LevelWarn,
+	logrus.InfoLevel:  LevelInfo,
+	logrus.DebugLevel: LevelDebug,
+	logrus.TraceLevel: LevelDebug,
 }
 
 // NewLogrus creates a new Logger that uses https://github.com/sirupsen/logrus
@@ -33,6 +53,7 @@ func NewLogrus(options ...LoggerOption) Logger {
 	var l loggerConfig
 	defaultOptions := []LoggerOption{
 		WithLevel(LevelInfo),
+		WithFormat(FormatText),
 		Output(os.Stdout),
 	}
 	options = append(defaultOptions, options...)
@@ -44,9 +65,14 @@ func NewLogrus(options ...LoggerOption) Logger {
 	logger := logrus.New()
 	logger.SetReportCaller(true)
 	logger.SetOutput(l.output)
-	logger.SetFormatter(logrusFormatter{
-		timeFormat: "2006-01-02T15:04:05.1483386-00:00",
-	})
+	logger.AddHook(redactHook{redactor: l.redactor})
+	if l.format == FormatJSON {
+		logger.SetFormatter(jsonFormatter())
+	} else {
+		logger.SetFormatter(logrusFormatter{
+			timeFormat: "2006-01-02T15:04:05.1483386-00:00",
+		})
+	}
 
 	level, ok := logLevelToLogrusLevel[l.level]
 	if !ok {
@@ -55,7 +81,8 @@ func NewLogrus(options ...LoggerOption) Logger {
 	logger.SetLevel(level)
 
 	return logrusAdapter{
-		entry: logrus.NewEntry(logger),
+		entry:   logrus.NewEntry(logger),
+		sampler: newSampler(l.sampling),
 	}
 }
 
@@ -111,6 +138,22 @@ func (f logrusFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// jsonFormatter writes each line as a JSON object with the time, level,
+// caller, file and message next to the tags.
+func jsonFormatter() *logrus.JSONFormatter {
+	return &logrus.JSONFormatter{
+		TimestampFormat:  time.RFC3339Nano,
+		CallerPrettyfier: prettifyCaller,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  "time",
+			logrus.FieldKeyLevel: "level",
+			logrus.FieldKeyMsg:   "message",
+			logrus.FieldKeyFunc:  "caller",
+			logrus.FieldKeyFile:  "file",
+		},
+	}
+}
+
 func prettifyCaller(frame *runtime.Frame) (string, string) {
 	// we need the next frame because logrus reports the caller as being
 	// always logrusAdapter on this file
@@ -125,7 +168,8 @@ func prettifyCaller(frame *runtime.Frame) (string, string) {
 var _ Logger = logrusAdapter{}
 
 type logrusAdapter struct {
-	entry *logrus.Entry `di:"norecurse"`
+	entry   *logrus.Entry `di:"norecurse"`
+	sampler *sampler
 }
 
 func (l logrusAdapter) Tag(name string, value interface{}) Logger {
@@ -133,10 +177,74 @@ func (l logrusAdapter) Tag(name string, value interface{}) Logger {
 	return l
 }
 
-func (l logrusAdapter) Errorf(format string, args ...interface{}) {
-	l.entry.Errorf(format, args...)
+func (l logrusAdapter) With(fields ...interface{}) Logger {
+	data := make(logrus.Fields, (len(fields)+1)/2)
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 == len(fields) {
+			data["!BADKEY"] = fields[i]
+			break
+		}
+		data[fmt.Sprint(fields[i])] = fields[i+1]
+	}
+	l.entry = l.entry.WithFields(data)
+	return l
+}
+
+func (l logrusAdapter) WithContext(ctx context.Context) Logger {
+	traceID, spanID, ok := metrics.GetTraceInfo(ctx)
+	if !ok {
+		return l
+	}
+	l.entry = l.entry.WithFields(logrus.Fields{
+		"trace_id": traceID,
+		"span_id":  spanID,
+	})
+	return l
+}
+
+// The log methods call the entry themselves, as the caller of a line is
+// found a fixed number of frames away.
+
+func (l logrusAdapter) Debugf(format string, args ...interface{}) {
+	if l.enabled(LevelDebug, format) {
+		l.entry.Debugf(format, args...)
+	}
 }
 
 func (l logrusAdapter) Infof(format string, args ...interface{}) {
-	l.entry.Infof(format, args...)
+	if l.enabled(LevelInfo, format) {
+		l.entry.Infof(format, args...)
+	}
+}
+
+func (l logrusAdapter) Warnf(format string, args ...interface{}) {
+	if l.enabled(LevelWarn, format) {
+		l.entry.Warnf(format, args...)
+	}
+}
+
+func (l logrusAdapter) Errorf(format string, args ...interface{}) {
+	if l.enabled(LevelError, format) {
+		l.entry.Errorf(format, args...)
+	}
+}
+
+func (l logrusAdapter) Fatalf(format string, args ...interface{}) {
+	l.entry.Fatalf(format, args...)
+}
+
+func (l logrusAdapter) Level() Level {
+	return logrusLevelToLogLevel[l.entry.Logger.GetLevel()]
+}
+
+func (l logrusAdapter) SetLevel(level Level) {
+	if logrusLevel, ok := logLevelToLogrusLevel[level]; ok {
+		l.entry.Logger.SetLevel(logrusLevel)
+	}
+}
+
+// enabled reports whether a line of level from format is to be written,
+// sampling only the lines the level of the logger lets through.
+func (l logrusAdapter) enabled(level Level, format string) bool {
+	return l.entry.Logger.IsLevelEnabled(logLevelToLogrusLevel[level]) && l.sampler.allow(level, format)
 }
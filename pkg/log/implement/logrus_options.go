@@ -1,6 +1,10 @@
 package implement
 
-import "io"
+import (
+	"io"
+
+	"go-payments-api/pkg/redact"
+)
 
 type LoggerOption func(loggerConfig) loggerConfig
 
@@ -17,3 +21,33 @@ func Output(w io.Writer) LoggerOption {
 		return l
 	}
 }
+
+func WithFormat(format Format) LoggerOption {
+	return func(l loggerConfig) loggerConfig {
+		l.format = format
+		return l
+	}
+}
+
+// WithSampling samples the lines of level as sampling sets. Fatal lines are
+// never sampled.
+func WithSampling(level Level, sampling Sampling) LoggerOption {
+	return func(l loggerConfig) loggerConfig {
+		sampled := make(map[Level]Sampling, len(l.sampling)+1)
+		for k, v := range l.sampling {
+			sampled[k] = v
+		}
+		sampled[level] = sampling
+		l.sampling = sampled
+		return l
+	}
+}
+
+// WithRedactor masks the sensitive data of the lines with redactor instead
+// of redact.Default.
+func WithRedactor(redactor *redact.Redactor) LoggerOption {
+	return func(l loggerConfig) loggerConfig {
+		l.redactor = redactor
+		return l
+	}
+}
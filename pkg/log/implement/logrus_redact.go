@@ -0,0 +1,46 @@
+package implement
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"go-payments-api/pkg/redact"
+)
+
+// redactHook masks the sensitive data of messages and tags before lines
+// are formatted. Without a redactor of its own it uses redact.Default when
+// firing, so replacing the default applies to loggers already created.
+type redactHook struct {
+	redactor *redact.Redactor
+}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h redactHook) Fire(entry *logrus.Entry) error {
+	redactor := h.redactor
+	if redactor == nil {
+		redactor = redact.Default
+	}
+
+	entry.Message = redactor.String(entry.Message)
+
+	for name, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			entry.Data[name] = redactor.Field(name, v)
+		case error:
+			entry.Data[name] = redactor.Field(name, v.Error())
+		case fmt.Stringer:
+			entry.Data[name] = redactor.Field(name, v.String())
+		default:
+			if mask, ok := redactor.Sensitive(name); ok {
+				entry.Data[name] = redactor.Mask(fmt.Sprint(v), mask)
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,148 @@
 package implement
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_logrusAdapter(t *testing.T) {
 	LoggerTestSuite(t, NewLogrus)
 }
+
+func Test_logrusAdapterJSON(t *testing.T) {
+	var output bytes.Buffer
+	logger := NewLogrus(WithFormat(FormatJSON), Output(&output))
+
+	logger.Tag("payment_id", 42).Errorf("failed to process payment %d", 42)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(output.Bytes(), &line); err != nil {
+		t.Fatalf("json.Unmarshal(%q) = %v; want a JSON line", output.String(), err)
+	}
+
+	want := map[string]interface{}{
+		"level":      "error",
+		"message":    "failed to process payment 42",
+		"payment_id": float64(42),
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("line[%q] = %v; want %v", key, line[key], value)
+		}
+	}
+	if file, _ := line["file"].(string); !strings.HasPrefix(file, "logrus_test.go:") {
+		t.Errorf("line[%q] = %v; want the file of the caller", "file", line["file"])
+	}
+	for _, key := range []string{"time", "caller"} {
+		if _, ok := line[key]; !ok {
+			t.Errorf("line = %v; want to contain %q", line, key)
+		}
+	}
+}
+
+func Test_logrusAdapterFatalf(t *testing.T) {
+	var output bytes.Buffer
+	logger := NewLogrus(WithLevel(LevelFatal), Output(&output))
+
+	code := -1
+	logger.(logrusAdapter).entry.Logger.ExitFunc = func(c int) { code = c }
+
+	logger.Errorf("not written")
+	logger.Fatalf("cannot start: %s", "missing settings")
+
+	if code != 1 {
+		t.Errorf("logger.Fatalf() exited with %d; want 1", code)
+	}
+	if line := output.String(); !strings.Contains(line, "FATAL") || !strings.HasSuffix(line, "cannot start: missing settings\n") {
+		t.Errorf("logger.Fatalf() = %q; want the fatal line alone", line)
+	}
+}
+
+func Test_logrusAdapterSampling(t *testing.T) {
+	var output bytes.Buffer
+	logger := NewLogrus(
+		WithLevel(LevelDebug),
+		WithSampling(LevelDebug, Sampling{First: 2, Thereafter: 3, Tick: time.Hour}),
+		Output(&output),
+	)
+
+	for i := 1; i <= 8; i++ {
+		logger.Debugf("message %d", i)
+		logger.Infof("unsampled %d", i)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	var debug []string
+	for _, line := range lines {
+		if strings.Contains(line, "DEBUG") {
+			debug = append(debug, line[strings.LastIndex(line, "] ")+2:])
+		}
+	}
+
+	want := []string{"message 1", "message 2", "message 5", "message 8"}
+	if strings.Join(debug, ",") != strings.Join(want, ",") {
+		t.Errorf("sampled debug lines = %v; want %v", debug, want)
+	}
+	if len(lines)-len(debug) != 8 {
+		t.Errorf("info lines = %d; want 8", len(lines)-len(debug))
+	}
+}
+
+func Test_logrusAdapterRedacts(t *testing.T) {
+	var output bytes.Buffer
+	logger := NewLogrus(WithFormat(FormatJSON), Output(&output))
+
+	logger.With("cvv", 123, "payer", "529.982.247-25").Errorf("card %s declined", "4111111111111111")
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(output.Bytes(), &line); err != nil {
+		t.Fatalf("json.Unmarshal(%q) = %v; want a JSON line", output.String(), err)
+	}
+
+	if line["message"] != "card ****1111 declined" {
+		t.Errorf("line[%q] = %v; want the last 4 digits of the card", "message", line["message"])
+	}
+	if line["cvv"] != "[REDACTED]" {
+		t.Errorf("line[%q] = %v; want [REDACTED]", "cvv", line["cvv"])
+	}
+	if payer, _ := line["payer"].(string); !strings.HasPrefix(payer, "[hash:") {
+		t.Errorf("line[%q] = %v; want the CPF hashed", "payer", line["payer"])
+	}
+}
+
+func TestLevelFromString(t *testing.T) {
+	cases := map[string]Level{
+		"fatal":   LevelFatal,
+		"error":   LevelError,
+		"WARN":    LevelWarn,
+		"warning": LevelWarn,
+		"info":    LevelInfo,
+		"Debug":   LevelDebug,
+	}
+	for value, want := range cases {
+		got, err := LevelFromString(value)
+		if err != nil || got != want {
+			t.Errorf("LevelFromString(%q) = %s, %v; want %s, nil", value, got, err, want)
+		}
+	}
+
+	if _, err := LevelFromString("trace"); err == nil {
+		t.Errorf("LevelFromString(%q) = nil error; want error", "trace")
+	}
+}
+
+func TestFormatFromString(t *testing.T) {
+	for value, want := range map[string]Format{"text": FormatText, "JSON": FormatJSON} {
+		got, err := FormatFromString(value)
+		if err != nil || got != want {
+			t.Errorf("FormatFromString(%q) = %q, %v; want %q, nil", value, got, err, want)
+		}
+	}
+
+	if _, err := FormatFromString("xml"); err == nil {
+		t.Errorf("FormatFromString(%q) = nil error; want error", "xml")
+	}
+}
@@ -0,0 +1,86 @@
+package implement
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampling caps the lines of a level written from the same format string,
+// so a hot path can log without flooding the output. Within every Tick the
+// First lines are written and then one of every Thereafter, or none when
+// Thereafter is 0.
+type Sampling struct {
+	First      int
+	Thereafter int
+	Tick       time.Duration
+}
+
+const defaultSamplingTick = time.Second
+
+// sampler counts the lines of the sampled levels. Lines are counted by
+// format string rather than by message, so the counters are bounded by the
+// log calls in the code.
+type sampler struct {
+	levels map[Level]Sampling
+	now    func() time.Time
+
+	mu       sync.Mutex
+	counters map[samplerKey]*samplerCounter
+}
+
+type samplerKey struct {
+	level  Level
+	format string
+}
+
+type samplerCounter struct {
+	reset time.Time
+	count int
+}
+
+// newSampler returns nil, which samples nothing, when levels is empty.
+func newSampler(levels map[Level]Sampling) *sampler {
+	if len(levels) == 0 {
+		return nil
+	}
+
+	return &sampler{
+		levels:   levels,
+		now:      time.Now,
+		counters: map[samplerKey]*samplerCounter{},
+	}
+}
+
+// allow reports whether the line of level written from format is to be
+// written.
+func (s *sampler) allow(level Level, format string) bool {
+	if s == nil {
+		return true
+	}
+	sampling, ok := s.levels[level]
+	if !ok {
+		return true
+	}
+
+	tick := sampling.Tick
+	if tick <= 0 {
+		tick = defaultSamplingTick
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	key := samplerKey{level: level, format: format}
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.reset) {
+		counter = &samplerCounter{reset: now.Add(tick)}
+		s.counters[key] = counter
+	}
+	counter.count++
+
+	if counter.count <= sampling.First {
+		return true
+	}
+	return sampling.Thereafter > 0 && (counter.count-sampling.First)%sampling.Thereafter == 0
+}
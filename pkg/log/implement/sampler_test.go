@@ -0,0 +1,39 @@
+package implement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSamplerAllow(t *testing.T) {
+	now := time.Date(2024, 11, 13, 10, 30, 0, 0, time.UTC)
+	s := newSampler(map[Level]Sampling{
+		LevelInfo: {First: 1, Thereafter: 0, Tick: time.Second},
+	})
+	s.now = func() time.Time { return now }
+
+	if !s.allow(LevelInfo, "payment %d saved") {
+		t.Errorf("s.allow() first line = false; want true")
+	}
+	if s.allow(LevelInfo, "payment %d saved") {
+		t.Errorf("s.allow() second line = true; want false")
+	}
+	if !s.allow(LevelInfo, "payment %d processed") {
+		t.Errorf("s.allow() other format = false; want true")
+	}
+	if !s.allow(LevelError, "payment %d saved") {
+		t.Errorf("s.allow() unsampled level = false; want true")
+	}
+
+	now = now.Add(time.Second)
+	if !s.allow(LevelInfo, "payment %d saved") {
+		t.Errorf("s.allow() after the tick = false; want true")
+	}
+}
+
+func TestSamplerNil(t *testing.T) {
+	s := newSampler(nil)
+	if !s.allow(LevelDebug, "anything") {
+		t.Errorf("nil sampler allow() = false; want true")
+	}
+}
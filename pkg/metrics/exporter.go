@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exporters of traces and metrics
+const (
+	ExporterOTLP = "otlp"
+	// ExporterJaeger sends traces to the OTLP collector of Jaeger, which
+	// replaced its own protocol
+	ExporterJaeger     = "jaeger"
+	ExporterStdout     = "stdout"
+	ExporterPrometheus = "prometheus"
+	ExporterNone       = "none"
+)
+
+// OTLP protocols
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http/protobuf"
+)
+
+// OTLPConfig is where the OTLP exporters send to. Fields left empty fall
+// back to the OTEL_EXPORTER_OTLP_* variables.
+type OTLPConfig struct {
+	// Endpoint is the URL of the collector, such as http://localhost:4317.
+	// TLS is only used for https URLs, and the HTTP exporters append the
+	// path of their signal, such as /v1/traces
+	Endpoint string
+	// Protocol is grpc or http/protobuf, grpc when empty
+	Protocol string
+	Headers  map[string]string
+}
+
+// protocol returns the protocol of c, lower cased and validated.
+func (c OTLPConfig) protocol() (string, error) {
+	switch protocol := strings.ToLower(strings.TrimSpace(c.Protocol)); protocol {
+	case ProtocolGRPC, "":
+		return ProtocolGRPC, nil
+	case ProtocolHTTP, "http":
+		return ProtocolHTTP, nil
+	default:
+		return "", fmt.Errorf("unknown OTLP protocol %q", c.Protocol)
+	}
+}
+
+// signalURL is the endpoint of the signal at path, such as /v1/traces, for
+// the HTTP exporters.
+func (c OTLPConfig) signalURL(path string) string {
+	return strings.TrimSuffix(c.Endpoint, "/") + path
+}
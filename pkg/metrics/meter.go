@@ -0,0 +1,225 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Meter creates the instruments of the helpers below. It comes from the
+// global provider, which forwards them to the provider set by App.Start.
+var Meter = otel.Meter("go-payments-api")
+
+// Instrument describes a metric recorded through the helpers. Names follow
+// the OTel semantic conventions when there is one for the metric.
+type Instrument struct {
+	Name        string
+	Unit        string
+	Description string
+	// Buckets are the bucket boundaries of histograms, the SDK defaults
+	// when empty
+	Buckets []float64
+}
+
+// durationBuckets go from 5ms to 10s, as the semantic conventions advise
+// for request durations.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+var (
+	HTTPServerDuration = Instrument{
+		Name:        "http.server.request.duration",
+		Unit:        "s",
+		Description: "Duration of the HTTP requests by route and status",
+		Buckets:     durationBuckets,
+	}
+	DBClientDuration = Instrument{
+		Name:        "db.client.operation.duration",
+		Unit:        "s",
+		Description: "Duration of the database queries",
+		Buckets:     durationBuckets,
+	}
+	MessagingPublishDuration = Instrument{
+		Name:        "messaging.client.operation.duration",
+		Unit:        "s",
+		Description: "Duration of the Kafka publishes",
+		Buckets:     durationBuckets,
+	}
+	MessagingSentMessages = Instrument{
+		Name:        "messaging.client.sent.messages",
+		Unit:        "{message}",
+		Description: "Messages published to Kafka, with error.type when they failed",
+	}
+	PaymentsCreated = Instrument{
+		Name:        "payments.created",
+		Unit:        "{payment}",
+		Description: "Payments created by method and status",
+	}
+	PaymentAmount = Instrument{
+		Name:        "payments.amount",
+		Unit:        "{currency}",
+		Description: "Amount of the payments created, in units of their currency",
+		Buckets:     []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 50000},
+	}
+)
+
+// instruments caches the instruments of the helpers by name, so they are
+// only created once per Meter.
+var instruments = struct {
+	sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}{
+	counters:   map[string]metric.Int64Counter{},
+	histograms: map[string]metric.Float64Histogram{},
+}
+
+func counter(instrument Instrument) metric.Int64Counter {
+	instruments.Lock()
+	defer instruments.Unlock()
+
+	if c, ok := instruments.counters[instrument.Name]; ok {
+		return c
+	}
+
+	c, err := Meter.Int64Counter(instrument.Name,
+		metric.WithUnit(instrument.Unit),
+		metric.WithDescription(instrument.Description))
+	if err != nil {
+		otel.Handle(err)
+	}
+	instruments.counters[instrument.Name] = c
+	return c
+}
+
+func histogram(instrument Instrument) metric.Float64Histogram {
+	instruments.Lock()
+	defer instruments.Unlock()
+
+	if h, ok := instruments.histograms[instrument.Name]; ok {
+		return h
+	}
+
+	options := []metric.Float64HistogramOption{
+		metric.WithUnit(instrument.Unit),
+		metric.WithDescription(instrument.Description),
+	}
+	if len(instrument.Buckets) > 0 {
+		options = append(options, metric.WithExplicitBucketBoundaries(instrument.Buckets...))
+	}
+
+	h, err := Meter.Float64Histogram(instrument.Name, options...)
+	if err != nil {
+		otel.Handle(err)
+	}
+	instruments.histograms[instrument.Name] = h
+	return h
+}
+
+// Helper to count events
+func AddCounter(ctx context.Context, instrument Instrument, value int64, attrs ...attribute.KeyValue) {
+	counter(instrument).Add(ctx, value, metric.WithAttributes(attrs...))
+}
+
+// Helper to record a distribution, such as amounts
+func RecordHistogram(ctx context.Context, instrument Instrument, value float64, attrs ...attribute.KeyValue) {
+	histogram(instrument).Record(ctx, value, metric.WithAttributes(attrs...))
+}
+
+// Helper to record the seconds elapsed since start
+func RecordDuration(ctx context.Context, instrument Instrument, start time.Time, attrs ...attribute.KeyValue) {
+	RecordHistogram(ctx, instrument, time.Since(start).Seconds(), attrs...)
+}
+
+type MeterConfig struct {
+	Resource *resource.Resource
+	// Exporters are otlp, prometheus or none
+	Exporters []string
+	OTLP      OTLPConfig
+}
+
+// MeterProvider is the SDK provider with the scrape handler of the
+// prometheus exporter.
+type MeterProvider struct {
+	*sdkmetric.MeterProvider
+	// Handler serves the metrics in the Prometheus text format, it's nil
+	// when the prometheus exporter is off
+	Handler http.Handler
+}
+
+func NewMeterProvider(ctx context.Context, config MeterConfig) (*MeterProvider, error) {
+	provider := &MeterProvider{}
+	options := []sdkmetric.Option{sdkmetric.WithResource(config.Resource)}
+
+	for _, exporter := range config.Exporters {
+		switch strings.ToLower(strings.TrimSpace(exporter)) {
+		case ExporterOTLP:
+			otlp, err := newOTLPMetricExporter(ctx, config.OTLP)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the OTLP metric exporter: %w", err)
+			}
+			options = append(options, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(otlp)))
+		case ExporterPrometheus:
+			// A registry of its own keeps the metrics of other libraries
+			// registered on the default one out of the scrape
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(
+				collectors.NewGoCollector(),
+				collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			)
+			reader, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the prometheus metric exporter: %w", err)
+			}
+			options = append(options, sdkmetric.WithReader(reader))
+			provider.Handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+		case ExporterNone, "":
+		default:
+			return nil, fmt.Errorf("unknown metrics exporter %q", exporter)
+		}
+	}
+
+	provider.MeterProvider = sdkmetric.NewMeterProvider(options...)
+	return provider, nil
+}
+
+func newOTLPMetricExporter(ctx context.Context, config OTLPConfig) (sdkmetric.Exporter, error) {
+	protocol, err := config.protocol()
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol == ProtocolHTTP {
+		var options []otlpmetrichttp.Option
+		if config.Endpoint != "" {
+			options = append(options, otlpmetrichttp.WithEndpointURL(config.signalURL("/v1/metrics")))
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlpmetrichttp.WithHeaders(config.Headers))
+		}
+		return otlpmetrichttp.New(ctx, options...)
+	}
+
+	var options []otlpmetricgrpc.Option
+	if config.Endpoint != "" {
+		options = append(options, otlpmetricgrpc.WithEndpointURL(config.Endpoint))
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlpmetricgrpc.WithHeaders(config.Headers))
+	}
+	return otlpmetricgrpc.New(ctx, options...)
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// useReader makes the helpers record to a reader of their own.
+func useReader(t *testing.T) *sdkmetric.ManualReader {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	meter, counters, histograms := Meter, instruments.counters, instruments.histograms
+	Meter = provider.Meter("test")
+	instruments.counters = map[string]metric.Int64Counter{}
+	instruments.histograms = map[string]metric.Float64Histogram{}
+	t.Cleanup(func() {
+		Meter, instruments.counters, instruments.histograms = meter, counters, histograms
+	})
+
+	return reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &data))
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	t.Fatalf("metric %s not recorded", name)
+	return nil
+}
+
+func TestAddCounter(t *testing.T) {
+	reader := useReader(t)
+	ctx := context.Background()
+
+	AddCounter(ctx, PaymentsCreated, 1, attribute.String("payment.method", "PIX"))
+	AddCounter(ctx, PaymentsCreated, 2, attribute.String("payment.method", "PIX"))
+	AddCounter(ctx, PaymentsCreated, 1, attribute.String("payment.method", "CARD"))
+
+	sum := collect(t, reader, PaymentsCreated.Name).(metricdata.Sum[int64])
+	assert.True(t, sum.IsMonotonic)
+	assert.Len(t, sum.DataPoints, 2)
+	for _, point := range sum.DataPoints {
+		method, _ := point.Attributes.Value("payment.method")
+		switch method.AsString() {
+		case "PIX":
+			assert.Equal(t, int64(3), point.Value)
+		case "CARD":
+			assert.Equal(t, int64(1), point.Value)
+		}
+	}
+}
+
+func TestRecordHistogram(t *testing.T) {
+	reader := useReader(t)
+	ctx := context.Background()
+
+	RecordHistogram(ctx, PaymentAmount, 7.5)
+	RecordHistogram(ctx, PaymentAmount, 120)
+	RecordDuration(ctx, DBClientDuration, time.Now().Add(-time.Second))
+
+	amounts := collect(t, reader, PaymentAmount.Name).(metricdata.Histogram[float64])
+	assert.Len(t, amounts.DataPoints, 1)
+	assert.Equal(t, uint64(2), amounts.DataPoints[0].Count)
+	assert.Equal(t, 127.5, amounts.DataPoints[0].Sum)
+	assert.Equal(t, PaymentAmount.Buckets, amounts.DataPoints[0].Bounds)
+
+	durations := collect(t, reader, DBClientDuration.Name).(metricdata.Histogram[float64])
+	assert.Len(t, durations.DataPoints, 1)
+	assert.GreaterOrEqual(t, durations.DataPoints[0].Sum, 1.0)
+}
+
+func TestNewMeterProviderPrometheus(t *testing.T) {
+	res, err := NewResource("go-payments-api", "")
+	assert.NoError(t, err)
+
+	provider, err := NewMeterProvider(context.Background(), MeterConfig{
+		Resource:  res,
+		Exporters: []string{"prometheus"},
+	})
+	assert.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	counter, _ := provider.Meter("test").Int64Counter(PaymentsCreated.Name)
+	counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("payment.method", "PIX")))
+
+	recorder := httptest.NewRecorder()
+	provider.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `payments_created_total{`)
+	assert.Contains(t, recorder.Body.String(), `payment_method="PIX"`)
+	assert.Contains(t, recorder.Body.String(), `service_name="go-payments-api"`)
+	assert.Contains(t, recorder.Body.String(), "go_goroutines")
+}
+
+func TestNewMeterProviderExporters(t *testing.T) {
+	provider, err := NewMeterProvider(context.Background(), MeterConfig{Exporters: []string{"none"}})
+	assert.NoError(t, err)
+	assert.Nil(t, provider.Handler)
+
+	_, err = NewMeterProvider(context.Background(), MeterConfig{Exporters: []string{"statsd"}})
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package metrics
 import (
 	"context"
 
+	"go-payments-api/pkg/redact"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -21,7 +23,7 @@ func StartSpanWithAttributes(ctx context.Context, spanName string, attrs ...trac
 
 func Finish(span trace.Span, err error) {
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		span.SetStatus(codes.Error, redact.String(err.Error()))
 	}
 	span.End()
 }
@@ -57,7 +59,7 @@ func GetTraceInfo(ctx context.Context) (traceID, spanID string, isValid bool) {
 func AddSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
-		span.SetAttributes(attrs...)
+		span.SetAttributes(redactAttributes(attrs)...)
 	}
 }
 
@@ -65,6 +67,32 @@ func AddSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
 func AddSpanEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
-		span.AddEvent(name, trace.WithAttributes(attrs...))
+		span.AddEvent(name, trace.WithAttributes(redactAttributes(attrs)...))
+	}
+}
+
+// redactAttributes masks the sensitive values of attrs, by their key or by
+// their content, such as card numbers in the errors recorded on events.
+func redactAttributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		key := string(attr.Key)
+
+		switch attr.Value.Type() {
+		case attribute.STRING:
+			redacted[i] = attribute.String(key, redact.Field(key, attr.Value.AsString()))
+		case attribute.STRINGSLICE:
+			values := attr.Value.AsStringSlice()
+			for j, value := range values {
+				values[j] = redact.Field(key, value)
+			}
+			redacted[i] = attribute.StringSlice(key, values)
+		default:
+			redacted[i] = attr
+			if mask, ok := redact.Default.Sensitive(key); ok {
+				redacted[i] = attribute.String(key, redact.Default.Mask(attr.Value.Emit(), mask))
+			}
+		}
 	}
+	return redacted
 }
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestRedactAttributes(t *testing.T) {
+	attrs := redactAttributes([]attribute.KeyValue{
+		attribute.String("error", "card 4111111111111111 declined for payer@example.com"),
+		attribute.String("payment.card.cvv", "123"),
+		attribute.Int64("payment.card.number", 4242424242424242),
+		attribute.StringSlice("payment.payers", []string{"529.982.247-25"}),
+		attribute.Int64("payment.id", 42),
+		attribute.String("payment.method", "CARD"),
+	})
+
+	assert.Equal(t, "error", string(attrs[0].Key))
+	assert.NotContains(t, attrs[0].Value.AsString(), "4111111111111111")
+	assert.NotContains(t, attrs[0].Value.AsString(), "payer@example.com")
+	assert.Contains(t, attrs[0].Value.AsString(), "****1111")
+	assert.Equal(t, "[REDACTED]", attrs[1].Value.AsString())
+	assert.Equal(t, "****4242", attrs[2].Value.AsString())
+	assert.NotContains(t, attrs[3].Value.AsStringSlice()[0], "529.982.247-25")
+	assert.Equal(t, attribute.Int64("payment.id", 42), attrs[4])
+	assert.Equal(t, attribute.String("payment.method", "CARD"), attrs[5])
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceParent(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := WithTraceParent(context.Background(), traceParent)
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	assert.True(t, spanCtx.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanCtx.TraceID().String())
+	assert.Equal(t, traceParent, TraceParent(ctx))
+}
+
+func TestTraceParentWithoutSpan(t *testing.T) {
+	assert.Empty(t, TraceParent(context.Background()))
+	assert.Equal(t, context.Background(), WithTraceParent(context.Background(), "garbage"))
+}
+
+func TestNewPropagator(t *testing.T) {
+	propagator, err := NewPropagator([]string{"tracecontext", "baggage", "b3multi"})
+	assert.NoError(t, err)
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(WithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"), carrier)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", carrier.Get("traceparent"))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", carrier.Get("x-b3-traceid"))
+
+	// B3 requests join the trace of the caller
+	propagator, err = NewPropagator([]string{"tracecontext", "b3"})
+	assert.NoError(t, err)
+	ctx := propagator.Extract(context.Background(), propagation.MapCarrier{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"})
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String())
+
+	_, err = NewPropagator([]string{"xray"})
+	assert.Error(t, err)
+}
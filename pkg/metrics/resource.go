@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// NewResource describes the process to the traces and metrics it exports.
+// attributes holds key=value pairs separated by commas, with values
+// percent-encoded, as OTEL_RESOURCE_ATTRIBUTES does; serviceName overrides
+// the service.name among them.
+func NewResource(serviceName, attributes string) (*resource.Resource, error) {
+	attrs, err := parseResourceAttributes(attributes)
+	if err != nil {
+		return nil, err
+	}
+	if serviceName != "" {
+		attrs = append(attrs, semconv.ServiceName(serviceName))
+	}
+
+	return resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+}
+
+func parseResourceAttributes(attributes string) ([]attribute.KeyValue, error) {
+	var attrs []attribute.KeyValue
+	for _, pair := range strings.Split(attributes, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid resource attribute %q, expected key=value", pair)
+		}
+
+		value, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of resource attribute %q: %w", key, err)
+		}
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	return attrs, nil
+}
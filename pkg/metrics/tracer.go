@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type TracerConfig struct {
+	Resource *resource.Resource
+	// Exporter is otlp, jaeger, stdout or none
+	Exporter string
+	OTLP     OTLPConfig
+	// SampleRatio is the share of the traces started here that are
+	// sampled, between 0 and 1. Traces continued from a caller follow the
+	// decision of the caller
+	SampleRatio float64
+}
+
+// NewTracerProvider returns the SDK provider exporting the sampled spans in
+// batches. Its Shutdown flushes the spans still in the batch.
+func NewTracerProvider(ctx context.Context, config TracerConfig) (*sdktrace.TracerProvider, error) {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid trace sample ratio %v, expected a value between 0 and 1", config.SampleRatio)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(config.Resource),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+
+	switch strings.ToLower(strings.TrimSpace(config.Exporter)) {
+	case ExporterOTLP, ExporterJaeger:
+		exporter, err := newOTLPTraceExporter(ctx, config.OTLP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the stdout trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterNone, "":
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", config.Exporter)
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+func newOTLPTraceExporter(ctx context.Context, config OTLPConfig) (sdktrace.SpanExporter, error) {
+	protocol, err := config.protocol()
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol == ProtocolHTTP {
+		var options []otlptracehttp.Option
+		if config.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.signalURL("/v1/traces")))
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(config.Headers))
+		}
+		return otlptracehttp.New(ctx, options...)
+	}
+
+	var options []otlptracegrpc.Option
+	if config.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpointURL(config.Endpoint))
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracegrpc.WithHeaders(config.Headers))
+	}
+	return otlptracegrpc.New(ctx, options...)
+}
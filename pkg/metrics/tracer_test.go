@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewResource(t *testing.T) {
+	res, err := NewResource("go-payments-api", "service.name=ignored, deployment.environment=prod,team=pay%20ments")
+	assert.NoError(t, err)
+
+	attrs := map[attribute.Key]string{}
+	for _, attr := range res.Attributes() {
+		attrs[attr.Key] = attr.Value.Emit()
+	}
+	assert.Equal(t, "go-payments-api", attrs["service.name"])
+	assert.Equal(t, "prod", attrs["deployment.environment"])
+	assert.Equal(t, "pay ments", attrs["team"])
+	assert.Equal(t, "opentelemetry", attrs["telemetry.sdk.name"])
+
+	_, err = NewResource("go-payments-api", "team")
+	assert.Error(t, err)
+}
+
+func TestNewTracerProviderSampling(t *testing.T) {
+	provider, err := NewTracerProvider(context.Background(), TracerConfig{Exporter: "none", SampleRatio: 0})
+	assert.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+	tracer := provider.Tracer("test")
+
+	// New traces follow the ratio
+	_, span := tracer.Start(context.Background(), "root")
+	assert.False(t, span.SpanContext().IsSampled())
+	span.End()
+
+	// Traces from callers keep their decision
+	ctx := WithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, span = tracer.Start(ctx, "child")
+	assert.True(t, span.SpanContext().IsSampled())
+	assert.Equal(t, trace.SpanContextFromContext(ctx).TraceID(), span.SpanContext().TraceID())
+	span.End()
+}
+
+func TestNewTracerProviderConfig(t *testing.T) {
+	for _, exporter := range []string{"otlp", "jaeger", "stdout", "none"} {
+		provider, err := NewTracerProvider(context.Background(), TracerConfig{
+			Exporter:    exporter,
+			OTLP:        OTLPConfig{Endpoint: "http://localhost:4317"},
+			SampleRatio: 1,
+		})
+		assert.NoError(t, err, exporter)
+		provider.Shutdown(context.Background())
+	}
+
+	provider, err := NewTracerProvider(context.Background(), TracerConfig{
+		Exporter:    "otlp",
+		OTLP:        OTLPConfig{Endpoint: "http://localhost:4318", Protocol: "http/protobuf"},
+		SampleRatio: 1,
+	})
+	assert.NoError(t, err)
+	provider.Shutdown(context.Background())
+
+	_, err = NewTracerProvider(context.Background(), TracerConfig{Exporter: "zipkin", SampleRatio: 1})
+	assert.Error(t, err)
+	_, err = NewTracerProvider(context.Background(), TracerConfig{Exporter: "none", SampleRatio: 1.5})
+	assert.Error(t, err)
+	_, err = NewTracerProvider(context.Background(), TracerConfig{Exporter: "otlp", OTLP: OTLPConfig{Protocol: "thrift"}})
+	assert.Error(t, err)
+}
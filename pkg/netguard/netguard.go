@@ -0,0 +1,87 @@
+// Package netguard keeps requests to addresses given by clients, such as the
+// URLs of webhook subscriptions, away from the internal network: loopback,
+// private, link-local and other non-public addresses are refused.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+var ErrNotPublic = errors.New("address is not public")
+
+// reserved are the ranges net.IP doesn't report as private nor as
+// non-global: this network, the carrier-grade NAT shared space and the
+// benchmarking networks.
+var reserved = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+	{IP: net.IPv4(198, 18, 0, 0), Mask: net.CIDRMask(15, 32)},
+}
+
+// Lookup resolves the addresses of a host, such as
+// net.DefaultResolver.LookupIPAddr.
+type Lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// IsPublic tells ip is a global unicast address outside of the private and
+// reserved ranges. IPv4 addresses mapped to IPv6 are judged as IPv4.
+func IsPublic(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, network := range reserved {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost fails with ErrNotPublic when host is, or resolves to, an address
+// that isn't public. Hosts may resolve elsewhere later, so connections must
+// be checked too; see Control.
+func CheckHost(ctx context.Context, lookup Lookup, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		return check(ip)
+	}
+
+	addresses, err := lookup(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	for _, address := range addresses {
+		if err := check(address.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control refusing to connect to addresses that
+// aren't public. It runs on the resolved address of every connection, so it
+// also covers hosts resolving to another address after CheckHost, as in DNS
+// rebinding, and redirects.
+func Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s", ErrNotPublic, host)
+	}
+	return check(ip)
+}
+
+func check(ip net.IP) error {
+	if !IsPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrNotPublic, ip)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublic(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"0.1.2.3":              false,
+		"224.0.0.1":            false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:10.0.0.1":      false,
+	}
+
+	for address, want := range cases {
+		t.Run(address, func(t *testing.T) {
+			assert.Equal(t, want, IsPublic(net.ParseIP(address)))
+		})
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	lookup := func(_ context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "merchant.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}}, nil
+		case "internal.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}, {IP: net.ParseIP("10.0.0.5")}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, CheckHost(ctx, lookup, "merchant.example.com"))
+	assert.NoError(t, CheckHost(ctx, lookup, "93.184.215.14"))
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "internal.example.com"), ErrNotPublic)
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "169.254.169.254"), ErrNotPublic)
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "::1"), ErrNotPublic)
+
+	err := CheckHost(ctx, lookup, "unknown.example.com")
+	assert.ErrorContains(t, err, "no such host")
+	assert.NotErrorIs(t, err, ErrNotPublic)
+}
+
+func TestControl(t *testing.T) {
+	assert.NoError(t, Control("tcp4", "93.184.215.14:443", nil))
+	assert.ErrorIs(t, Control("tcp4", "127.0.0.1:9464", nil), ErrNotPublic)
+	assert.ErrorIs(t, Control("tcp6", "[fe80::1]:80", nil), ErrNotPublic)
+	assert.Error(t, Control("tcp", "127.0.0.1", nil))
+}
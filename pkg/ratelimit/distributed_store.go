@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxSwapAttempts bounds how many times DistributedStore retries a Take
+// that lost the race for a bucket to another instance.
+const maxSwapAttempts = 10
+
+// ErrContention is returned when a bucket kept changing under a Take.
+var ErrContention = errors.New("rate limit bucket changed concurrently")
+
+// Backend is the part of a key value store shared by every instance, such
+// as Redis, that DistributedStore needs. Get returns nil for missing keys.
+// CompareAndSwap stores value for ttl only if key still holds old, nil
+// meaning missing, and reports whether it did; on Redis that's a WATCH
+// transaction or a short Lua script.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	CompareAndSwap(ctx context.Context, key string, old, value []byte, ttl time.Duration) (bool, error)
+}
+
+// DistributedStore keeps the buckets in a Backend, so every instance of the
+// API shares the same limits.
+type DistributedStore struct {
+	backend Backend
+	prefix  string
+	now     func() time.Time
+}
+
+// NewDistributedStore returns a DistributedStore keeping each bucket in
+// backend under prefix followed by its key.
+func NewDistributedStore(backend Backend, prefix string) *DistributedStore {
+	return &DistributedStore{
+		backend: backend,
+		prefix:  prefix,
+		now:     time.Now,
+	}
+}
+
+func (s *DistributedStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	key = s.prefix + key
+
+	for attempt := 0; attempt < maxSwapAttempts; attempt++ {
+		now := s.now()
+
+		old, err := s.backend.Get(ctx, key)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to get rate limit bucket: %w", err)
+		}
+
+		b := fullBucket(limit, now)
+		if old != nil {
+			if err := json.Unmarshal(old, &b); err != nil {
+				return Result{}, fmt.Errorf("invalid rate limit bucket %s: %w", key, err)
+			}
+		}
+
+		b, result := b.take(limit, now)
+		value, err := json.Marshal(b)
+		if err != nil {
+			return Result{}, err
+		}
+
+		swapped, err := s.backend.CompareAndSwap(ctx, key, old, value, result.Reset)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to store rate limit bucket: %w", err)
+		}
+		if swapped {
+			return result, nil
+		}
+	}
+
+	return Result{}, ErrContention
+}
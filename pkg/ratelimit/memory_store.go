@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"go-payments-api/pkg/cache"
+	"sync/atomic"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the buckets that refilled,
+// which would otherwise pile up for keys that stopped coming, such as IPs.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the memory of the process, so every
+// instance of the API limits on its own.
+type MemoryStore struct {
+	buckets   *cache.LocalCache
+	now       func() time.Time
+	nextSweep atomic.Int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: cache.NewLocalCache(),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	now := s.now()
+
+	var result Result
+	s.buckets.Update(key, func(value interface{}, found bool) (interface{}, time.Duration) {
+		b, ok := value.(bucket)
+		if !found || !ok {
+			b = fullBucket(limit, now)
+		}
+
+		// Buckets expire once full again, when they are the same as missing
+		b, result = b.take(limit, now)
+		return b, result.Reset
+	})
+
+	s.sweep(now)
+
+	return result, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	next := s.nextSweep.Load()
+	if now.UnixNano() < next || !s.nextSweep.CompareAndSwap(next, now.Add(sweepInterval).UnixNano()) {
+		return
+	}
+	s.buckets.DeleteExpired()
+}
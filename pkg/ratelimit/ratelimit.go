@@ -0,0 +1,130 @@
+// Package ratelimit limits how often something may happen with token
+// buckets kept in a Store: each key gets a bucket of Burst tokens, refilled
+// at Requests every Period, and every Take spends one.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests every Period on average, in bursts of up to Burst.
+// Burst defaults to Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// ParseLimit reads a limit written as "requests/period", e.g. "100/1m",
+// with period in time.ParseDuration format. A bare unit such as "s" stands
+// for one of it.
+func ParseLimit(value string) (Limit, error) {
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid limit %q, want requests/period", value)
+	}
+
+	count, err := strconv.Atoi(requests)
+	if err != nil || count < 1 {
+		return Limit{}, fmt.Errorf("invalid requests in limit %q", value)
+	}
+
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return Limit{}, fmt.Errorf("invalid period in limit %q", value)
+	}
+
+	return Limit{Requests: count, Period: duration}, nil
+}
+
+// IsZero reports whether the limit doesn't limit anything.
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// interval is how long the bucket takes to get one token back.
+func (l Limit) interval() float64 {
+	return float64(l.Period) / float64(l.Requests)
+}
+
+// Rule is a Limit on the requests sharing a key, named by By, such as the
+// client IP.
+type Rule struct {
+	Limit
+	By string
+}
+
+// Result tells whether a Take was allowed and how the bucket was left.
+type Result struct {
+	Allowed bool
+
+	// Limit is the bucket capacity and Remaining the whole tokens left
+	Limit     int
+	Remaining int
+
+	// Reset is how long the bucket takes to be full again
+	Reset time.Duration
+
+	// RetryAfter is how long until a Take would be allowed, 0 when this one
+	// was
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Take spends a token of the bucket of key when it
+// has one, atomically for every caller sharing the store.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket is the state of a key: the tokens it had at Time, in Unix
+// nanoseconds. A missing bucket is a full one.
+type bucket struct {
+	Tokens float64 `json:"tokens"`
+	Time   int64   `json:"time"`
+}
+
+func fullBucket(limit Limit, now time.Time) bucket {
+	return bucket{Tokens: limit.capacity(), Time: now.UnixNano()}
+}
+
+// take refills b up to now and spends a token when there is one.
+func (b bucket) take(limit Limit, now time.Time) (bucket, Result) {
+	capacity, interval := limit.capacity(), limit.interval()
+
+	elapsed := float64(now.UnixNano() - b.Time)
+	if elapsed < 0 {
+		// Another instance with a clock ahead of ours wrote the bucket
+		elapsed = 0
+	}
+
+	b.Tokens = math.Min(capacity, b.Tokens+elapsed/interval)
+	b.Time = now.UnixNano()
+
+	result := Result{Limit: int(capacity)}
+	if b.Tokens >= 1 {
+		b.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - b.Tokens) * interval))
+	}
+
+	result.Remaining = int(b.Tokens)
+	result.Reset = time.Duration(math.Ceil((capacity - b.Tokens) * interval))
+
+	return b, result
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	cases := map[string]Limit{
+		"100/1m": {Requests: 100, Period: time.Minute},
+		"5/s":    {Requests: 5, Period: time.Second},
+		"1/24h":  {Requests: 1, Period: 24 * time.Hour},
+	}
+	for value, want := range cases {
+		limit, err := ParseLimit(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, limit, value)
+	}
+
+	for _, value := range []string{"", "100", "0/1s", "x/1s", "10/", "10/0s", "10/-1s"} {
+		_, err := ParseLimit(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// clock is a time source moved by hand.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time                 { return c.now }
+func (c *clock) Advance(duration time.Duration) { c.now = c.now.Add(duration) }
+
+func TestMemoryStoreTake(t *testing.T) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	store := NewMemoryStore()
+	store.now = c.Now
+
+	limit := Limit{Requests: 2, Period: time.Second, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "key", limit)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, _ := store.Take(context.Background(), "key", limit)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, result.Reset)
+
+	// Other keys have buckets of their own
+	result, _ = store.Take(context.Background(), "other", limit)
+	assert.True(t, result.Allowed)
+
+	c.Advance(500 * time.Millisecond)
+	result, _ = store.Take(context.Background(), "key", limit)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Refills stop at the burst
+	c.Advance(time.Hour)
+	result, _ = store.Take(context.Background(), "key", limit)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+// backend is an in-memory Backend standing in for a shared store.
+type backend struct {
+	mu     sync.Mutex
+	values map[string][]byte
+
+	// conflicts makes the next CompareAndSwap calls fail as if another
+	// instance had written first
+	conflicts int
+}
+
+func (b *backend) Get(_ context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.values[key], nil
+}
+
+func (b *backend) CompareAndSwap(_ context.Context, key string, old, value []byte, _ time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conflicts > 0 {
+		b.conflicts--
+		return false, nil
+	}
+	if !bytes.Equal(b.values[key], old) {
+		return false, nil
+	}
+	b.values[key] = value
+	return true, nil
+}
+
+func TestDistributedStoreTake(t *testing.T) {
+	shared := &backend{values: map[string][]byte{}}
+	c := &clock{now: time.Unix(1700000000, 0)}
+
+	// Two instances share the buckets
+	first, second := NewDistributedStore(shared, "ratelimit:"), NewDistributedStore(shared, "ratelimit:")
+	first.now, second.now = c.Now, c.Now
+
+	limit := Limit{Requests: 2, Period: time.Second}
+
+	result, err := first.Take(context.Background(), "key", limit)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Contains(t, shared.values, "ratelimit:key")
+
+	shared.conflicts = 2
+	result, err = second.Take(context.Background(), "key", limit)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, _ = first.Take(context.Background(), "key", limit)
+	assert.False(t, result.Allowed)
+
+	shared.conflicts = maxSwapAttempts
+	_, err = first.Take(context.Background(), "key", limit)
+	assert.ErrorIs(t, err, ErrContention)
+}
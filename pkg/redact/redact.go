@@ -0,0 +1,157 @@
+// Package redact removes sensitive data, such as card numbers, documents,
+// emails and credentials, from what leaves the process in logs, traces and
+// responses.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// Mask is how a sensitive value is replaced.
+type Mask int
+
+const (
+	// MaskFull replaces the whole value with [REDACTED]
+	MaskFull Mask = iota
+	// MaskLast4 keeps the last 4 characters, as allowed for card numbers
+	MaskLast4
+	// MaskHash replaces the value with a keyed hash of it, so lines about
+	// the same value can still be correlated
+	MaskHash
+	// MaskNone leaves the value as it is, for fields known to be safe that
+	// rules could mistake for sensitive data
+	MaskNone
+)
+
+const redacted = "[REDACTED]"
+
+// Rule finds sensitive data in free text, such as messages and errors.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	// Valid filters the matches of Pattern, such as the card numbers
+	// passing the Luhn check, when set
+	Valid func(match string) bool
+	Mask  Mask
+}
+
+type Config struct {
+	// Fields maps field names to the mask of their values. Names are
+	// compared in lower case with dots, dashes and spaces as underscores,
+	// and match fields named as them or ending in _name, so card_number
+	// matches payment.card.number
+	Fields map[string]Mask
+	Rules  []Rule
+	// HashKey keys the hashes of MaskHash. A random key is used when it's
+	// empty, so hashes only match within the process
+	HashKey []byte
+}
+
+// Redactor replaces the sensitive data of values by the name of their field
+// and of free text by its rules.
+type Redactor struct {
+	fields  map[string]Mask
+	rules   []Rule
+	hashKey []byte
+}
+
+// Default is the Redactor of the application, used by the logger, the span
+// helpers and the presenter.
+var Default = New(Config{Fields: DefaultFields(), Rules: DefaultRules()})
+
+func New(config Config) *Redactor {
+	r := &Redactor{
+		fields:  make(map[string]Mask, len(config.Fields)),
+		rules:   config.Rules,
+		hashKey: config.HashKey,
+	}
+	for name, mask := range config.Fields {
+		r.fields[normalize(name)] = mask
+	}
+
+	if len(r.hashKey) == 0 {
+		r.hashKey = make([]byte, 32)
+		if _, err := rand.Read(r.hashKey); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+// String redacts s with the rules, as String of Default.
+func String(s string) string {
+	return Default.String(s)
+}
+
+// Field redacts the value of the field name, as Field of Default.
+func Field(name, value string) string {
+	return Default.Field(name, value)
+}
+
+// String returns s with every match of the rules masked.
+func (r *Redactor) String(s string) string {
+	for _, rule := range r.rules {
+		rule := rule
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.Valid != nil && !rule.Valid(match) {
+				return match
+			}
+			return r.Mask(match, rule.Mask)
+		})
+	}
+	return s
+}
+
+// Field returns value masked when name is a sensitive field and redacted
+// with the rules otherwise.
+func (r *Redactor) Field(name, value string) string {
+	if mask, ok := r.Sensitive(name); ok {
+		return r.Mask(value, mask)
+	}
+	return r.String(value)
+}
+
+// Sensitive returns the mask of the field name, if it has one.
+func (r *Redactor) Sensitive(name string) (Mask, bool) {
+	name = normalize(name)
+	if mask, ok := r.fields[name]; ok {
+		return mask, true
+	}
+	for field, mask := range r.fields {
+		if strings.HasSuffix(name, "_"+field) {
+			return mask, true
+		}
+	}
+	return 0, false
+}
+
+// Mask returns value replaced as mask sets.
+func (r *Redactor) Mask(value string, mask Mask) string {
+	switch mask {
+	case MaskNone:
+		return value
+	case MaskLast4:
+		if len(value) < 8 {
+			return redacted
+		}
+		return "****" + value[len(value)-4:]
+	case MaskHash:
+		if value == "" {
+			return value
+		}
+		h := hmac.New(sha256.New, r.hashKey)
+		h.Write([]byte(value))
+		return "[hash:" + hex.EncodeToString(h.Sum(nil))[:16] + "]"
+	}
+	return redacted
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
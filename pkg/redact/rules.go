@@ -0,0 +1,135 @@
+package redact
+
+import "regexp"
+
+// DefaultFields are the names of the fields holding card data, documents,
+// contacts, PIX keys and credentials.
+func DefaultFields() map[string]Mask {
+	return map[string]Mask{
+		"pan":             MaskLast4,
+		"card_number":     MaskLast4,
+		"cvv":             MaskFull,
+		"cvc":             MaskFull,
+		"security_code":   MaskFull,
+		"pin":             MaskFull,
+		"expiry":          MaskFull,
+		"card_expiration": MaskFull,
+		"password":        MaskFull,
+		"secret":          MaskFull,
+		"token":           MaskFull,
+		"authorization":   MaskFull,
+		"api_key":         MaskFull,
+		"key_hash":        MaskFull,
+		"pix_key":         MaskHash,
+		"cpf":             MaskHash,
+		"cnpj":            MaskHash,
+		"document":        MaskHash,
+		"email":           MaskHash,
+		"phone":           MaskHash,
+		"trace_id":        MaskNone,
+		"span_id":         MaskNone,
+	}
+}
+
+var (
+	jwtPattern    = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	cnpjPattern   = regexp.MustCompile(`\b\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2}\b`)
+	cpfPattern    = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`)
+	panPattern    = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// DefaultRules find tokens, emails, CPFs, CNPJs and card numbers in free
+// text. Documents and card numbers are only masked when their check digits
+// are valid, which leaves most IDs and amounts alone.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: "jwt", Pattern: jwtPattern, Mask: MaskFull},
+		{Name: "bearer", Pattern: bearerPattern, Mask: MaskFull},
+		{Name: "email", Pattern: emailPattern, Mask: MaskHash},
+		{Name: "cnpj", Pattern: cnpjPattern, Valid: validCNPJ, Mask: MaskHash},
+		{Name: "cpf", Pattern: cpfPattern, Valid: validCPF, Mask: MaskHash},
+		{Name: "pan", Pattern: panPattern, Valid: validPAN, Mask: MaskLast4},
+	}
+}
+
+func digits(s string) []int {
+	d := make([]int, 0, len(s))
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			d = append(d, int(c-'0'))
+		}
+	}
+	return d
+}
+
+// validPAN runs the Luhn check on the digits of s.
+func validPAN(s string) bool {
+	d := digits(s)
+	if len(d) < 13 || len(d) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := len(d) - 1; i >= 0; i-- {
+		n := d[i]
+		if (len(d)-i)%2 == 0 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+func validCPF(s string) bool {
+	d := digits(s)
+	if len(d) != 11 || repeated(d) {
+		return false
+	}
+	return checkDigit(d[:9], 10) == d[9] && checkDigit(d[:10], 11) == d[10]
+}
+
+func validCNPJ(s string) bool {
+	d := digits(s)
+	if len(d) != 14 || repeated(d) {
+		return false
+	}
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return weightedCheckDigit(d[:12], weights[1:]) == d[12] && weightedCheckDigit(d[:13], weights) == d[13]
+}
+
+// checkDigit is the modulo 11 check digit of d weighted from weight down to
+// 2, as CPFs use.
+func checkDigit(d []int, weight int) int {
+	weights := make([]int, len(d))
+	for i := range d {
+		weights[i] = weight - i
+	}
+	return weightedCheckDigit(d, weights)
+}
+
+func weightedCheckDigit(d, weights []int) int {
+	sum := 0
+	for i, n := range d {
+		sum += n * weights[i]
+	}
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+	return 0
+}
+
+// repeated reports whether every digit of d is the same, as 111.111.111-11,
+// which passes the check digits without being a document.
+func repeated(d []int) bool {
+	for _, n := range d[1:] {
+		if n != d[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+// avroSchema is a parsed Avro schema with its named types indexed by both
+// full and short name.
+type avroSchema struct {
+	root  interface{}
+	named map[string]map[string]interface{}
+}
+
+func parseAvro(schema string) (*avroSchema, error) {
+	if _, err := goavro.NewCodec(schema); err != nil {
+		return nil, err
+	}
+
+	var root interface{}
+	if err := json.Unmarshal([]byte(schema), &root); err != nil {
+		return nil, err
+	}
+
+	parsed := &avroSchema{root: root, named: map[string]map[string]interface{}{}}
+	parsed.collect(root, "")
+	return parsed, nil
+}
+
+func (s *avroSchema) collect(node interface{}, namespace string) {
+	switch node := node.(type) {
+	case []interface{}:
+		for _, branch := range node {
+			s.collect(branch, namespace)
+		}
+	case map[string]interface{}:
+		if name, ok := node["name"].(string); ok {
+			if ns, ok := node["namespace"].(string); ok {
+				namespace = ns
+			}
+			fullName := name
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				namespace = name[:i]
+			} else if namespace != "" {
+				fullName = namespace + "." + name
+			}
+			s.named[fullName] = node
+			s.named[shortName(fullName)] = node
+		}
+
+		for _, key := range []string{"items", "values"} {
+			if child, ok := node[key]; ok {
+				s.collect(child, namespace)
+			}
+		}
+		if fields, ok := node["fields"].([]interface{}); ok {
+			for _, field := range fields {
+				if field, ok := field.(map[string]interface{}); ok {
+					s.collect(field["type"], namespace)
+				}
+			}
+		}
+		if inner, ok := node["type"].(map[string]interface{}); ok {
+			s.collect(inner, namespace)
+		}
+	}
+}
+
+// resolve replaces references to named types with their definitions and
+// unwraps primitives written as {"type": "long", ...}.
+func (s *avroSchema) resolve(node interface{}) interface{} {
+	switch typed := node.(type) {
+	case string:
+		if definition, ok := s.named[typed]; ok {
+			return definition
+		}
+	case map[string]interface{}:
+		if inner, ok := typed["type"].(string); ok && avroPrimitives[inner] {
+			return inner
+		}
+		if inner, ok := typed["type"].(map[string]interface{}); ok {
+			return s.resolve(inner)
+		}
+	}
+	return node
+}
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+// avroPromotions lists the writer types each reader type can also read.
+var avroPromotions = map[string][]string{
+	"long":   {"int"},
+	"float":  {"int", "long"},
+	"double": {"int", "long", "float"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+func avroBackward(previous, next string) error {
+	writer, err := parseAvro(previous)
+	if err != nil {
+		return err
+	}
+	reader, err := parseAvro(next)
+	if err != nil {
+		return err
+	}
+
+	resolution := &avroResolution{reader: reader, writer: writer, seen: map[string]bool{}}
+	return resolution.match(reader.root, writer.root, "$")
+}
+
+// avroResolution applies the Avro schema resolution rules to decide whether
+// data written with writer can be read with reader.
+type avroResolution struct {
+	reader, writer *avroSchema
+	seen           map[string]bool
+}
+
+func (r *avroResolution) match(reader, writer interface{}, path string) error {
+	reader, writer = r.reader.resolve(reader), r.writer.resolve(writer)
+
+	if union, ok := writer.([]interface{}); ok {
+		for _, branch := range union {
+			if err := r.match(reader, branch, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if union, ok := reader.([]interface{}); ok {
+		for _, branch := range union {
+			if r.match(branch, writer, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %s is missing from the union", path, avroTypeOf(writer))
+	}
+
+	readerType, writerType := avroTypeOf(reader), avroTypeOf(writer)
+	if readerType != writerType {
+		for _, promoted := range avroPromotions[readerType] {
+			if promoted == writerType {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %s can't be read as %s", path, writerType, readerType)
+	}
+
+	readerNode, _ := reader.(map[string]interface{})
+	writerNode, _ := writer.(map[string]interface{})
+
+	switch readerType {
+	case "record", "enum", "fixed":
+		readerName, _ := readerNode["name"].(string)
+		writerName, _ := writerNode["name"].(string)
+		if shortName(readerName) != shortName(writerName) {
+			return fmt.Errorf("%s: %s was renamed to %s", path, writerName, readerName)
+		}
+	}
+
+	switch readerType {
+	case "record":
+		// Recursive records would otherwise be compared forever
+		key := path + "|" + readerNode["name"].(string)
+		if r.seen[key] {
+			return nil
+		}
+		r.seen[key] = true
+		return r.matchRecord(readerNode, writerNode, path)
+	case "enum":
+		symbols := map[string]bool{}
+		for _, symbol := range stringsOf(readerNode["symbols"]) {
+			symbols[symbol] = true
+		}
+		if _, ok := readerNode["default"]; ok {
+			return nil
+		}
+		for _, symbol := range stringsOf(writerNode["symbols"]) {
+			if !symbols[symbol] {
+				return fmt.Errorf("%s: symbol %s was removed", path, symbol)
+			}
+		}
+	case "fixed":
+		if readerNode["size"] != writerNode["size"] {
+			return fmt.Errorf("%s: fixed size changed", path)
+		}
+	case "array":
+		return r.match(readerNode["items"], writerNode["items"], path+"[]")
+	case "map":
+		return r.match(readerNode["values"], writerNode["values"], path+"{}")
+	}
+
+	return nil
+}
+
+// matchRecord checks every reader field can be filled from the writer's
+// record: either the writer has it or the reader has a default.
+func (r *avroResolution) matchRecord(reader, writer map[string]interface{}, path string) error {
+	written := map[string]map[string]interface{}{}
+	for _, field := range fieldsOf(writer) {
+		written[field["name"].(string)] = field
+	}
+
+	for _, field := range fieldsOf(reader) {
+		name := field["name"].(string)
+		source, ok := written[name]
+		for _, alias := range stringsOf(field["aliases"]) {
+			if ok {
+				break
+			}
+			source, ok = written[alias]
+		}
+
+		if !ok {
+			if _, hasDefault := field["default"]; !hasDefault {
+				return fmt.Errorf("%s.%s was added without a default", path, name)
+			}
+			continue
+		}
+
+		if err := r.match(field["type"], source["type"], path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func avroTypeOf(node interface{}) string {
+	switch typed := node.(type) {
+	case string:
+		return typed
+	case map[string]interface{}:
+		name, _ := typed["type"].(string)
+		return name
+	}
+	return "union"
+}
+
+func fieldsOf(record map[string]interface{}) []map[string]interface{} {
+	var fields []map[string]interface{}
+	list, _ := record["fields"].([]interface{})
+	for _, field := range list {
+		if field, ok := field.(map[string]interface{}); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func stringsOf(node interface{}) []string {
+	var values []string
+	list, _ := node.([]interface{})
+	for _, value := range list {
+		if value, ok := value.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
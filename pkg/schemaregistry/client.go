@@ -0,0 +1,150 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go-payments-api/pkg/http"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Client is a Registry backed by the Confluent Schema Registry REST API.
+// The registry enforces the subject's compatibility level itself; Register
+// reports its rejections as ErrIncompatible.
+type Client struct {
+	baseURL  string
+	username string
+	password string
+	http     http.Wrapper
+
+	mu  sync.Mutex
+	ids map[int]*Schema
+}
+
+func NewClient(baseURL, username, password string, wrapper http.Wrapper) *Client {
+	return &Client{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		username: username,
+		password: password,
+		http:     wrapper,
+		ids:      map[int]*Schema{},
+	}
+}
+
+type registryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+type registrySchema struct {
+	ID         int    `json:"id,omitempty"`
+	Subject    string `json:"subject,omitempty"`
+	Version    int    `json:"version,omitempty"`
+	SchemaType string `json:"schemaType,omitempty"`
+	Schema     string `json:"schema"`
+}
+
+func (c *Client) Register(ctx context.Context, subject string, schemaType SchemaType, schema string) (int, error) {
+	request := registrySchema{Schema: schema}
+	// The registry defaults to Avro and older versions reject the field
+	if schemaType != Avro {
+		request.SchemaType = string(schemaType)
+	}
+
+	var registered registrySchema
+	if err := c.do(ctx, nethttp.MethodPost, "/subjects/"+url.PathEscape(subject)+"/versions", request, &registered); err != nil {
+		return 0, err
+	}
+
+	return registered.ID, nil
+}
+
+func (c *Client) GetByID(ctx context.Context, id int) (*Schema, error) {
+	c.mu.Lock()
+	cached, ok := c.ids[id]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var found registrySchema
+	if err := c.do(ctx, nethttp.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, &found); err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{ID: id, Type: schemaTypeOf(found.SchemaType), Schema: found.Schema}
+
+	// Schemas are immutable once registered, so IDs can be cached forever
+	c.mu.Lock()
+	c.ids[id] = schema
+	c.mu.Unlock()
+
+	return schema, nil
+}
+
+func (c *Client) Latest(ctx context.Context, subject string) (*Schema, error) {
+	var found registrySchema
+	if err := c.do(ctx, nethttp.MethodGet, "/subjects/"+url.PathEscape(subject)+"/versions/latest", nil, &found); err != nil {
+		return nil, err
+	}
+
+	return &Schema{
+		ID:      found.ID,
+		Subject: found.Subject,
+		Version: found.Version,
+		Type:    schemaTypeOf(found.SchemaType),
+		Schema:  found.Schema,
+	}, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	request := http.Request{Headers: map[string]string{
+		"Accept":       contentType,
+		"Content-Type": contentType,
+	}}
+	if c.username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(c.username + ":" + c.password))
+		request.Headers["Authorization"] = "Basic " + credentials
+	}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		request.Body = encoded
+	}
+
+	response, err := c.http.Request(ctx, method, c.baseURL+path, request)
+	if err != nil {
+		return fmt.Errorf("schema registry %s %s: %w", method, path, err)
+	}
+
+	if response.StatusCode >= 300 {
+		var failure registryError
+		_ = json.Unmarshal(response.Body, &failure)
+
+		switch response.StatusCode {
+		case nethttp.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrNotFound, failure.Message)
+		case nethttp.StatusConflict:
+			return fmt.Errorf("%w: %s", ErrIncompatible, failure.Message)
+		}
+		return fmt.Errorf("schema registry %s %s returned %d: %s", method, path, response.StatusCode, failure.Message)
+	}
+
+	return json.Unmarshal(response.Body, out)
+}
+
+func schemaTypeOf(name string) SchemaType {
+	if name == "" {
+		return Avro
+	}
+	return SchemaType(name)
+}
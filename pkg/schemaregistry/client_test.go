@@ -0,0 +1,77 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-payments-api/pkg/http"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		requests++
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "secret", password)
+		w.Header().Set("Content-Type", contentType)
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /subjects/payment.events-value/versions":
+			body, _ := io.ReadAll(r.Body)
+			var request map[string]string
+			assert.NoError(t, json.Unmarshal(body, &request))
+			assert.Equal(t, "PROTOBUF", request["schemaType"])
+
+			if request["schema"] != protoPayment {
+				w.WriteHeader(nethttp.StatusConflict)
+				w.Write([]byte(`{"error_code":409,"message":"field 2 changed type"}`))
+				return
+			}
+			w.Write([]byte(`{"id":7}`))
+		case "GET /schemas/ids/7":
+			w.Write([]byte(`{"schemaType":"PROTOBUF","schema":` + quote(protoPayment) + `}`))
+		case "GET /subjects/payment.events-value/versions/latest":
+			w.Write([]byte(`{"subject":"payment.events-value","id":7,"version":3,"schemaType":"PROTOBUF","schema":` + quote(protoPayment) + `}`))
+		default:
+			w.WriteHeader(nethttp.StatusNotFound)
+			w.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(server.URL+"/", "user", "secret", http.NewWrapper())
+
+	id, err := client.Register(ctx, "payment.events-value", Protobuf, protoPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+
+	_, err = client.Register(ctx, "payment.events-value", Protobuf, `syntax = "proto3";`)
+	assert.ErrorIs(t, err, ErrIncompatible)
+
+	for i := 0; i < 2; i++ {
+		schema, err := client.GetByID(ctx, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, &Schema{ID: 7, Type: Protobuf, Schema: protoPayment}, schema)
+	}
+	assert.Equal(t, 3, requests, "GetByID should be cached")
+
+	latest, err := client.Latest(ctx, "payment.events-value")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, latest.Version)
+
+	_, err = client.GetByID(ctx, 8)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func quote(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
@@ -0,0 +1,38 @@
+package schemaregistry
+
+import "fmt"
+
+// Validate checks that schema parses as schemaType.
+func Validate(schemaType SchemaType, schema string) error {
+	var err error
+	switch schemaType {
+	case Avro:
+		_, err = parseAvro(schema)
+	case Protobuf:
+		_, err = compileProto(schema)
+	case JSON:
+		_, err = parseJSONSchema(schema)
+	default:
+		return fmt.Errorf("unsupported schema type %q", schemaType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s schema: %w", schemaType, err)
+	}
+	return nil
+}
+
+// CheckBackward checks that consumers using next can read data written with
+// previous, i.e. that next may be registered after previous in BACKWARD mode.
+func CheckBackward(schemaType SchemaType, previous, next string) error {
+	switch schemaType {
+	case Avro:
+		return avroBackward(previous, next)
+	case Protobuf:
+		return protobufBackward(previous, next)
+	case JSON:
+		return jsonSchemaBackward(previous, next)
+	default:
+		return fmt.Errorf("unsupported schema type %q", schemaType)
+	}
+}
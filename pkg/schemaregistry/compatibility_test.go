@@ -0,0 +1,155 @@
+package schemaregistry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const avroPayment = `{"type":"record","name":"Payment","namespace":"payments","fields":[
+	{"name":"id","type":"long"},
+	{"name":"status","type":{"type":"enum","name":"Status","symbols":["PENDING","COMPLETED"]}},
+	{"name":"amount","type":"int"}
+]}`
+
+const protoPayment = `syntax = "proto3";
+package payments;
+message Payment {
+  int64 id = 1;
+  string status = 2;
+  int32 amount = 3;
+}`
+
+const jsonPayment = `{"type":"object","required":["id"],"properties":{
+	"id":{"type":"integer"},
+	"status":{"type":"string"}
+}}`
+
+func TestCheckBackward(t *testing.T) {
+	cases := map[string]struct {
+		schemaType SchemaType
+		previous   string
+		next       string
+		compatible bool
+	}{
+		"avro field with default added": {
+			schemaType: Avro,
+			previous:   avroPayment,
+			next: `{"type":"record","name":"Payment","namespace":"payments","fields":[
+				{"name":"id","type":"long"},
+				{"name":"status","type":{"type":"enum","name":"Status","symbols":["PENDING","COMPLETED"]}},
+				{"name":"amount","type":"int"},
+				{"name":"reason","type":["null","string"],"default":null}
+			]}`,
+			compatible: true,
+		},
+		"avro field removed and type promoted": {
+			schemaType: Avro,
+			previous:   avroPayment,
+			next: `{"type":"record","name":"Payment","namespace":"payments","fields":[
+				{"name":"id","type":"long"},
+				{"name":"amount","type":"double"}
+			]}`,
+			compatible: true,
+		},
+		"avro field without default added": {
+			schemaType: Avro,
+			previous:   avroPayment,
+			next: `{"type":"record","name":"Payment","namespace":"payments","fields":[
+				{"name":"id","type":"long"},
+				{"name":"reason","type":"string"}
+			]}`,
+		},
+		"avro type narrowed": {
+			schemaType: Avro,
+			previous:   avroPayment,
+			next: `{"type":"record","name":"Payment","namespace":"payments","fields":[
+				{"name":"id","type":"int"}
+			]}`,
+		},
+		"avro enum symbol removed": {
+			schemaType: Avro,
+			previous:   avroPayment,
+			next: `{"type":"record","name":"Payment","namespace":"payments","fields":[
+				{"name":"status","type":{"type":"enum","name":"Status","symbols":["PENDING"]}}
+			]}`,
+		},
+		"protobuf field added and removed": {
+			schemaType: Protobuf,
+			previous:   protoPayment,
+			next: `syntax = "proto3";
+				package payments;
+				message Payment {
+				  int64 id = 1;
+				  int64 amount = 3;
+				  string reason = 4;
+				}`,
+			compatible: true,
+		},
+		"protobuf field encoding changed": {
+			schemaType: Protobuf,
+			previous:   protoPayment,
+			next: `syntax = "proto3";
+				package payments;
+				message Payment {
+				  int64 id = 1;
+				  int64 status = 2;
+				}`,
+		},
+		"json optional property added": {
+			schemaType: JSON,
+			previous:   jsonPayment,
+			next: `{"type":"object","required":["id"],"properties":{
+				"id":{"type":"number"},
+				"status":{"type":"string"},
+				"reason":{"type":"string"}
+			}}`,
+			compatible: true,
+		},
+		"json property made required": {
+			schemaType: JSON,
+			previous:   jsonPayment,
+			next: `{"type":"object","required":["id","status"],"properties":{
+				"id":{"type":"integer"},
+				"status":{"type":"string"}
+			}}`,
+		},
+		"json property type changed": {
+			schemaType: JSON,
+			previous:   jsonPayment,
+			next: `{"type":"object","required":["id"],"properties":{
+				"id":{"type":"string"}
+			}}`,
+		},
+		"json property removed from closed object": {
+			schemaType: JSON,
+			previous:   jsonPayment,
+			next: `{"type":"object","required":["id"],"additionalProperties":false,"properties":{
+				"id":{"type":"integer"}
+			}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckBackward(tc.schemaType, tc.previous, tc.next)
+
+			if tc.compatible {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(Avro, avroPayment))
+	assert.NoError(t, Validate(Protobuf, protoPayment))
+	assert.NoError(t, Validate(JSON, jsonPayment))
+
+	assert.Error(t, Validate(Avro, `{"type":"record","name":"Payment"}`))
+	assert.Error(t, Validate(Protobuf, `message Payment { int64 id = 1 }`))
+	assert.Error(t, Validate(JSON, `[]`))
+	assert.Error(t, Validate("XML", `<schema/>`))
+}
@@ -0,0 +1,90 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func parseJSONSchema(schema string) (map[string]interface{}, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// jsonSchemaBackward checks that every document valid under previous is
+// still valid under next, within the subset of JSON Schema the registry
+// understands: type, properties, required, additionalProperties and items.
+func jsonSchemaBackward(previous, next string) error {
+	writer, err := parseJSONSchema(previous)
+	if err != nil {
+		return err
+	}
+	reader, err := parseJSONSchema(next)
+	if err != nil {
+		return err
+	}
+
+	return matchJSONSchema(reader, writer, "$")
+}
+
+func matchJSONSchema(reader, writer map[string]interface{}, path string) error {
+	accepted := map[string]bool{}
+	for _, name := range jsonTypes(reader) {
+		accepted[name] = true
+	}
+	for _, name := range jsonTypes(writer) {
+		if len(accepted) > 0 && !accepted[name] && !(name == "integer" && accepted["number"]) {
+			return fmt.Errorf("%s: type %s is no longer accepted", path, name)
+		}
+	}
+
+	required := map[string]bool{}
+	for _, name := range stringsOf(writer["required"]) {
+		required[name] = true
+	}
+	for _, name := range stringsOf(reader["required"]) {
+		if !required[name] {
+			return fmt.Errorf("%s.%s is now required", path, name)
+		}
+	}
+
+	readerProperties, _ := reader["properties"].(map[string]interface{})
+	writerProperties, _ := writer["properties"].(map[string]interface{})
+
+	if closed, ok := reader["additionalProperties"].(bool); ok && !closed {
+		for name := range writerProperties {
+			if _, ok := readerProperties[name]; !ok {
+				return fmt.Errorf("%s.%s was removed but additional properties aren't allowed", path, name)
+			}
+		}
+	}
+
+	for name, property := range readerProperties {
+		readerProperty, _ := property.(map[string]interface{})
+		writerProperty, _ := writerProperties[name].(map[string]interface{})
+		if readerProperty == nil || writerProperty == nil {
+			continue
+		}
+		if err := matchJSONSchema(readerProperty, writerProperty, path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	readerItems, _ := reader["items"].(map[string]interface{})
+	writerItems, _ := writer["items"].(map[string]interface{})
+	if readerItems != nil && writerItems != nil {
+		return matchJSONSchema(readerItems, writerItems, path+"[]")
+	}
+
+	return nil
+}
+
+// jsonTypes returns the schema's "type", which may be a name or a list.
+func jsonTypes(schema map[string]interface{}) []string {
+	if name, ok := schema["type"].(string); ok {
+		return []string{name}
+	}
+	return stringsOf(schema["type"])
+}
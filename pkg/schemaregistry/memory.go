@@ -0,0 +1,97 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// InMemory is a Registry kept in process memory. Like the Confluent
+// registry's default BACKWARD mode, a new version must be readable by
+// consumers of the subject's latest version.
+type InMemory struct {
+	mu       sync.Mutex
+	schemas  []*Schema
+	subjects map[string][]*Schema
+}
+
+func NewInMemory() *InMemory {
+	return &InMemory{subjects: map[string][]*Schema{}}
+}
+
+func (r *InMemory) Register(_ context.Context, subject string, schemaType SchemaType, schema string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.subjects[subject]
+	for _, version := range versions {
+		if version.Type == schemaType && version.Schema == schema {
+			return version.ID, nil
+		}
+	}
+
+	if err := Validate(schemaType, schema); err != nil {
+		return 0, err
+	}
+
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		if latest.Type != schemaType {
+			return 0, fmt.Errorf("%w: %s changes type from %s to %s", ErrIncompatible, subject, latest.Type, schemaType)
+		}
+		if err := CheckBackward(schemaType, latest.Schema, schema); err != nil {
+			return 0, fmt.Errorf("%w: %s: %v", ErrIncompatible, subject, err)
+		}
+	}
+
+	id := r.idOf(schemaType, schema)
+	registered := &Schema{
+		ID:      id,
+		Subject: subject,
+		Version: len(versions) + 1,
+		Type:    schemaType,
+		Schema:  schema,
+	}
+	r.subjects[subject] = append(versions, registered)
+	if id > len(r.schemas) {
+		r.schemas = append(r.schemas, registered)
+	}
+
+	return id, nil
+}
+
+// idOf returns the ID schema already has under another subject, or the next
+// free one.
+func (r *InMemory) idOf(schemaType SchemaType, schema string) int {
+	for _, known := range r.schemas {
+		if known.Type == schemaType && known.Schema == schema {
+			return known.ID
+		}
+	}
+	return len(r.schemas) + 1
+}
+
+func (r *InMemory) GetByID(_ context.Context, id int) (*Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id < 1 || id > len(r.schemas) {
+		return nil, fmt.Errorf("%w: id %d", ErrNotFound, id)
+	}
+
+	schema := *r.schemas[id-1]
+	return &schema, nil
+}
+
+func (r *InMemory) Latest(_ context.Context, subject string) (*Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.subjects[subject]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: subject %s", ErrNotFound, subject)
+	}
+
+	schema := *versions[len(versions)-1]
+	return &schema, nil
+}
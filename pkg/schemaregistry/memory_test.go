@@ -0,0 +1,71 @@
+package schemaregistry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryRegister(t *testing.T) {
+	ctx := context.Background()
+	registry := NewInMemory()
+
+	id, err := registry.Register(ctx, "payments-value", Avro, avroPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	again, err := registry.Register(ctx, "payments-value", Avro, avroPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	shared, err := registry.Register(ctx, "payments.dlq-value", Avro, avroPayment)
+	assert.NoError(t, err)
+	assert.Equal(t, id, shared)
+
+	evolved := `{"type":"record","name":"Payment","namespace":"payments","fields":[
+		{"name":"id","type":"long"},
+		{"name":"amount","type":"long"}
+	]}`
+	next, err := registry.Register(ctx, "payments-value", Avro, evolved)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, next)
+
+	latest, err := registry.Latest(ctx, "payments-value")
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{ID: 2, Subject: "payments-value", Version: 2, Type: Avro, Schema: evolved}, latest)
+
+	found, err := registry.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, avroPayment, found.Schema)
+}
+
+func TestInMemoryRegisterIncompatible(t *testing.T) {
+	ctx := context.Background()
+	registry := NewInMemory()
+
+	_, err := registry.Register(ctx, "payments-value", Avro, avroPayment)
+	assert.NoError(t, err)
+
+	_, err = registry.Register(ctx, "payments-value", Avro, `{"type":"record","name":"Payment","namespace":"payments","fields":[
+		{"name":"id","type":"string"}
+	]}`)
+	assert.ErrorIs(t, err, ErrIncompatible)
+
+	_, err = registry.Register(ctx, "payments-value", Protobuf, protoPayment)
+	assert.ErrorIs(t, err, ErrIncompatible)
+
+	latest, err := registry.Latest(ctx, "payments-value")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, latest.Version)
+}
+
+func TestInMemoryNotFound(t *testing.T) {
+	registry := NewInMemory()
+
+	_, err := registry.GetByID(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = registry.Latest(context.Background(), "payments-value")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,131 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const protoFileName = "schema.proto"
+
+// compileProto compiles a self-contained .proto schema. Imports other than
+// the well-known google/protobuf types aren't supported.
+func compileProto(schema string) (protoreflect.FileDescriptor, error) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{protoFileName: schema}),
+		}),
+	}
+
+	files, err := compiler.Compile(context.Background(), protoFileName)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// protobufBackward checks that no field kept its number but changed to a
+// type with a different wire encoding. Removed and added fields are always
+// readable: protobuf skips unknown fields and defaults missing ones.
+func protobufBackward(previous, next string) error {
+	writer, err := compileProto(previous)
+	if err != nil {
+		return err
+	}
+	reader, err := compileProto(next)
+	if err != nil {
+		return err
+	}
+
+	written := map[protoreflect.FullName]protoreflect.MessageDescriptor{}
+	walkMessages(writer.Messages(), func(message protoreflect.MessageDescriptor) error {
+		written[message.FullName()] = message
+		return nil
+	})
+
+	return walkMessages(reader.Messages(), func(message protoreflect.MessageDescriptor) error {
+		source, ok := written[message.FullName()]
+		if !ok {
+			return nil
+		}
+
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			old := source.Fields().ByNumber(field.Number())
+			if old == nil {
+				continue
+			}
+			if !wireCompatible(old, field) {
+				return fmt.Errorf("%s: field %d changed from %s to %s",
+					message.FullName(), field.Number(), describeField(old), describeField(field))
+			}
+		}
+		return nil
+	})
+}
+
+func walkMessages(messages protoreflect.MessageDescriptors, fn func(protoreflect.MessageDescriptor) error) error {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		if err := fn(message); err != nil {
+			return err
+		}
+		if err := walkMessages(message.Messages(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoWireGroups maps each kind to the kinds sharing its encoding.
+var protoWireGroups = map[protoreflect.Kind]int{
+	protoreflect.Int32Kind:    1,
+	protoreflect.Uint32Kind:   1,
+	protoreflect.Int64Kind:    1,
+	protoreflect.Uint64Kind:   1,
+	protoreflect.BoolKind:     1,
+	protoreflect.EnumKind:     1,
+	protoreflect.Sint32Kind:   2,
+	protoreflect.Sint64Kind:   2,
+	protoreflect.Fixed32Kind:  3,
+	protoreflect.Sfixed32Kind: 3,
+	protoreflect.Fixed64Kind:  4,
+	protoreflect.Sfixed64Kind: 4,
+	protoreflect.StringKind:   5,
+	protoreflect.BytesKind:    5,
+}
+
+func wireCompatible(old, field protoreflect.FieldDescriptor) bool {
+	if old.IsList() != field.IsList() || old.IsMap() != field.IsMap() {
+		return false
+	}
+
+	if old.IsMap() {
+		return wireCompatible(old.MapKey(), field.MapKey()) && wireCompatible(old.MapValue(), field.MapValue())
+	}
+
+	if old.Kind() == field.Kind() {
+		if old.Message() != nil {
+			return old.Message().FullName() == field.Message().FullName()
+		}
+		return true
+	}
+
+	group, ok := protoWireGroups[old.Kind()]
+	return ok && group == protoWireGroups[field.Kind()]
+}
+
+func describeField(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return "map"
+	case field.Message() != nil:
+		return string(field.Message().FullName())
+	case field.IsList():
+		return "repeated " + field.Kind().String()
+	}
+	return field.Kind().String()
+}
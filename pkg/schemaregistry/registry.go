@@ -0,0 +1,41 @@
+// Package schemaregistry resolves the schemas Kafka messages are framed
+// with. Client talks to a Confluent-compatible registry; InMemory stands in
+// for it in tests and local runs. Both refuse schemas that aren't backward
+// compatible with the latest version of their subject.
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+)
+
+type SchemaType string
+
+const (
+	Avro     SchemaType = "AVRO"
+	Protobuf SchemaType = "PROTOBUF"
+	JSON     SchemaType = "JSON"
+)
+
+var (
+	ErrNotFound     = errors.New("schema not found")
+	ErrIncompatible = errors.New("schema is not backward compatible")
+)
+
+// Schema is a registered version of a subject's schema. The ID is global:
+// the same schema registered under two subjects gets the same ID.
+type Schema struct {
+	ID      int
+	Subject string
+	Version int
+	Type    SchemaType
+	Schema  string
+}
+
+type Registry interface {
+	// Register adds schema to subject and returns its ID. Registering a
+	// schema the subject already has returns the existing ID.
+	Register(ctx context.Context, subject string, schemaType SchemaType, schema string) (int, error)
+	GetByID(ctx context.Context, id int) (*Schema, error)
+	Latest(ctx context.Context, subject string) (*Schema, error)
+}
@@ -0,0 +1,43 @@
+package signature
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	signature := Sign("secret", now, body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.NoError(t, Verify("secret", timestamp, signature, body, now.Add(time.Minute), 5*time.Minute))
+
+	cases := map[string]struct {
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		want      error
+	}{
+		"wrong secret":      {secret: "other", timestamp: timestamp, signature: signature, body: body, now: now, want: ErrInvalidSignature},
+		"tampered body":     {secret: "secret", timestamp: timestamp, signature: signature, body: []byte(`{"id":"evt_2"}`), now: now, want: ErrInvalidSignature},
+		"missing prefix":    {secret: "secret", timestamp: timestamp, signature: signature[7:], body: body, now: now, want: ErrInvalidSignature},
+		"not hex":           {secret: "secret", timestamp: timestamp, signature: "sha256=zz", body: body, now: now, want: ErrInvalidSignature},
+		"too old":           {secret: "secret", timestamp: timestamp, signature: signature, body: body, now: now.Add(6 * time.Minute), want: ErrInvalidTimestamp},
+		"from the future":   {secret: "secret", timestamp: timestamp, signature: signature, body: body, now: now.Add(-6 * time.Minute), want: ErrInvalidTimestamp},
+		"invalid timestamp": {secret: "secret", timestamp: "yesterday", signature: signature, body: body, now: now, want: ErrInvalidTimestamp},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Verify(tc.secret, tc.timestamp, tc.signature, tc.body, tc.now, 5*time.Minute)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
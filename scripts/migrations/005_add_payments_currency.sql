@@ -0,0 +1,5 @@
+ALTER TABLE payments ALTER COLUMN amount TYPE NUMERIC(22, 4);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+CREATE INDEX idx_payments_currency_amount ON payments(currency, amount);
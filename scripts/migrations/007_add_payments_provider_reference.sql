@@ -0,0 +1 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS provider_reference VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS pix_charges (
+    payment_id BIGINT PRIMARY KEY REFERENCES payments(id),
+    txid VARCHAR(25) NOT NULL UNIQUE,
+    payload TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pix_charges_expires_at ON pix_charges(expires_at);
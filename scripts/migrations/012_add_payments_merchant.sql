@@ -0,0 +1,3 @@
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS merchant_id BIGINT;
+
+CREATE INDEX idx_payments_merchant_created_at ON payments(merchant_id, created_at DESC, id DESC);
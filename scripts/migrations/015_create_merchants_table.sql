@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS merchants (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    payment_methods TEXT[] NOT NULL DEFAULT '{}',
+    settlement_schedule VARCHAR(20) NOT NULL DEFAULT 'DAILY',
+    settlement_delay_days INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Merchants already referenced by payments or API keys keep taking every method
+INSERT INTO merchants (id, name, payment_methods)
+SELECT merchant_id, 'Merchant ' || merchant_id, '{PIX,CARD}'
+FROM (
+    SELECT merchant_id FROM payments WHERE merchant_id IS NOT NULL
+    UNION
+    SELECT merchant_id FROM api_keys
+) AS referenced
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('merchants', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM merchants;
+
+ALTER TABLE payments ADD CONSTRAINT fk_payments_merchant FOREIGN KEY (merchant_id) REFERENCES merchants(id);
+ALTER TABLE api_keys ADD CONSTRAINT fk_api_keys_merchant FOREIGN KEY (merchant_id) REFERENCES merchants(id);
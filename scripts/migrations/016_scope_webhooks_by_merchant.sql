@@ -0,0 +1,7 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS merchant_id BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS merchant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS merchant_id BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_webhook_subscriptions_merchant ON webhook_subscriptions(merchant_id) WHERE active;
+CREATE INDEX idx_webhook_deliveries_merchant ON webhook_deliveries(merchant_id, id);
@@ -0,0 +1,2 @@
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS payment_id BIGINT;
+ALTER TABLE idempotency_keys ALTER COLUMN response DROP NOT NULL;